@@ -5,7 +5,6 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
@@ -14,7 +13,6 @@ import (
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
-	"github.com/disgoorg/snowflake/v2"
 )
 
 var (
@@ -25,11 +23,21 @@ func main() {
 	slog.Info("starting example...")
 	slog.Info("disgo version", slog.String("version", disgo.Version))
 
+	// the backend can be replaced with any shared key value store to share the cache between processes
+	backend, err := cache.OpenFileBackend("cache.kv")
+	if err != nil {
+		slog.Error("error while opening cache backend", slog.Any("err", err))
+		return
+	}
+	defer backend.Close()
+
+	flags := cache.FlagGuilds | cache.FlagMessages | cache.FlagMembers | cache.FlagRoles
 	client, err := disgo.New(token,
 		bot.WithGatewayConfigOpts(gateway.WithIntents(gateway.IntentGuilds|gateway.IntentGuildMessages|gateway.IntentDirectMessages)),
 		bot.WithCacheConfigOpts(
-			cache.WithCaches(cache.FlagGuilds|cache.FlagMessages|cache.FlagMembers),
-			cache.WithMemberCache(cache.NewMemberCache(newGroupedCache[discord.Member]())),
+			cache.WithCaches(flags),
+			cache.WithMemberCache(cache.NewMemberCache(cache.NewBackendGroupedCache[discord.Member](backend, "members", cache.MemberCodec, flags, cache.FlagMembers, nil))),
+			cache.WithRoleCache(cache.NewRoleCache(cache.NewBackendGroupedCache[discord.Role](backend, "roles", cache.RoleCodec, flags, cache.FlagRoles, nil))),
 		),
 	)
 	if err != nil {
@@ -54,146 +62,3 @@ func main() {
 	signal.Notify(s, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	<-s
 }
-
-var _ cache.GroupedCache[discord.Member] = (*groupedCache[discord.Member])(nil)
-
-func newGroupedCache[T any]() cache.GroupedCache[T] {
-	return &groupedCache[T]{
-		cache: make(map[snowflake.ID]map[snowflake.ID]T),
-	}
-}
-
-type groupedCache[T any] struct {
-	cache map[snowflake.ID]map[snowflake.ID]T
-	mu    sync.Mutex
-}
-
-func (g *groupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	groupEntities, ok := g.cache[groupID]
-	if !ok {
-		var entity T
-		return entity, false
-	}
-
-	entity, ok := groupEntities[id]
-	return entity, ok
-}
-
-func (g *groupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	groupEntities, ok := g.cache[groupID]
-	if !ok {
-		groupEntities = make(map[snowflake.ID]T)
-		g.cache[groupID] = groupEntities
-	}
-
-	groupEntities[id] = entity
-}
-
-func (g *groupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	groupEntities, ok := g.cache[groupID]
-	if !ok {
-		var entity T
-		return entity, false
-	}
-
-	entity, ok := groupEntities[id]
-	if !ok {
-		return entity, false
-	}
-
-	delete(groupEntities, id)
-	return entity, true
-}
-
-func (g *groupedCache[T]) GroupRemove(groupID snowflake.ID) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	delete(g.cache, groupID)
-}
-
-func (g *groupedCache[T]) RemoveIf(filterFunc cache.GroupedFilterFunc[T]) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	for groupID, groupEntities := range g.cache {
-		for id, entity := range groupEntities {
-			if filterFunc(groupID, entity) {
-				delete(groupEntities, id)
-			}
-		}
-	}
-}
-
-func (g *groupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc cache.GroupedFilterFunc[T]) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	groupEntities, ok := g.cache[groupID]
-	if !ok {
-		return
-	}
-
-	for id, entity := range groupEntities {
-		if filterFunc(groupID, entity) {
-			delete(groupEntities, id)
-		}
-	}
-}
-
-func (g *groupedCache[T]) Len() int {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	var length int
-	for _, groupEntities := range g.cache {
-		length += len(groupEntities)
-	}
-	return length
-}
-
-func (g *groupedCache[T]) GroupLen(groupID snowflake.ID) int {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	groupEntities, ok := g.cache[groupID]
-	if !ok {
-		return 0
-	}
-
-	return len(groupEntities)
-}
-
-func (g *groupedCache[T]) ForEach(f func(groupID snowflake.ID, entity T)) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	for groupID, groupEntities := range g.cache {
-		for _, entity := range groupEntities {
-			f(groupID, entity)
-		}
-	}
-}
-
-func (g *groupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	groupEntities, ok := g.cache[groupID]
-	if !ok {
-		return
-	}
-
-	for _, entity := range groupEntities {
-		forEachFunc(entity)
-	}
-}
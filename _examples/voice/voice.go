@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/binary"
 	"io"
 	"log/slog"
 	"os"
@@ -78,32 +77,26 @@ func play(client bot.Client, closeChan chan os.Signal) {
 }
 
 func writeOpus(w io.Writer) {
-	file, err := os.Open("nico.dca")
+	file, err := os.Open("nico.opus")
 	if err != nil {
 		panic("error opening file: " + err.Error())
 	}
+	defer file.Close()
+
+	reader := voice.NewOggReader(file)
 	ticker := time.NewTicker(time.Millisecond * 20)
 	defer ticker.Stop()
 
-	var lenBuf [4]byte
 	for range ticker.C {
-		_, err = io.ReadFull(file, lenBuf[:])
+		frame, err := reader.ProvideOpusFrame()
 		if err != nil {
 			if err == io.EOF {
-				_ = file.Close()
 				return
 			}
 			panic("error reading file: " + err.Error())
-			return
 		}
 
-		// Read the integer
-		frameLen := int64(binary.LittleEndian.Uint32(lenBuf[:]))
-
-		// Copy the frame.
-		_, err = io.CopyN(w, file, frameLen)
-		if err != nil && err != io.EOF {
-			_ = file.Close()
+		if _, err = w.Write(frame); err != nil {
 			return
 		}
 	}
@@ -0,0 +1,267 @@
+package bot
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+var _ AuditLogCorrelator = (*auditLogCorrelatorImpl)(nil)
+
+// auditLogCorrelatedEventTypes are the gateway.EventType(s) passed to the AuditLogCorrelator.
+var auditLogCorrelatedEventTypes = []gateway.EventType{
+	gateway.EventTypeGuildAuditLogEntryCreate,
+	gateway.EventTypeGuildBanAdd,
+	gateway.EventTypeGuildBanRemove,
+	gateway.EventTypeGuildMemberRemove,
+	gateway.EventTypeGuildRoleDelete,
+	gateway.EventTypeChannelDelete,
+}
+
+// NewAuditLogCorrelator returns a new AuditLogCorrelator with the AuditLogCorrelatorConfigOpt(s) applied.
+// Use WithAuditLogCorrelator to pass it the gateway events.
+func NewAuditLogCorrelator(opts ...AuditLogCorrelatorConfigOpt) AuditLogCorrelator {
+	cfg := DefaultAuditLogCorrelatorConfig()
+	cfg.Apply(opts)
+	cfg.Logger = cfg.Logger.With(slog.String("name", "bot_audit_log_correlator"))
+
+	return &auditLogCorrelatorImpl{
+		config: *cfg,
+	}
+}
+
+// AuditLogCorrelator joins moderation gateway events with the discord.AuditLogEntry describing who triggered them.
+// Bans, unbans, member removes, role & channel deletes are matched by guild, target ID & action type with entries received via gateway.EventGuildAuditLogEntryCreate.
+// If no entry arrives within the configured window, the audit log is fetched via the rest API, for member removes only if enabled.
+// For each event an AuditLogCorrelatedEvent is dispatched, in addition to the normal event.
+type AuditLogCorrelator interface {
+	// HandleGatewayEvent correlates the given gateway event. Unrelated events are ignored.
+	HandleGatewayEvent(client Client, sequenceNumber int, shardID int, event gateway.EventData)
+
+	// Close stops waiting for audit log entries. Pending events are dropped.
+	Close()
+}
+
+// AuditLogCorrelatedEvent is dispatched by the AuditLogCorrelator for each moderation event.
+type AuditLogCorrelatedEvent struct {
+	client         Client
+	sequenceNumber int
+	shardID        int
+	receivedAt     time.Time
+
+	// Event is the original gateway event. One of gateway.EventGuildBanAdd, gateway.EventGuildBanRemove, gateway.EventGuildMemberRemove, gateway.EventGuildRoleDelete or gateway.EventChannelDelete.
+	Event      gateway.EventData
+	GuildID    snowflake.ID
+	TargetID   snowflake.ID
+	ActionType discord.AuditLogEvent
+	// Entry is the matching discord.AuditLogEntry or nil if none was found. For gateway.EventGuildMemberRemove this usually means the member left on their own.
+	Entry *discord.AuditLogEntry
+}
+
+// Client returns the Client instance that dispatched the event
+func (e *AuditLogCorrelatedEvent) Client() Client {
+	return e.client
+}
+
+// SequenceNumber returns the sequence number of the gateway event
+func (e *AuditLogCorrelatedEvent) SequenceNumber() int {
+	return e.sequenceNumber
+}
+
+// ShardID returns the shard ID the event was dispatched from
+func (e *AuditLogCorrelatedEvent) ShardID() int {
+	return e.shardID
+}
+
+// ExecutorID returns the ID of the user who triggered the event and a bool indicating if an Entry was found.
+func (e *AuditLogCorrelatedEvent) ExecutorID() (snowflake.ID, bool) {
+	if e.Entry == nil {
+		return 0, false
+	}
+	return e.Entry.UserID, true
+}
+
+// Reason returns the audit log reason of the Entry or nil if there is none.
+func (e *AuditLogCorrelatedEvent) Reason() *string {
+	if e.Entry == nil {
+		return nil
+	}
+	return e.Entry.Reason
+}
+
+func (e *AuditLogCorrelatedEvent) matches(guildID snowflake.ID, entry discord.AuditLogEntry) bool {
+	return e.GuildID == guildID && entry.ActionType == e.ActionType && entry.TargetID != nil && *entry.TargetID == e.TargetID
+}
+
+type receivedAuditLogEntry struct {
+	guildID    snowflake.ID
+	entry      discord.AuditLogEntry
+	receivedAt time.Time
+}
+
+type pendingAuditLogEvent struct {
+	event *AuditLogCorrelatedEvent
+	timer *time.Timer
+}
+
+type auditLogCorrelatorImpl struct {
+	config AuditLogCorrelatorConfig
+
+	mu      sync.Mutex
+	entries []receivedAuditLogEntry
+	pending []*pendingAuditLogEvent
+}
+
+func (c *auditLogCorrelatorImpl) HandleGatewayEvent(client Client, sequenceNumber int, shardID int, event gateway.EventData) {
+	if e, ok := event.(gateway.EventGuildAuditLogEntryCreate); ok {
+		c.addEntry(client, e.GuildID, e.AuditLogEntry)
+		return
+	}
+
+	correlatedEvent := &AuditLogCorrelatedEvent{
+		client:         client,
+		sequenceNumber: sequenceNumber,
+		shardID:        shardID,
+		receivedAt:     time.Now(),
+		Event:          event,
+	}
+	switch e := event.(type) {
+	case gateway.EventGuildBanAdd:
+		correlatedEvent.GuildID, correlatedEvent.TargetID, correlatedEvent.ActionType = e.GuildID, e.User.ID, discord.AuditLogEventMemberBanAdd
+	case gateway.EventGuildBanRemove:
+		correlatedEvent.GuildID, correlatedEvent.TargetID, correlatedEvent.ActionType = e.GuildID, e.User.ID, discord.AuditLogEventMemberBanRemove
+	case gateway.EventGuildMemberRemove:
+		correlatedEvent.GuildID, correlatedEvent.TargetID, correlatedEvent.ActionType = e.GuildID, e.User.ID, discord.AuditLogEventMemberKick
+	case gateway.EventGuildRoleDelete:
+		correlatedEvent.GuildID, correlatedEvent.TargetID, correlatedEvent.ActionType = e.GuildID, e.RoleID, discord.AuditLogEventRoleDelete
+	case gateway.EventChannelDelete:
+		if e.GuildChannel == nil {
+			return
+		}
+		correlatedEvent.GuildID, correlatedEvent.TargetID, correlatedEvent.ActionType = e.GuildID(), e.ID(), discord.AuditLogEventChannelDelete
+	default:
+		return
+	}
+
+	c.mu.Lock()
+	c.pruneEntries()
+	for i, received := range c.entries {
+		if correlatedEvent.matches(received.guildID, received.entry) {
+			c.entries = slices.Delete(c.entries, i, i+1)
+			c.mu.Unlock()
+			correlatedEvent.Entry = &received.entry
+			client.EventManager().DispatchEvent(correlatedEvent)
+			return
+		}
+	}
+	pending := &pendingAuditLogEvent{event: correlatedEvent}
+	pending.timer = time.AfterFunc(c.config.Window, func() {
+		c.expire(pending)
+	})
+	c.pending = append(c.pending, pending)
+	c.mu.Unlock()
+}
+
+func (c *auditLogCorrelatorImpl) addEntry(client Client, guildID snowflake.ID, entry discord.AuditLogEntry) {
+	c.mu.Lock()
+	c.pruneEntries()
+	for i, pending := range c.pending {
+		if pending.event.matches(guildID, entry) {
+			pending.timer.Stop()
+			c.pending = slices.Delete(c.pending, i, i+1)
+			c.mu.Unlock()
+			pending.event.Entry = &entry
+			client.EventManager().DispatchEvent(pending.event)
+			return
+		}
+	}
+	c.entries = append(c.entries, receivedAuditLogEntry{
+		guildID:    guildID,
+		entry:      entry,
+		receivedAt: time.Now(),
+	})
+	c.mu.Unlock()
+}
+
+// pruneEntries removes all entries older than the window. It must be called with the lock held.
+func (c *auditLogCorrelatorImpl) pruneEntries() {
+	deadline := time.Now().Add(-c.config.Window)
+	c.entries = slices.DeleteFunc(c.entries, func(received receivedAuditLogEntry) bool {
+		return received.receivedAt.Before(deadline)
+	})
+}
+
+func (c *auditLogCorrelatorImpl) expire(pending *pendingAuditLogEvent) {
+	c.mu.Lock()
+	i := slices.Index(c.pending, pending)
+	if i == -1 {
+		// the entry arrived or the correlator was closed meanwhile
+		c.mu.Unlock()
+		return
+	}
+	c.pending = slices.Delete(c.pending, i, i+1)
+	c.mu.Unlock()
+
+	event := pending.event
+	if c.useRestFallback(event) {
+		event.Entry = c.fetchEntry(event)
+	}
+	event.client.EventManager().DispatchEvent(event)
+}
+
+// useRestFallback returns whether the audit log should be fetched for the event.
+// The audit log can only be fetched with discord.PermissionViewAuditLog, so it is skipped if the bot's member is not cached to check it.
+func (c *auditLogCorrelatorImpl) useRestFallback(event *AuditLogCorrelatedEvent) bool {
+	if !c.config.RestFallback {
+		return false
+	}
+	if _, ok := event.Event.(gateway.EventGuildMemberRemove); ok && !c.config.MemberRemoveRestFallback {
+		return false
+	}
+	member, ok := event.client.Caches().SelfMember(event.GuildID)
+	if !ok {
+		return false
+	}
+	return event.client.Caches().MemberPermissions(member).Has(discord.PermissionViewAuditLog)
+}
+
+func (c *auditLogCorrelatorImpl) fetchEntry(event *AuditLogCorrelatedEvent) *discord.AuditLogEntry {
+	auditLog, err := event.client.Rest().GetAuditLog(event.GuildID, 0, event.ActionType, 0, 0, 10)
+	if err != nil {
+		c.config.Logger.Error("failed to fetch audit log", slog.Any("err", err), slog.String("guild_id", event.GuildID.String()))
+		return nil
+	}
+	deadline := event.receivedAt.Add(-c.config.Window)
+	for _, entry := range auditLog.AuditLogEntries {
+		if event.matches(event.GuildID, entry) && entry.ID.Time().After(deadline) {
+			return &entry
+		}
+	}
+	return nil
+}
+
+func (c *auditLogCorrelatorImpl) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, pending := range c.pending {
+		pending.timer.Stop()
+	}
+	c.pending = nil
+	c.entries = nil
+}
+
+type auditLogCorrelatorGatewayEventHandler struct {
+	GatewayEventHandler
+	correlator AuditLogCorrelator
+}
+
+func (h *auditLogCorrelatorGatewayEventHandler) HandleGatewayEvent(client Client, sequenceNumber int, shardID int, event gateway.EventData) {
+	h.GatewayEventHandler.HandleGatewayEvent(client, sequenceNumber, shardID, event)
+	h.correlator.HandleGatewayEvent(client, sequenceNumber, shardID, event)
+}
@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultAuditLogCorrelatorConfig returns a new AuditLogCorrelatorConfig with all default values.
+func DefaultAuditLogCorrelatorConfig() *AuditLogCorrelatorConfig {
+	return &AuditLogCorrelatorConfig{
+		Logger:       slog.Default(),
+		Window:       5 * time.Second,
+		RestFallback: true,
+	}
+}
+
+// AuditLogCorrelatorConfig can be used to configure the AuditLogCorrelator.
+type AuditLogCorrelatorConfig struct {
+	Logger *slog.Logger
+	// Window is how long the AuditLogCorrelator waits for a matching discord.AuditLogEntry & how old a matching entry can be.
+	Window time.Duration
+	// RestFallback enables looking up the discord.AuditLogEntry via rest.Guilds.GetAuditLog if none was received within the Window.
+	// The lookup is skipped if the bot's member is not cached or misses discord.PermissionViewAuditLog.
+	RestFallback bool
+	// MemberRemoveRestFallback enables the RestFallback for gateway.EventGuildMemberRemove.
+	// Most member removes are members leaving on their own, so looking up each of them costs a request for nothing.
+	MemberRemoveRestFallback bool
+}
+
+// AuditLogCorrelatorConfigOpt is a functional option for configuring an AuditLogCorrelator.
+type AuditLogCorrelatorConfigOpt func(config *AuditLogCorrelatorConfig)
+
+// Apply applies the given AuditLogCorrelatorConfigOpt(s) to the AuditLogCorrelatorConfig.
+func (c *AuditLogCorrelatorConfig) Apply(opts []AuditLogCorrelatorConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithAuditLogCorrelatorLogger overrides the default logger in the AuditLogCorrelatorConfig.
+func WithAuditLogCorrelatorLogger(logger *slog.Logger) AuditLogCorrelatorConfigOpt {
+	return func(config *AuditLogCorrelatorConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithAuditLogCorrelatorWindow sets the time window in which events & audit log entries are matched.
+func WithAuditLogCorrelatorWindow(window time.Duration) AuditLogCorrelatorConfigOpt {
+	return func(config *AuditLogCorrelatorConfig) {
+		config.Window = window
+	}
+}
+
+// WithAuditLogCorrelatorRestFallback enables/disables looking up missing audit log entries via the rest API.
+func WithAuditLogCorrelatorRestFallback(enabled bool) AuditLogCorrelatorConfigOpt {
+	return func(config *AuditLogCorrelatorConfig) {
+		config.RestFallback = enabled
+	}
+}
+
+// WithAuditLogCorrelatorMemberRemoveRestFallback enables/disables looking up missing audit log entries of member removes via the rest API.
+// This only has an effect if the rest fallback is enabled, see WithAuditLogCorrelatorRestFallback.
+func WithAuditLogCorrelatorMemberRemoveRestFallback(enabled bool) AuditLogCorrelatorConfigOpt {
+	return func(config *AuditLogCorrelatorConfig) {
+		config.MemberRemoveRestFallback = enabled
+	}
+}
@@ -0,0 +1,194 @@
+package bot
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const (
+	testGuildID snowflake.ID = 1
+	testSelfID  snowflake.ID = 2
+)
+
+// testEventManager records the dispatched events.
+type testEventManager struct {
+	EventManager
+	events chan Event
+}
+
+func (m *testEventManager) DispatchEvent(event Event) {
+	m.events <- event
+}
+
+// testAuditLogRest returns the audit log entries & counts the requests.
+type testAuditLogRest struct {
+	rest.Rest
+	mu       sync.Mutex
+	entries  []discord.AuditLogEntry
+	requests int
+}
+
+func (r *testAuditLogRest) GetAuditLog(_ snowflake.ID, _ snowflake.ID, _ discord.AuditLogEvent, _ snowflake.ID, _ snowflake.ID, _ int, _ ...rest.RequestOpt) (*discord.AuditLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests++
+	return &discord.AuditLog{AuditLogEntries: r.entries}, nil
+}
+
+func (r *testAuditLogRest) requestCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+type testClient struct {
+	Client
+	caches       cache.Caches
+	rest         *testAuditLogRest
+	eventManager *testEventManager
+}
+
+func (c *testClient) Caches() cache.Caches {
+	return c.caches
+}
+
+func (c *testClient) Rest() rest.Rest {
+	return c.rest
+}
+
+func (c *testClient) EventManager() EventManager {
+	return c.eventManager
+}
+
+// newTestClient returns a testClient whose member has the given permissions in the test guild.
+func newTestClient(permissions discord.Permissions) *testClient {
+	caches := cache.New(cache.WithCaches(cache.FlagsAll))
+	caches.SetSelfUser(discord.OAuth2User{User: discord.User{ID: testSelfID}})
+	caches.AddGuild(discord.Guild{ID: testGuildID})
+	caches.AddRole(discord.Role{ID: testGuildID, GuildID: testGuildID, Permissions: permissions})
+	caches.AddMember(discord.Member{GuildID: testGuildID, User: discord.User{ID: testSelfID}})
+	return &testClient{
+		caches:       caches,
+		rest:         &testAuditLogRest{},
+		eventManager: &testEventManager{events: make(chan Event, 10)},
+	}
+}
+
+func (c *testClient) nextEvent(t *testing.T) *AuditLogCorrelatedEvent {
+	t.Helper()
+	select {
+	case event := <-c.eventManager.events:
+		require.IsType(t, &AuditLogCorrelatedEvent{}, event)
+		return event.(*AuditLogCorrelatedEvent)
+	case <-time.After(time.Second):
+		t.Fatal("no event dispatched")
+		return nil
+	}
+}
+
+func testAuditLogEntry(actionType discord.AuditLogEvent, targetID snowflake.ID) gateway.EventGuildAuditLogEntryCreate {
+	return gateway.EventGuildAuditLogEntryCreate{
+		GuildID: testGuildID,
+		AuditLogEntry: discord.AuditLogEntry{
+			ID:         snowflake.New(time.Now()),
+			ActionType: actionType,
+			TargetID:   &targetID,
+			UserID:     3,
+			Reason:     json.Ptr("reason"),
+		},
+	}
+}
+
+func testBanAdd(userID snowflake.ID) gateway.EventGuildBanAdd {
+	return gateway.EventGuildBanAdd{GuildID: testGuildID, User: discord.User{ID: userID}}
+}
+
+func TestAuditLogCorrelatorEntryFirst(t *testing.T) {
+	client := newTestClient(discord.PermissionViewAuditLog)
+	c := NewAuditLogCorrelator(WithAuditLogCorrelatorWindow(time.Hour))
+	defer c.Close()
+
+	c.HandleGatewayEvent(client, 0, 0, testAuditLogEntry(discord.AuditLogEventMemberBanAdd, 10))
+	c.HandleGatewayEvent(client, 1, 0, testBanAdd(10))
+
+	event := client.nextEvent(t)
+	assert.Equal(t, 1, event.SequenceNumber())
+	assert.Equal(t, discord.AuditLogEventMemberBanAdd, event.ActionType)
+	executorID, ok := event.ExecutorID()
+	assert.True(t, ok)
+	assert.Equal(t, snowflake.ID(3), executorID)
+	assert.Equal(t, "reason", *event.Reason())
+}
+
+func TestAuditLogCorrelatorEventFirst(t *testing.T) {
+	client := newTestClient(discord.PermissionViewAuditLog)
+	c := NewAuditLogCorrelator(WithAuditLogCorrelatorWindow(time.Hour))
+	defer c.Close()
+
+	c.HandleGatewayEvent(client, 0, 0, gateway.EventGuildRoleDelete{GuildID: testGuildID, RoleID: 20})
+	// entries of other targets & actions don't match
+	c.HandleGatewayEvent(client, 1, 0, testAuditLogEntry(discord.AuditLogEventRoleDelete, 21))
+	c.HandleGatewayEvent(client, 2, 0, testAuditLogEntry(discord.AuditLogEventChannelDelete, 20))
+	c.HandleGatewayEvent(client, 3, 0, testAuditLogEntry(discord.AuditLogEventRoleDelete, 20))
+
+	event := client.nextEvent(t)
+	assert.Equal(t, snowflake.ID(20), event.TargetID)
+	require.NotNil(t, event.Entry)
+	assert.Equal(t, discord.AuditLogEventRoleDelete, event.Entry.ActionType)
+	assert.Equal(t, snowflake.ID(20), *event.Entry.TargetID)
+	assert.Zero(t, client.rest.requestCount())
+}
+
+func TestAuditLogCorrelatorRestFallback(t *testing.T) {
+	client := newTestClient(discord.PermissionViewAuditLog)
+	client.rest.entries = []discord.AuditLogEntry{testAuditLogEntry(discord.AuditLogEventMemberBanAdd, 10).AuditLogEntry}
+	c := NewAuditLogCorrelator(WithAuditLogCorrelatorWindow(10 * time.Millisecond))
+	defer c.Close()
+
+	c.HandleGatewayEvent(client, 0, 0, testBanAdd(10))
+
+	event := client.nextEvent(t)
+	require.NotNil(t, event.Entry, "the entry should be fetched via rest")
+	assert.Equal(t, 1, client.rest.requestCount())
+}
+
+func TestAuditLogCorrelatorRestFallbackMissingPermission(t *testing.T) {
+	client := newTestClient(discord.PermissionBanMembers)
+	c := NewAuditLogCorrelator(WithAuditLogCorrelatorWindow(10 * time.Millisecond))
+	defer c.Close()
+
+	c.HandleGatewayEvent(client, 0, 0, testBanAdd(10))
+
+	event := client.nextEvent(t)
+	assert.Nil(t, event.Entry)
+	assert.Zero(t, client.rest.requestCount(), "the audit log can't be fetched without the view audit log permission")
+}
+
+func TestAuditLogCorrelatorMemberRemoveRestFallback(t *testing.T) {
+	client := newTestClient(discord.PermissionViewAuditLog)
+	c := NewAuditLogCorrelator(WithAuditLogCorrelatorWindow(10 * time.Millisecond))
+	defer c.Close()
+
+	c.HandleGatewayEvent(client, 0, 0, gateway.EventGuildMemberRemove{GuildID: testGuildID, User: discord.User{ID: 10}})
+	event := client.nextEvent(t)
+	assert.Nil(t, event.Entry)
+	assert.Zero(t, client.rest.requestCount(), "the member remove rest fallback should be opt-in")
+
+	c = NewAuditLogCorrelator(WithAuditLogCorrelatorWindow(10*time.Millisecond), WithAuditLogCorrelatorMemberRemoveRestFallback(true))
+	defer c.Close()
+
+	c.HandleGatewayEvent(client, 0, 0, gateway.EventGuildMemberRemove{GuildID: testGuildID, User: discord.User{ID: 10}})
+	client.nextEvent(t)
+	assert.Equal(t, 1, client.rest.requestCount())
+}
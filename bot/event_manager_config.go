@@ -2,6 +2,7 @@ package bot
 
 import (
 	"log/slog"
+	"maps"
 
 	"github.com/disgoorg/disgo/gateway"
 )
@@ -77,3 +78,20 @@ func WithHTTPServerHandler(handler HTTPServerEventHandler) EventManagerConfigOpt
 		config.HTTPServerHandler = handler
 	}
 }
+
+// WithAuditLogCorrelator passes the events, which can be matched with audit log entries, to the given AuditLogCorrelator after they were handled.
+// This wraps the GatewayEventHandler(s) set before.
+func WithAuditLogCorrelator(correlator AuditLogCorrelator) EventManagerConfigOpt {
+	return func(config *EventManagerConfig) {
+		handlers := maps.Clone(config.GatewayHandlers)
+		for _, eventType := range auditLogCorrelatedEventTypes {
+			if handler, ok := handlers[eventType]; ok {
+				handlers[eventType] = &auditLogCorrelatorGatewayEventHandler{
+					GatewayEventHandler: handler,
+					correlator:          correlator,
+				}
+			}
+		}
+		config.GatewayHandlers = handlers
+	}
+}
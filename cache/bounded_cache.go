@@ -0,0 +1,421 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EvictionStats holds the number of entities evicted from a bounded cache by reason.
+type EvictionStats struct {
+	// MaxSize is the number of entities evicted because BoundedCacheConfig.MaxSize was exceeded.
+	MaxSize uint64
+	// MaxGroupSize is the number of entities evicted because BoundedCacheConfig.MaxGroupSize was exceeded.
+	MaxGroupSize uint64
+	// Expired is the number of entities evicted because they were not used within BoundedCacheConfig.TTL.
+	Expired uint64
+}
+
+// Total returns the total number of evicted entities.
+func (s EvictionStats) Total() uint64 {
+	return s.MaxSize + s.MaxGroupSize + s.Expired
+}
+
+// EvictionStatsProvider is implemented by caches which evict entities.
+type EvictionStatsProvider interface {
+	// EvictionStats returns the EvictionStats of the cache.
+	EvictionStats() EvictionStats
+}
+
+var (
+	_ Cache[any]            = (*BoundedCache[any])(nil)
+	_ EvictionStatsProvider = (*BoundedCache[any])(nil)
+)
+
+// NewBoundedCache returns a new BoundedCache which filters the entities after the given Flags and Policy.
+func NewBoundedCache[T any](flags Flags, neededFlags Flags, policy Policy[T], opts ...BoundedCacheConfigOpt) *BoundedCache[T] {
+	config := DefaultBoundedCacheConfig()
+	config.Apply(opts)
+	// there is only a single group
+	config.MaxGroupSize = 0
+
+	return &BoundedCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		store:       newBoundedStore[T](*config),
+	}
+}
+
+// BoundedCache is a thread safe Cache which evicts the least recently used entities once BoundedCacheConfig.MaxSize is exceeded
+// and entities which were not used within BoundedCacheConfig.TTL.
+type BoundedCache[T any] struct {
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	store       *boundedStore[T]
+}
+
+func (c *BoundedCache[T]) Get(id snowflake.ID) (T, bool) {
+	return c.store.get(0, id)
+}
+
+func (c *BoundedCache[T]) Put(id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.store.put(0, id, entity)
+}
+
+func (c *BoundedCache[T]) Remove(id snowflake.ID) (T, bool) {
+	return c.store.remove(0, id)
+}
+
+func (c *BoundedCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.store.removeIf(func(_ snowflake.ID, entity T) bool {
+		return filterFunc(entity)
+	})
+}
+
+func (c *BoundedCache[T]) Len() int {
+	return c.store.len()
+}
+
+func (c *BoundedCache[T]) ForEach(forEachFunc func(entity T)) {
+	c.store.forEach(func(_ snowflake.ID, entity T) {
+		forEachFunc(entity)
+	})
+}
+
+func (c *BoundedCache[T]) EvictionStats() EvictionStats {
+	return c.store.evictionStats()
+}
+
+var (
+	_ GroupedCache[any]     = (*BoundedGroupedCache[any])(nil)
+	_ EvictionStatsProvider = (*BoundedGroupedCache[any])(nil)
+)
+
+// NewBoundedGroupedCache returns a new BoundedGroupedCache which filters the entities after the given Flags and Policy.
+func NewBoundedGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T], opts ...BoundedCacheConfigOpt) *BoundedGroupedCache[T] {
+	config := DefaultBoundedCacheConfig()
+	config.Apply(opts)
+
+	return &BoundedGroupedCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		store:       newBoundedStore[T](*config),
+	}
+}
+
+// BoundedGroupedCache is a thread safe GroupedCache which evicts the least recently used entities once BoundedCacheConfig.MaxSize
+// or BoundedCacheConfig.MaxGroupSize is exceeded and entities which were not used within BoundedCacheConfig.TTL.
+type BoundedGroupedCache[T any] struct {
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	store       *boundedStore[T]
+}
+
+func (c *BoundedGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	return c.store.get(groupID, id)
+}
+
+func (c *BoundedGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.store.put(groupID, id, entity)
+}
+
+func (c *BoundedGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	return c.store.remove(groupID, id)
+}
+
+func (c *BoundedGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	c.store.groupRemove(groupID)
+}
+
+func (c *BoundedGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.store.removeIf(filterFunc)
+}
+
+func (c *BoundedGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.store.groupRemoveIf(groupID, filterFunc)
+}
+
+func (c *BoundedGroupedCache[T]) Len() int {
+	return c.store.len()
+}
+
+func (c *BoundedGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return c.store.groupLen(groupID)
+}
+
+func (c *BoundedGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	c.store.forEach(forEachFunc)
+}
+
+func (c *BoundedGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.store.groupForEach(groupID, forEachFunc)
+}
+
+func (c *BoundedGroupedCache[T]) EvictionStats() EvictionStats {
+	return c.store.evictionStats()
+}
+
+type boundedEntry[T any] struct {
+	groupID  snowflake.ID
+	id       snowflake.ID
+	entity   T
+	lastUsed time.Time
+
+	// elem is the element in the global lru list, groupElem the element in the lru list of the group
+	elem      *list.Element
+	groupElem *list.Element
+}
+
+type boundedGroup[T any] struct {
+	entries map[snowflake.ID]*boundedEntry[T]
+	lru     *list.List
+}
+
+// boundedStore is the grouped lru list both bounded caches are built on. The front of the lists holds the most recently used entries.
+type boundedStore[T any] struct {
+	config BoundedCacheConfig
+
+	mu     sync.Mutex
+	groups map[snowflake.ID]*boundedGroup[T]
+	lru    *list.List
+	stats  EvictionStats
+}
+
+func newBoundedStore[T any](config BoundedCacheConfig) *boundedStore[T] {
+	return &boundedStore[T]{
+		config: config,
+		groups: map[snowflake.ID]*boundedGroup[T]{},
+		lru:    list.New(),
+	}
+}
+
+func (s *boundedStore[T]) get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	e := s.entry(groupID, id)
+	if e == nil {
+		var entity T
+		return entity, false
+	}
+	if s.expired(e, now) {
+		s.removeEntry(e)
+		s.stats.Expired++
+		var entity T
+		return entity, false
+	}
+	s.touch(e, now)
+	return e.entity, true
+}
+
+func (s *boundedStore[T]) put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.expire(now)
+
+	if e := s.entry(groupID, id); e != nil {
+		e.entity = entity
+		s.touch(e, now)
+		return
+	}
+
+	group, ok := s.groups[groupID]
+	if !ok {
+		group = &boundedGroup[T]{
+			entries: map[snowflake.ID]*boundedEntry[T]{},
+			lru:     list.New(),
+		}
+		s.groups[groupID] = group
+	}
+	e := &boundedEntry[T]{
+		groupID:  groupID,
+		id:       id,
+		entity:   entity,
+		lastUsed: now,
+	}
+	e.elem = s.lru.PushFront(e)
+	e.groupElem = group.lru.PushFront(e)
+	group.entries[id] = e
+
+	if s.config.MaxGroupSize > 0 {
+		for group.lru.Len() > s.config.MaxGroupSize {
+			s.removeEntry(group.lru.Back().Value.(*boundedEntry[T]))
+			s.stats.MaxGroupSize++
+		}
+	}
+	if s.config.MaxSize > 0 {
+		for s.lru.Len() > s.config.MaxSize {
+			s.removeEntry(s.lru.Back().Value.(*boundedEntry[T]))
+			s.stats.MaxSize++
+		}
+	}
+}
+
+func (s *boundedStore[T]) remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(groupID, id)
+	if e == nil {
+		var entity T
+		return entity, false
+	}
+	s.removeEntry(e)
+	return e.entity, true
+}
+
+func (s *boundedStore[T]) groupRemove(groupID snowflake.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.groups[groupID]
+	if !ok {
+		return
+	}
+	for _, e := range group.entries {
+		s.lru.Remove(e.elem)
+	}
+	delete(s.groups, groupID)
+}
+
+func (s *boundedStore[T]) removeIf(filterFunc GroupedFilterFunc[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, group := range s.groups {
+		for _, e := range group.entries {
+			if filterFunc(e.groupID, e.entity) {
+				s.removeEntry(e)
+			}
+		}
+	}
+}
+
+func (s *boundedStore[T]) groupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.groups[groupID]
+	if !ok {
+		return
+	}
+	for _, e := range group.entries {
+		if filterFunc(groupID, e.entity) {
+			s.removeEntry(e)
+		}
+	}
+}
+
+func (s *boundedStore[T]) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(time.Now())
+	return s.lru.Len()
+}
+
+func (s *boundedStore[T]) groupLen(groupID snowflake.ID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(time.Now())
+	if group, ok := s.groups[groupID]; ok {
+		return group.lru.Len()
+	}
+	return 0
+}
+
+func (s *boundedStore[T]) forEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(time.Now())
+	for elem := s.lru.Front(); elem != nil; elem = elem.Next() {
+		e := elem.Value.(*boundedEntry[T])
+		forEachFunc(e.groupID, e.entity)
+	}
+}
+
+func (s *boundedStore[T]) groupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(time.Now())
+	group, ok := s.groups[groupID]
+	if !ok {
+		return
+	}
+	for elem := group.lru.Front(); elem != nil; elem = elem.Next() {
+		forEachFunc(elem.Value.(*boundedEntry[T]).entity)
+	}
+}
+
+func (s *boundedStore[T]) evictionStats() EvictionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+func (s *boundedStore[T]) entry(groupID snowflake.ID, id snowflake.ID) *boundedEntry[T] {
+	if group, ok := s.groups[groupID]; ok {
+		return group.entries[id]
+	}
+	return nil
+}
+
+func (s *boundedStore[T]) touch(e *boundedEntry[T], now time.Time) {
+	e.lastUsed = now
+	s.lru.MoveToFront(e.elem)
+	s.groups[e.groupID].lru.MoveToFront(e.groupElem)
+}
+
+func (s *boundedStore[T]) removeEntry(e *boundedEntry[T]) {
+	s.lru.Remove(e.elem)
+	group := s.groups[e.groupID]
+	group.lru.Remove(e.groupElem)
+	delete(group.entries, e.id)
+	if len(group.entries) == 0 {
+		delete(s.groups, e.groupID)
+	}
+}
+
+func (s *boundedStore[T]) expired(e *boundedEntry[T], now time.Time) bool {
+	return s.config.TTL > 0 && now.Sub(e.lastUsed) >= s.config.TTL
+}
+
+// expire removes all expired entries. As every use moves an entry to the front, the expired entries are at the back of the lru list.
+func (s *boundedStore[T]) expire(now time.Time) {
+	if s.config.TTL <= 0 {
+		return
+	}
+	for elem := s.lru.Back(); elem != nil; {
+		e := elem.Value.(*boundedEntry[T])
+		if !s.expired(e, now) {
+			return
+		}
+		elem = elem.Prev()
+		s.removeEntry(e)
+		s.stats.Expired++
+	}
+}
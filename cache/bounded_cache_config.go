@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"time"
+)
+
+// DefaultBoundedCacheConfig returns a BoundedCacheConfig with sensible defaults.
+func DefaultBoundedCacheConfig() *BoundedCacheConfig {
+	return &BoundedCacheConfig{
+		MaxSize: 10000,
+	}
+}
+
+// BoundedCacheConfig is used to configure a BoundedCache or BoundedGroupedCache.
+// A limit of 0 disables the limit.
+type BoundedCacheConfig struct {
+	// MaxSize is the maximum number of entities in the cache. The least recently used entity is evicted when it is exceeded.
+	MaxSize int
+	// MaxGroupSize is the maximum number of entities per group. The least recently used entity of the group is evicted when it is exceeded.
+	// It is only used by the BoundedGroupedCache.
+	MaxGroupSize int
+	// TTL is the time after which entities which were not written or read expire.
+	TTL time.Duration
+}
+
+// BoundedCacheConfigOpt is used to functionally configure a BoundedCacheConfig.
+type BoundedCacheConfigOpt func(config *BoundedCacheConfig)
+
+// Apply applies the given BoundedCacheConfigOpt(s) to the BoundedCacheConfig.
+func (c *BoundedCacheConfig) Apply(opts []BoundedCacheConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithMaxSize sets the maximum number of entities in the cache.
+func WithMaxSize(maxSize int) BoundedCacheConfigOpt {
+	return func(config *BoundedCacheConfig) {
+		config.MaxSize = maxSize
+	}
+}
+
+// WithMaxGroupSize sets the maximum number of entities per group, for example messages per channel.
+func WithMaxGroupSize(maxGroupSize int) BoundedCacheConfigOpt {
+	return func(config *BoundedCacheConfig) {
+		config.MaxGroupSize = maxGroupSize
+	}
+}
+
+// WithTTL sets the time after which entities which were not written or read expire.
+func WithTTL(ttl time.Duration) BoundedCacheConfigOpt {
+	return func(config *BoundedCacheConfig) {
+		config.TTL = ttl
+	}
+}
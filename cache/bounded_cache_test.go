@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedGroupedCacheLRU(t *testing.T) {
+	c := NewBoundedGroupedCache[int](FlagsAll, FlagsNone, nil, WithMaxSize(3), WithMaxGroupSize(2))
+
+	c.Put(1, 1, 1)
+	c.Put(1, 2, 2)
+	// 1 is now the most recently used entity of group 1
+	_, ok := c.Get(1, 1)
+	assert.True(t, ok)
+
+	c.Put(1, 3, 3)
+	_, ok = c.Get(1, 2)
+	assert.False(t, ok, "least recently used entity of the group should be evicted")
+	assert.Equal(t, 2, c.GroupLen(1))
+
+	c.Put(2, 4, 4)
+	c.Put(2, 5, 5)
+	assert.Equal(t, 3, c.Len())
+	_, ok = c.Get(1, 1)
+	assert.False(t, ok, "least recently used entity should be evicted")
+
+	assert.Equal(t, EvictionStats{MaxSize: 1, MaxGroupSize: 1}, c.EvictionStats())
+}
+
+func TestBoundedCacheTTL(t *testing.T) {
+	c := NewBoundedCache[int](FlagsAll, FlagsNone, nil, WithTTL(20*time.Millisecond))
+
+	c.Put(snowflake.ID(1), 1)
+	c.Put(snowflake.ID(2), 2)
+	assert.Equal(t, 2, c.Len())
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, uint64(2), c.EvictionStats().Expired)
+}
@@ -0,0 +1,339 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Backend is a storage agnostic key value store which can be used to back a Cache or GroupedCache with BackendCache or BackendGroupedCache.
+// This allows several processes to share the same cached state, for example a gateway process and an HTTP interactions server.
+// Implementations have to be thread safe.
+type Backend interface {
+	// Get returns the values of all given keys which exist.
+	Get(ctx context.Context, keys ...string) (map[string][]byte, error)
+
+	// Put stores all given key value pairs, existing keys are overwritten.
+	Put(ctx context.Context, entries map[string][]byte) error
+
+	// Delete deletes all given keys. Missing keys are ignored.
+	Delete(ctx context.Context, keys ...string) error
+
+	// Scan calls the given function for each key value pair with the given key prefix until it returns false.
+	Scan(ctx context.Context, prefix string, fn func(key string, value []byte) bool) error
+}
+
+var _ Cache[any] = (*BackendCache[any])(nil)
+
+// NewBackendCache returns a new BackendCache storing its entities in the given Backend under the given key prefix encoded with the given Codec.
+// The Flags and Policy are applied like in the DefaultCache.
+func NewBackendCache[T any](backend Backend, prefix string, codec Codec[T], flags Flags, neededFlags Flags, policy Policy[T], opts ...BackendCacheConfigOpt) *BackendCache[T] {
+	config := DefaultBackendCacheConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "cache_backend"), slog.String("prefix", prefix))
+
+	return &BackendCache[T]{
+		store: backendStore[T]{
+			config:  *config,
+			backend: backend,
+			prefix:  prefix,
+			codec:   codec,
+		},
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+// BackendCache is a Cache storing its entities in a Backend.
+// As the Cache interface does not return errors, Backend errors are logged & the operation is treated as a cache miss.
+type BackendCache[T any] struct {
+	store       backendStore[T]
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+}
+
+func (c *BackendCache[T]) Get(id snowflake.ID) (T, bool) {
+	entity, ok := c.GetMany(id)[id]
+	return entity, ok
+}
+
+// GetMany returns all entities with the given IDs in a single Backend call.
+func (c *BackendCache[T]) GetMany(ids ...snowflake.ID) map[snowflake.ID]T {
+	return c.store.getMany(c.store.prefix+":", ids)
+}
+
+func (c *BackendCache[T]) Put(id snowflake.ID, entity T) {
+	c.PutMany(map[snowflake.ID]T{id: entity})
+}
+
+// PutMany stores all given entities in a single Backend call.
+func (c *BackendCache[T]) PutMany(entities map[snowflake.ID]T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	c.store.putMany(c.store.prefix+":", entities, c.policy)
+}
+
+func (c *BackendCache[T]) Remove(id snowflake.ID) (T, bool) {
+	return c.store.remove(c.store.prefix+":", id)
+}
+
+func (c *BackendCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.store.removeIf(c.store.prefix+":", func(_ string, entity T) bool {
+		return filterFunc(entity)
+	})
+}
+
+func (c *BackendCache[T]) Len() int {
+	return c.store.len(c.store.prefix + ":")
+}
+
+func (c *BackendCache[T]) ForEach(forEachFunc func(entity T)) {
+	c.store.forEach(c.store.prefix+":", func(_ string, entity T) {
+		forEachFunc(entity)
+	})
+}
+
+var _ GroupedCache[any] = (*BackendGroupedCache[any])(nil)
+
+// NewBackendGroupedCache returns a new BackendGroupedCache storing its entities in the given Backend under the given key prefix encoded with the given Codec.
+// The Flags and Policy are applied like in the default GroupedCache.
+func NewBackendGroupedCache[T any](backend Backend, prefix string, codec Codec[T], flags Flags, neededFlags Flags, policy Policy[T], opts ...BackendCacheConfigOpt) *BackendGroupedCache[T] {
+	config := DefaultBackendCacheConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "cache_backend"), slog.String("prefix", prefix))
+
+	return &BackendGroupedCache[T]{
+		store: backendStore[T]{
+			config:  *config,
+			backend: backend,
+			prefix:  prefix,
+			codec:   codec,
+		},
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+// BackendGroupedCache is a GroupedCache storing its entities in a Backend. Entities are stored with the key "<prefix>:<groupID>:<id>".
+// As the GroupedCache interface does not return errors, Backend errors are logged & the operation is treated as a cache miss.
+type BackendGroupedCache[T any] struct {
+	store       backendStore[T]
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+}
+
+func (c *BackendGroupedCache[T]) groupPrefix(groupID snowflake.ID) string {
+	return c.store.prefix + ":" + groupID.String() + ":"
+}
+
+func (c *BackendGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.GetMany(groupID, id)[id]
+	return entity, ok
+}
+
+// GetMany returns all entities of the group with the given IDs in a single Backend call.
+func (c *BackendGroupedCache[T]) GetMany(groupID snowflake.ID, ids ...snowflake.ID) map[snowflake.ID]T {
+	return c.store.getMany(c.groupPrefix(groupID), ids)
+}
+
+func (c *BackendGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	c.PutMany(groupID, map[snowflake.ID]T{id: entity})
+}
+
+// PutMany stores all given entities of the group in a single Backend call.
+func (c *BackendGroupedCache[T]) PutMany(groupID snowflake.ID, entities map[snowflake.ID]T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	c.store.putMany(c.groupPrefix(groupID), entities, c.policy)
+}
+
+func (c *BackendGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	return c.store.remove(c.groupPrefix(groupID), id)
+}
+
+func (c *BackendGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	c.store.removeIf(c.groupPrefix(groupID), func(_ string, _ T) bool {
+		return true
+	})
+}
+
+func (c *BackendGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.store.removeIf(c.store.prefix+":", func(key string, entity T) bool {
+		return filterFunc(c.groupID(key), entity)
+	})
+}
+
+func (c *BackendGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.store.removeIf(c.groupPrefix(groupID), func(_ string, entity T) bool {
+		return filterFunc(groupID, entity)
+	})
+}
+
+func (c *BackendGroupedCache[T]) Len() int {
+	return c.store.len(c.store.prefix + ":")
+}
+
+func (c *BackendGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return c.store.len(c.groupPrefix(groupID))
+}
+
+func (c *BackendGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	c.store.forEach(c.store.prefix+":", func(key string, entity T) {
+		forEachFunc(c.groupID(key), entity)
+	})
+}
+
+func (c *BackendGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.store.forEach(c.groupPrefix(groupID), func(_ string, entity T) {
+		forEachFunc(entity)
+	})
+}
+
+// groupID parses the group ID of a "<prefix>:<groupID>:<id>" key.
+func (c *BackendGroupedCache[T]) groupID(key string) snowflake.ID {
+	rest := strings.TrimPrefix(key, c.store.prefix+":")
+	groupID, _, _ := strings.Cut(rest, ":")
+	id, _ := snowflake.Parse(groupID)
+	return id
+}
+
+// backendStore implements the shared logic of BackendCache and BackendGroupedCache on top of key prefixes.
+type backendStore[T any] struct {
+	config  BackendCacheConfig
+	backend Backend
+	prefix  string
+	codec   Codec[T]
+}
+
+func (s *backendStore[T]) ctx() (context.Context, context.CancelFunc) {
+	if s.config.Timeout > 0 {
+		return context.WithTimeout(context.Background(), s.config.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func (s *backendStore[T]) getMany(prefix string, ids []snowflake.ID) map[snowflake.ID]T {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = prefix + id.String()
+	}
+
+	ctx, cancel := s.ctx()
+	defer cancel()
+	values, err := s.backend.Get(ctx, keys...)
+	if err != nil {
+		s.config.Logger.Error("failed to get entities from cache backend", slog.String("err", err.Error()))
+		return nil
+	}
+
+	entities := make(map[snowflake.ID]T, len(values))
+	for i, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		entity, err := s.codec.Decode(value)
+		if err != nil {
+			s.config.Logger.Error("failed to decode cached entity", slog.String("key", key), slog.String("err", err.Error()))
+			continue
+		}
+		entities[ids[i]] = entity
+	}
+	return entities
+}
+
+func (s *backendStore[T]) putMany(prefix string, entities map[snowflake.ID]T, policy Policy[T]) {
+	values := make(map[string][]byte, len(entities))
+	for id, entity := range entities {
+		if policy != nil && !policy(entity) {
+			continue
+		}
+		value, err := s.codec.Encode(entity)
+		if err != nil {
+			s.config.Logger.Error("failed to encode entity", slog.String("id", id.String()), slog.String("err", err.Error()))
+			continue
+		}
+		values[prefix+id.String()] = value
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	ctx, cancel := s.ctx()
+	defer cancel()
+	if err := s.backend.Put(ctx, values); err != nil {
+		s.config.Logger.Error("failed to put entities into cache backend", slog.String("err", err.Error()))
+	}
+}
+
+func (s *backendStore[T]) remove(prefix string, id snowflake.ID) (T, bool) {
+	entity, ok := s.getMany(prefix, []snowflake.ID{id})[id]
+	if !ok {
+		return entity, false
+	}
+
+	ctx, cancel := s.ctx()
+	defer cancel()
+	if err := s.backend.Delete(ctx, prefix+id.String()); err != nil {
+		s.config.Logger.Error("failed to delete entity from cache backend", slog.String("err", err.Error()))
+		return entity, false
+	}
+	return entity, true
+}
+
+func (s *backendStore[T]) removeIf(prefix string, filterFunc func(key string, entity T) bool) {
+	var keys []string
+	s.forEach(prefix, func(key string, entity T) {
+		if filterFunc(key, entity) {
+			keys = append(keys, key)
+		}
+	})
+	if len(keys) == 0 {
+		return
+	}
+
+	ctx, cancel := s.ctx()
+	defer cancel()
+	if err := s.backend.Delete(ctx, keys...); err != nil {
+		s.config.Logger.Error("failed to delete entities from cache backend", slog.String("err", err.Error()))
+	}
+}
+
+func (s *backendStore[T]) len(prefix string) int {
+	ctx, cancel := s.ctx()
+	defer cancel()
+
+	var n int
+	if err := s.backend.Scan(ctx, prefix, func(_ string, _ []byte) bool {
+		n++
+		return true
+	}); err != nil {
+		s.config.Logger.Error("failed to scan cache backend", slog.String("err", err.Error()))
+	}
+	return n
+}
+
+func (s *backendStore[T]) forEach(prefix string, forEachFunc func(key string, entity T)) {
+	ctx, cancel := s.ctx()
+	defer cancel()
+
+	if err := s.backend.Scan(ctx, prefix, func(key string, value []byte) bool {
+		entity, err := s.codec.Decode(value)
+		if err != nil {
+			s.config.Logger.Error("failed to decode cached entity", slog.String("key", key), slog.String("err", err.Error()))
+			return true
+		}
+		forEachFunc(key, entity)
+		return true
+	}); err != nil {
+		s.config.Logger.Error("failed to scan cache backend", slog.String("err", err.Error()))
+	}
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultBackendCacheConfig returns a BackendCacheConfig with sensible defaults.
+func DefaultBackendCacheConfig() *BackendCacheConfig {
+	return &BackendCacheConfig{
+		Logger:  slog.Default(),
+		Timeout: 5 * time.Second,
+	}
+}
+
+// BackendCacheConfig is used to configure a BackendCache or BackendGroupedCache.
+type BackendCacheConfig struct {
+	Logger *slog.Logger
+	// Timeout is the timeout of every Backend call. 0 disables the timeout.
+	Timeout time.Duration
+}
+
+// BackendCacheConfigOpt is used to functionally configure a BackendCacheConfig.
+type BackendCacheConfigOpt func(config *BackendCacheConfig)
+
+// Apply applies the given BackendCacheConfigOpt(s) to the BackendCacheConfig.
+func (c *BackendCacheConfig) Apply(opts []BackendCacheConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithBackendCacheLogger sets the Logger of the BackendCacheConfig.
+func WithBackendCacheLogger(logger *slog.Logger) BackendCacheConfigOpt {
+	return func(config *BackendCacheConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithBackendCacheTimeout sets the timeout of every Backend call.
+func WithBackendCacheTimeout(timeout time.Duration) BackendCacheConfigOpt {
+	return func(config *BackendCacheConfig) {
+		config.Timeout = timeout
+	}
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/bincodec"
+)
+
+func TestMemberCodec(t *testing.T) {
+	premiumSince := time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+	member := discord.Member{
+		User: discord.User{
+			ID:          1,
+			Username:    "test",
+			GlobalName:  json.Ptr("Test"),
+			AccentColor: json.Ptr(0xff00ff),
+			Bot:         true,
+			PublicFlags: discord.UserFlagVerifiedBot,
+		},
+		Nick:         json.Ptr("nick"),
+		RoleIDs:      []snowflake.ID{2, 3},
+		JoinedAt:     time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC),
+		PremiumSince: &premiumSince,
+		Pending:      true,
+		GuildID:      4,
+	}
+
+	data, err := MemberCodec.Encode(member)
+	require.NoError(t, err)
+	decoded, err := MemberCodec.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, member, decoded)
+
+	_, err = MemberCodec.Decode(data[:len(data)-1])
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+}
+
+func TestGuildChannelCodec(t *testing.T) {
+	overwrites := `"permission_overwrites":[{"id":"10","type":0,"allow":"1024","deny":"2048"},{"id":"11","type":1,"allow":"8","deny":"0"}]`
+	channels := []string{
+		`{"id":"1","type":0,"guild_id":"2","position":3,` + overwrites + `,"name":"text","topic":"topic","nsfw":true,"last_message_id":"4","rate_limit_per_user":5,"parent_id":"6","last_pin_timestamp":"2023-01-02T03:04:05.123Z","default_auto_archive_duration":1440}`,
+		`{"id":"1","type":2,"guild_id":"2","position":3,` + overwrites + `,"name":"voice","bitrate":64000,"user_limit":10,"parent_id":"6","rtc_region":"europe","video_quality_mode":2,"last_message_id":"4","nsfw":true,"rate_limit_per_user":5}`,
+		`{"id":"1","type":4,"guild_id":"2","position":3,` + overwrites + `,"name":"category"}`,
+		`{"id":"1","type":5,"guild_id":"2","position":3,` + overwrites + `,"name":"news","topic":"topic","last_message_id":"4","parent_id":"6","default_auto_archive_duration":60}`,
+		`{"id":"1","type":11,"guild_id":"2","name":"thread","last_message_id":"4","rate_limit_per_user":5,"owner_id":"7","parent_id":"6","message_count":8,"total_message_sent":9,"applied_tags":["12","13"],"member_count":3,"thread_metadata":{"archived":true,"auto_archive_duration":4320,"archive_timestamp":"2023-01-02T03:04:05Z","locked":true,"invitable":true,"create_timestamp":"2022-01-02T03:04:05Z"}}`,
+		`{"id":"1","type":13,"guild_id":"2","position":3,` + overwrites + `,"name":"stage","bitrate":64000,"parent_id":"6","rtc_region":"us-east","video_quality_mode":1}`,
+		`{"id":"1","type":15,"guild_id":"2","position":3,` + overwrites + `,"name":"forum","parent_id":"6","last_message_id":"4","topic":"topic","nsfw":true,"rate_limit_per_user":5,"flags":16,"available_tags":[{"id":"12","name":"tag","moderated":true,"emoji_id":"14","emoji_name":null}],"default_reaction_emoji":{"emoji_id":null,"emoji_name":"👍"},"default_thread_rate_limit_per_user":15,"default_sort_order":1,"default_forum_layout":2}`,
+		`{"id":"1","type":16,"guild_id":"2","position":3,` + overwrites + `,"name":"media","parent_id":"6","topic":"topic","flags":32768,"available_tags":[{"id":"12","name":"tag","moderated":false,"emoji_id":null,"emoji_name":"tag"}],"default_sort_order":0}`,
+	}
+	for _, raw := range channels {
+		var v discord.UnmarshalChannel
+		require.NoError(t, json.Unmarshal([]byte(raw), &v))
+		channel := v.Channel.(discord.GuildChannel)
+
+		data, err := GuildChannelCodec.Encode(channel)
+		require.NoError(t, err)
+		decoded, err := GuildChannelCodec.Decode(data)
+		require.NoError(t, err)
+		assert.Equal(t, channel, decoded, "all fields of the %T should survive the round trip", channel)
+
+		_, err = GuildChannelCodec.Decode(data[:len(data)-1])
+		assert.ErrorIs(t, err, ErrInvalidEncoding)
+	}
+
+	w := bincodec.Writer{}
+	w.Uint8(binaryCodecVersion)
+	w.Int(int64(discord.ChannelTypeDM))
+	_, err := GuildChannelCodec.Decode(w.Buf)
+	assert.ErrorIs(t, err, ErrInvalidEncoding, "a dm channel is not a guild channel")
+}
+
+func TestFileBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.kv")
+	backend, err := OpenFileBackend(path)
+	require.NoError(t, err)
+
+	roles := NewBackendGroupedCache[discord.Role](backend, "roles", RoleCodec, FlagsAll, FlagRoles, nil)
+	roles.Put(1, 10, discord.Role{ID: 10, GuildID: 1, Name: "a"})
+	roles.Put(1, 11, discord.Role{ID: 11, GuildID: 1, Name: "b"})
+	roles.Put(2, 20, discord.Role{ID: 20, GuildID: 2, Name: "c"})
+	_, ok := roles.Remove(1, 11)
+	assert.True(t, ok)
+	require.NoError(t, backend.Close())
+
+	backend, err = OpenFileBackend(path)
+	require.NoError(t, err)
+	defer backend.Close()
+	roles = NewBackendGroupedCache[discord.Role](backend, "roles", RoleCodec, FlagsAll, FlagRoles, nil)
+
+	assert.Equal(t, 2, roles.Len())
+	assert.Equal(t, 1, roles.GroupLen(1))
+	role, ok := roles.Get(1, 10)
+	assert.True(t, ok)
+	assert.Equal(t, "a", role.Name)
+
+	var groups []snowflake.ID
+	roles.ForEach(func(groupID snowflake.ID, _ discord.Role) {
+		groups = append(groups, groupID)
+	})
+	assert.ElementsMatch(t, []snowflake.ID{1, 2}, groups)
+}
@@ -0,0 +1,269 @@
+package cache
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/bincodec"
+)
+
+// ErrInvalidEncoding is returned by a Codec when the data can't be decoded.
+var ErrInvalidEncoding = bincodec.ErrInvalidEncoding
+
+// Codec is used to encode & decode the entities stored in a Backend.
+type Codec[T any] interface {
+	// Encode encodes the entity.
+	Encode(entity T) ([]byte, error)
+
+	// Decode decodes the entity.
+	Decode(data []byte) (T, error)
+}
+
+// NewJSONCodec returns a Codec encoding entities as JSON. For discord.GuildChannel use GuildChannelCodec instead.
+func NewJSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Encode(entity T) ([]byte, error) {
+	return json.Marshal(entity)
+}
+
+func (jsonCodec[T]) Decode(data []byte) (T, error) {
+	var entity T
+	err := json.Unmarshal(data, &entity)
+	return entity, err
+}
+
+// GuildChannelCodec encodes discord.GuildChannel(s) in a compact binary format tagged with their discord.ChannelType,
+// so they are decoded into their concrete channel type.
+var GuildChannelCodec Codec[discord.GuildChannel] = guildChannelCodec{}
+
+type guildChannelCodec struct{}
+
+func (guildChannelCodec) Encode(channel discord.GuildChannel) ([]byte, error) {
+	marshaler, ok := channel.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported channel type %d", ErrInvalidEncoding, channel.Type())
+	}
+	data, err := marshaler.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	w := bincodec.Writer{Buf: make([]byte, 0, len(data)+2)}
+	w.Uint8(binaryCodecVersion)
+	w.Int(int64(channel.Type()))
+	w.Buf = append(w.Buf, data...)
+	return w.Buf, nil
+}
+
+func (guildChannelCodec) Decode(data []byte) (discord.GuildChannel, error) {
+	r := bincodec.NewReader(data)
+	readVersion(r)
+	channelType := discord.ChannelType(r.Int())
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	data = r.Bytes()
+
+	switch channelType {
+	case discord.ChannelTypeGuildText:
+		return unmarshalGuildChannel[discord.GuildTextChannel](data)
+	case discord.ChannelTypeGuildVoice:
+		return unmarshalGuildChannel[discord.GuildVoiceChannel](data)
+	case discord.ChannelTypeGuildCategory:
+		return unmarshalGuildChannel[discord.GuildCategoryChannel](data)
+	case discord.ChannelTypeGuildNews:
+		return unmarshalGuildChannel[discord.GuildNewsChannel](data)
+	case discord.ChannelTypeGuildNewsThread, discord.ChannelTypeGuildPublicThread, discord.ChannelTypeGuildPrivateThread:
+		return unmarshalGuildChannel[discord.GuildThread](data)
+	case discord.ChannelTypeGuildStageVoice:
+		return unmarshalGuildChannel[discord.GuildStageVoiceChannel](data)
+	case discord.ChannelTypeGuildForum:
+		return unmarshalGuildChannel[discord.GuildForumChannel](data)
+	case discord.ChannelTypeGuildMedia:
+		return unmarshalGuildChannel[discord.GuildMediaChannel](data)
+	default:
+		return nil, fmt.Errorf("%w: channel type %d is not a guild channel", ErrInvalidEncoding, channelType)
+	}
+}
+
+func unmarshalGuildChannel[T discord.GuildChannel, P interface {
+	*T
+	encoding.BinaryUnmarshaler
+}](data []byte) (discord.GuildChannel, error) {
+	var channel T
+	if err := P(&channel).UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// binaryCodecVersion is the first byte of every binary encoded entity. It has to be bumped when the layout changes.
+const binaryCodecVersion = 1
+
+// MemberCodec encodes discord.Member(s) in a compact binary format. It is several times smaller & faster than JSON.
+var MemberCodec Codec[discord.Member] = memberCodec{}
+
+type memberCodec struct{}
+
+func (memberCodec) Encode(member discord.Member) ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 128)}
+	w.Uint8(binaryCodecVersion)
+	writeUser(&w, member.User)
+	w.OptString(member.Nick)
+	w.OptString(member.Avatar)
+	w.IDs(member.RoleIDs)
+	w.Time(member.JoinedAt)
+	w.OptTime(member.PremiumSince)
+	w.Bool(member.Deaf)
+	w.Bool(member.Mute)
+	w.Int(int64(member.Flags))
+	w.Bool(member.Pending)
+	w.OptTime(member.CommunicationDisabledUntil)
+	w.ID(member.GuildID)
+	return w.Buf, nil
+}
+
+func (memberCodec) Decode(data []byte) (discord.Member, error) {
+	r := bincodec.NewReader(data)
+	readVersion(r)
+	member := discord.Member{
+		User:                       readUser(r),
+		Nick:                       r.OptString(),
+		Avatar:                     r.OptString(),
+		RoleIDs:                    r.IDs(),
+		JoinedAt:                   r.Time(),
+		PremiumSince:               r.OptTime(),
+		Deaf:                       r.Bool(),
+		Mute:                       r.Bool(),
+		Flags:                      discord.MemberFlags(r.Int()),
+		Pending:                    r.Bool(),
+		CommunicationDisabledUntil: r.OptTime(),
+		GuildID:                    r.ID(),
+	}
+	return member, r.Err()
+}
+
+// UserCodec encodes discord.User(s) in a compact binary format.
+var UserCodec Codec[discord.User] = userCodec{}
+
+type userCodec struct{}
+
+func (userCodec) Encode(user discord.User) ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 64)}
+	w.Uint8(binaryCodecVersion)
+	writeUser(&w, user)
+	return w.Buf, nil
+}
+
+func (userCodec) Decode(data []byte) (discord.User, error) {
+	r := bincodec.NewReader(data)
+	readVersion(r)
+	user := readUser(r)
+	return user, r.Err()
+}
+
+// RoleCodec encodes discord.Role(s) in a compact binary format.
+var RoleCodec Codec[discord.Role] = roleCodec{}
+
+type roleCodec struct{}
+
+func (roleCodec) Encode(role discord.Role) ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 64)}
+	w.Uint8(binaryCodecVersion)
+	w.ID(role.ID)
+	w.ID(role.GuildID)
+	w.String(role.Name)
+	w.OptString(role.Description)
+	w.Int(int64(role.Color))
+	w.Bool(role.Hoist)
+	w.Int(int64(role.Position))
+	w.Int(int64(role.Permissions))
+	w.Bool(role.Managed)
+	w.OptString(role.Icon)
+	w.OptString(role.Emoji)
+	w.Bool(role.Mentionable)
+	w.Bool(role.Tags != nil)
+	if role.Tags != nil {
+		w.OptID(role.Tags.BotID)
+		w.OptID(role.Tags.IntegrationID)
+		w.Bool(role.Tags.PremiumSubscriber)
+		w.OptID(role.Tags.SubscriptionListingID)
+		w.Bool(role.Tags.AvailableForPurchase)
+		w.Bool(role.Tags.GuildConnections)
+	}
+	w.Int(int64(role.Flags))
+	return w.Buf, nil
+}
+
+func (roleCodec) Decode(data []byte) (discord.Role, error) {
+	r := bincodec.NewReader(data)
+	readVersion(r)
+	role := discord.Role{
+		ID:          r.ID(),
+		GuildID:     r.ID(),
+		Name:        r.String(),
+		Description: r.OptString(),
+		Color:       int(r.Int()),
+		Hoist:       r.Bool(),
+		Position:    int(r.Int()),
+		Permissions: discord.Permissions(r.Int()),
+		Managed:     r.Bool(),
+		Icon:        r.OptString(),
+		Emoji:       r.OptString(),
+		Mentionable: r.Bool(),
+	}
+	if r.Bool() {
+		role.Tags = &discord.RoleTag{
+			BotID:                 r.OptID(),
+			IntegrationID:         r.OptID(),
+			PremiumSubscriber:     r.Bool(),
+			SubscriptionListingID: r.OptID(),
+			AvailableForPurchase:  r.Bool(),
+			GuildConnections:      r.Bool(),
+		}
+	}
+	role.Flags = discord.RoleFlags(r.Int())
+	return role, r.Err()
+}
+
+func readVersion(r *bincodec.Reader) {
+	if v := r.Uint8(); r.Err() == nil && v != binaryCodecVersion {
+		r.Fail(fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, v))
+	}
+}
+
+func writeUser(w *bincodec.Writer, user discord.User) {
+	w.ID(user.ID)
+	w.String(user.Username)
+	w.String(user.Discriminator)
+	w.OptString(user.GlobalName)
+	w.OptString(user.Avatar)
+	w.OptString(user.Banner)
+	w.OptInt(user.AccentColor)
+	w.Bool(user.Bot)
+	w.Bool(user.System)
+	w.Int(int64(user.PublicFlags))
+	w.OptString(user.AvatarDecoration)
+}
+
+func readUser(r *bincodec.Reader) discord.User {
+	return discord.User{
+		ID:               r.ID(),
+		Username:         r.String(),
+		Discriminator:    r.String(),
+		GlobalName:       r.OptString(),
+		Avatar:           r.OptString(),
+		Banner:           r.OptString(),
+		AccentColor:      r.OptInt(),
+		Bot:              r.Bool(),
+		System:           r.Bool(),
+		PublicFlags:      discord.UserFlags(r.Int()),
+		AvatarDecoration: r.OptString(),
+	}
+}
@@ -11,6 +11,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		GuildCachePolicy:               PolicyAll[discord.Guild],
 		ChannelCachePolicy:             PolicyAll[discord.GuildChannel],
+		PrivateChannelCachePolicy:      PolicyAll[discord.PrivateChannel],
 		StageInstanceCachePolicy:       PolicyAll[discord.StageInstance],
 		GuildScheduledEventCachePolicy: PolicyAll[discord.GuildScheduledEvent],
 		RoleCachePolicy:                PolicyAll[discord.Role],
@@ -21,12 +22,22 @@ func DefaultConfig() *Config {
 		MessageCachePolicy:             PolicyAll[discord.Message],
 		EmojiCachePolicy:               PolicyAll[discord.Emoji],
 		StickerCachePolicy:             PolicyAll[discord.Sticker],
+		AutoModerationRuleCachePolicy:  PolicyAll[discord.AutoModerationRule],
+		BanCachePolicy:                 PolicyAll[discord.Ban],
+		InviteCachePolicy:              PolicyAll[discord.ExtendedInvite],
+		IntegrationCachePolicy:         PolicyAll[discord.Integration],
+		WebhookCachePolicy:             PolicyAll[discord.Webhook],
 	}
 }
 
 // Config lets you configure your Caches instance.
 type Config struct {
 	CacheFlags Flags
+	// BackfillFlags are the Flags of the caches which are filled from the REST API once a guild becomes ready.
+	// Only FlagAutoModerationRules, FlagBans, FlagInvites, FlagIntegrations and FlagWebhooks are supported.
+	BackfillFlags Flags
+	// Metrics instruments all default caches if set.
+	Metrics *Metrics
 
 	SelfUserCache SelfUserCache
 
@@ -36,6 +47,9 @@ type Config struct {
 	ChannelCache       ChannelCache
 	ChannelCachePolicy Policy[discord.GuildChannel]
 
+	PrivateChannelCache       PrivateChannelCache
+	PrivateChannelCachePolicy Policy[discord.PrivateChannel]
+
 	StageInstanceCache       StageInstanceCache
 	StageInstanceCachePolicy Policy[discord.StageInstance]
 
@@ -59,12 +73,29 @@ type Config struct {
 
 	MessageCache       MessageCache
 	MessageCachePolicy Policy[discord.Message]
+	// MessageCacheBounds makes the default MessageCache a BoundedGroupedCache grouped by channel if set.
+	MessageCacheBounds []BoundedCacheConfigOpt
 
 	EmojiCache       EmojiCache
 	EmojiCachePolicy Policy[discord.Emoji]
 
 	StickerCache       StickerCache
 	StickerCachePolicy Policy[discord.Sticker]
+
+	AutoModerationRuleCache       AutoModerationRuleCache
+	AutoModerationRuleCachePolicy Policy[discord.AutoModerationRule]
+
+	BanCache       BanCache
+	BanCachePolicy Policy[discord.Ban]
+
+	InviteCache       InviteCache
+	InviteCachePolicy Policy[discord.ExtendedInvite]
+
+	IntegrationCache       IntegrationCache
+	IntegrationCachePolicy Policy[discord.Integration]
+
+	WebhookCache       WebhookCache
+	WebhookCachePolicy Policy[discord.Webhook]
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Caches.
@@ -79,40 +110,66 @@ func (c *Config) Apply(opts []ConfigOpt) {
 		c.SelfUserCache = NewSelfUserCache()
 	}
 	if c.GuildCache == nil {
-		c.GuildCache = NewGuildCache(NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
+		c.GuildCache = NewGuildCache(instrumentCache(c.Metrics, "guilds", NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy)), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
 	}
 	if c.ChannelCache == nil {
-		c.ChannelCache = NewChannelCache(NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy))
+		c.ChannelCache = NewChannelCache(instrumentCache(c.Metrics, "channels", NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy)))
+	}
+	if c.PrivateChannelCache == nil {
+		c.PrivateChannelCache = NewPrivateChannelCache(instrumentCache(c.Metrics, "private_channels", NewCache[discord.PrivateChannel](c.CacheFlags, FlagPrivateChannels, c.PrivateChannelCachePolicy)))
 	}
 	if c.StageInstanceCache == nil {
-		c.StageInstanceCache = NewStageInstanceCache(NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy))
+		c.StageInstanceCache = NewStageInstanceCache(instrumentGroupedCache(c.Metrics, "stage_instances", NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy)))
 	}
 	if c.GuildScheduledEventCache == nil {
-		c.GuildScheduledEventCache = NewGuildScheduledEventCache(NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
+		c.GuildScheduledEventCache = NewGuildScheduledEventCache(instrumentGroupedCache(c.Metrics, "guild_scheduled_events", NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy)))
 	}
 	if c.RoleCache == nil {
-		c.RoleCache = NewRoleCache(NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy))
+		c.RoleCache = NewRoleCache(instrumentGroupedCache(c.Metrics, "roles", NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy)))
 	}
 	if c.MemberCache == nil {
-		c.MemberCache = NewMemberCache(NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy))
+		c.MemberCache = NewMemberCache(instrumentGroupedCache(c.Metrics, "members", NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy)))
 	}
 	if c.ThreadMemberCache == nil {
-		c.ThreadMemberCache = NewThreadMemberCache(NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy))
+		c.ThreadMemberCache = NewThreadMemberCache(instrumentGroupedCache(c.Metrics, "thread_members", NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy)))
 	}
 	if c.PresenceCache == nil {
-		c.PresenceCache = NewPresenceCache(NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy))
+		c.PresenceCache = NewPresenceCache(instrumentGroupedCache(c.Metrics, "presences", NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy)))
 	}
 	if c.VoiceStateCache == nil {
-		c.VoiceStateCache = NewVoiceStateCache(NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy))
+		c.VoiceStateCache = NewVoiceStateCache(instrumentGroupedCache(c.Metrics, "voice_states", NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy)))
 	}
 	if c.MessageCache == nil {
-		c.MessageCache = NewMessageCache(NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy))
+		if c.MessageCacheBounds != nil {
+			c.MessageCache = NewMessageCache(instrumentGroupedCache(c.Metrics, "messages", NewBoundedGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy, c.MessageCacheBounds...)))
+		} else {
+			c.MessageCache = NewMessageCache(instrumentGroupedCache(c.Metrics, "messages", NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy)))
+		}
 	}
 	if c.EmojiCache == nil {
-		c.EmojiCache = NewEmojiCache(NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy))
+		c.EmojiCache = NewEmojiCache(instrumentGroupedCache(c.Metrics, "emojis", NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy)))
 	}
 	if c.StickerCache == nil {
-		c.StickerCache = NewStickerCache(NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy))
+		c.StickerCache = NewStickerCache(instrumentGroupedCache(c.Metrics, "stickers", NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy)))
+	}
+	if c.AutoModerationRuleCache == nil {
+		c.AutoModerationRuleCache = NewAutoModerationRuleCache(instrumentGroupedCache(c.Metrics, "auto_moderation_rules", NewGroupedCache[discord.AutoModerationRule](c.CacheFlags, FlagAutoModerationRules, c.AutoModerationRuleCachePolicy)))
+	}
+	if c.BanCache == nil {
+		c.BanCache = NewBanCache(instrumentGroupedCache(c.Metrics, "bans", NewGroupedCache[discord.Ban](c.CacheFlags, FlagBans, c.BanCachePolicy)))
+	}
+	if c.InviteCache == nil {
+		inviteCache := newInviteCache(c.CacheFlags, FlagInvites, c.InviteCachePolicy)
+		if c.Metrics != nil {
+			c.Metrics.register(inviteCache)
+		}
+		c.InviteCache = inviteCache
+	}
+	if c.IntegrationCache == nil {
+		c.IntegrationCache = NewIntegrationCache(instrumentGroupedCache(c.Metrics, "integrations", NewGroupedCache[discord.Integration](c.CacheFlags, FlagIntegrations, c.IntegrationCachePolicy)))
+	}
+	if c.WebhookCache == nil {
+		c.WebhookCache = NewWebhookCache(instrumentGroupedCache(c.Metrics, "webhooks", NewGroupedCache[discord.Webhook](c.CacheFlags, FlagWebhooks, c.WebhookCachePolicy)))
 	}
 }
 
@@ -123,6 +180,20 @@ func WithCaches(flags ...Flags) ConfigOpt {
 	}
 }
 
+// WithBackfill sets the BackfillFlags of the Config. The flags also need to be enabled with WithCaches.
+func WithBackfill(flags ...Flags) ConfigOpt {
+	return func(config *Config) {
+		config.BackfillFlags = config.BackfillFlags.Add(flags...)
+	}
+}
+
+// WithMetrics instruments all default caches and registers them with the given Metrics.
+func WithMetrics(metrics *Metrics) ConfigOpt {
+	return func(config *Config) {
+		config.Metrics = metrics
+	}
+}
+
 // WithGuildCachePolicy sets the Policy[discord.Guild] of the Config.
 func WithGuildCachePolicy(policy Policy[discord.Guild]) ConfigOpt {
 	return func(config *Config) {
@@ -151,6 +222,20 @@ func WithChannelCache(channelCache ChannelCache) ConfigOpt {
 	}
 }
 
+// WithPrivateChannelCachePolicy sets the Policy[discord.PrivateChannel] of the Config.
+func WithPrivateChannelCachePolicy(policy Policy[discord.PrivateChannel]) ConfigOpt {
+	return func(config *Config) {
+		config.PrivateChannelCachePolicy = policy
+	}
+}
+
+// WithPrivateChannelCache sets the PrivateChannelCache of the Config.
+func WithPrivateChannelCache(privateChannelCache PrivateChannelCache) ConfigOpt {
+	return func(config *Config) {
+		config.PrivateChannelCache = privateChannelCache
+	}
+}
+
 // WithStageInstanceCachePolicy sets the Policy[discord.Guild] of the Config.
 func WithStageInstanceCachePolicy(policy Policy[discord.StageInstance]) ConfigOpt {
 	return func(config *Config) {
@@ -256,6 +341,14 @@ func WithMessageCachePolicy(policy Policy[discord.Message]) ConfigOpt {
 	}
 }
 
+// WithMessageCacheBounds limits the default MessageCache with the given BoundedCacheConfigOpt(s).
+// The MaxGroupSize applies per channel. Use MessageCacheEvictionStats to observe the evictions.
+func WithMessageCacheBounds(opts ...BoundedCacheConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.MessageCacheBounds = append(config.MessageCacheBounds, opts...)
+	}
+}
+
 // WithMessageCache sets the MessageCache of the Config.
 func WithMessageCache(messageCache MessageCache) ConfigOpt {
 	return func(config *Config) {
@@ -290,3 +383,73 @@ func WithStickerCache(stickerCache StickerCache) ConfigOpt {
 		config.StickerCache = stickerCache
 	}
 }
+
+// WithAutoModerationRuleCachePolicy sets the Policy[discord.AutoModerationRule] of the Config.
+func WithAutoModerationRuleCachePolicy(policy Policy[discord.AutoModerationRule]) ConfigOpt {
+	return func(config *Config) {
+		config.AutoModerationRuleCachePolicy = policy
+	}
+}
+
+// WithAutoModerationRuleCache sets the AutoModerationRuleCache of the Config.
+func WithAutoModerationRuleCache(autoModerationRuleCache AutoModerationRuleCache) ConfigOpt {
+	return func(config *Config) {
+		config.AutoModerationRuleCache = autoModerationRuleCache
+	}
+}
+
+// WithBanCachePolicy sets the Policy[discord.Ban] of the Config.
+func WithBanCachePolicy(policy Policy[discord.Ban]) ConfigOpt {
+	return func(config *Config) {
+		config.BanCachePolicy = policy
+	}
+}
+
+// WithBanCache sets the BanCache of the Config.
+func WithBanCache(banCache BanCache) ConfigOpt {
+	return func(config *Config) {
+		config.BanCache = banCache
+	}
+}
+
+// WithInviteCachePolicy sets the Policy[discord.ExtendedInvite] of the Config.
+func WithInviteCachePolicy(policy Policy[discord.ExtendedInvite]) ConfigOpt {
+	return func(config *Config) {
+		config.InviteCachePolicy = policy
+	}
+}
+
+// WithInviteCache sets the InviteCache of the Config.
+func WithInviteCache(inviteCache InviteCache) ConfigOpt {
+	return func(config *Config) {
+		config.InviteCache = inviteCache
+	}
+}
+
+// WithIntegrationCachePolicy sets the Policy[discord.Integration] of the Config.
+func WithIntegrationCachePolicy(policy Policy[discord.Integration]) ConfigOpt {
+	return func(config *Config) {
+		config.IntegrationCachePolicy = policy
+	}
+}
+
+// WithIntegrationCache sets the IntegrationCache of the Config.
+func WithIntegrationCache(integrationCache IntegrationCache) ConfigOpt {
+	return func(config *Config) {
+		config.IntegrationCache = integrationCache
+	}
+}
+
+// WithWebhookCachePolicy sets the Policy[discord.Webhook] of the Config.
+func WithWebhookCachePolicy(policy Policy[discord.Webhook]) ConfigOpt {
+	return func(config *Config) {
+		config.WebhookCachePolicy = policy
+	}
+}
+
+// WithWebhookCache sets the WebhookCache of the Config.
+func WithWebhookCache(webhookCache WebhookCache) ConfigOpt {
+	return func(config *Config) {
+		config.WebhookCache = webhookCache
+	}
+}
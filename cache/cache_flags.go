@@ -19,6 +19,12 @@ const (
 	FlagStickers
 	FlagVoiceStates
 	FlagStageInstances
+	FlagPrivateChannels
+	FlagAutoModerationRules
+	FlagBans
+	FlagInvites
+	FlagIntegrations
+	FlagWebhooks
 
 	FlagsNone Flags = 0
 	FlagsAll        = FlagGuilds |
@@ -32,7 +38,13 @@ const (
 		FlagEmojis |
 		FlagStickers |
 		FlagVoiceStates |
-		FlagStageInstances
+		FlagStageInstances |
+		FlagPrivateChannels |
+		FlagAutoModerationRules |
+		FlagBans |
+		FlagInvites |
+		FlagIntegrations |
+		FlagWebhooks
 )
 
 // Add allows you to add multiple bits together, producing a new bit
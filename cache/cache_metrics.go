@@ -0,0 +1,369 @@
+package cache
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// CacheStats holds the counters of an InstrumentedCache or InstrumentedGroupedCache at a point in time.
+type CacheStats struct {
+	Name    string `json:"name"`
+	Gets    uint64 `json:"gets"`
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Puts    uint64 `json:"puts"`
+	Removes uint64 `json:"removes"`
+	Len     int    `json:"len"`
+	// Evictions is only set if the wrapped cache implements EvictionStatsProvider.
+	Evictions EvictionStats `json:"evictions"`
+	// ApproxBytes is the approximate memory used by the cached entities, extrapolated from a sample of them.
+	// It is 0 if MetricsConfig.SampleSize is 0.
+	ApproxBytes int64 `json:"approx_bytes"`
+}
+
+// HitRate returns the share of gets which were hits or 0 if there were no gets.
+func (s CacheStats) HitRate() float64 {
+	if s.Gets == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Gets)
+}
+
+// NewMetrics returns a new Metrics. Register caches with InstrumentCache, InstrumentGroupedCache or WithMetrics.
+func NewMetrics(opts ...MetricsConfigOpt) *Metrics {
+	config := DefaultMetricsConfig()
+	config.Apply(opts)
+
+	return &Metrics{
+		config: *config,
+	}
+}
+
+// Metrics collects the CacheStats of all instrumented caches registered with it.
+// It implements http.Handler and serves the stats in the OpenMetrics text format.
+type Metrics struct {
+	config MetricsConfig
+
+	mu      sync.Mutex
+	sources []statsSource
+}
+
+type statsSource interface {
+	stats(sampleSize int) CacheStats
+}
+
+func (m *Metrics) register(source statsSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sources = append(m.sources, source)
+}
+
+// Stats returns the CacheStats of all registered caches sorted by name.
+// Approximating the memory usage iterates over all entities, so this should not be called in a hot path.
+func (m *Metrics) Stats() []CacheStats {
+	m.mu.Lock()
+	sources := slices.Clone(m.sources)
+	m.mu.Unlock()
+
+	stats := make([]CacheStats, 0, len(sources))
+	for _, source := range sources {
+		stats = append(stats, source.stats(m.config.SampleSize))
+	}
+	slices.SortFunc(stats, func(a, b CacheStats) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return stats
+}
+
+// Expvar returns an expvar.Var reporting the Stats. Publish it with expvar.Publish.
+func (m *Metrics) Expvar() expvar.Var {
+	return expvar.Func(func() any {
+		return m.Stats()
+	})
+}
+
+// WriteOpenMetrics writes the Stats in the OpenMetrics text format.
+func (m *Metrics) WriteOpenMetrics(w io.Writer) error {
+	stats := m.Stats()
+	ns := m.config.Namespace
+	if ns != "" {
+		ns += "_"
+	}
+
+	var sb strings.Builder
+	writeMetric := func(name string, metricType string, help string, value func(s CacheStats) string) {
+		suffix := ""
+		if metricType == "counter" {
+			suffix = "_total"
+		}
+		fmt.Fprintf(&sb, "# TYPE %scache_%s %s\n", ns, name, metricType)
+		fmt.Fprintf(&sb, "# HELP %scache_%s %s\n", ns, name, help)
+		for _, s := range stats {
+			fmt.Fprintf(&sb, "%scache_%s%s{cache=%q} %s\n", ns, name, suffix, s.Name, value(s))
+		}
+	}
+	counter := func(get func(s CacheStats) uint64) func(s CacheStats) string {
+		return func(s CacheStats) string {
+			return fmt.Sprint(get(s))
+		}
+	}
+
+	writeMetric("gets", "counter", "Number of gets.", counter(func(s CacheStats) uint64 { return s.Gets }))
+	writeMetric("hits", "counter", "Number of gets which found the entity.", counter(func(s CacheStats) uint64 { return s.Hits }))
+	writeMetric("misses", "counter", "Number of gets which did not find the entity.", counter(func(s CacheStats) uint64 { return s.Misses }))
+	writeMetric("puts", "counter", "Number of puts.", counter(func(s CacheStats) uint64 { return s.Puts }))
+	writeMetric("removes", "counter", "Number of removed entities.", counter(func(s CacheStats) uint64 { return s.Removes }))
+	writeMetric("evictions", "counter", "Number of evicted entities.", counter(func(s CacheStats) uint64 { return s.Evictions.Total() }))
+	writeMetric("entities", "gauge", "Number of cached entities.", func(s CacheStats) string { return fmt.Sprint(s.Len) })
+	if m.config.SampleSize > 0 {
+		writeMetric("approx_bytes", "gauge", "Approximate memory used by the cached entities.", func(s CacheStats) string { return fmt.Sprint(s.ApproxBytes) })
+	}
+	sb.WriteString("# EOF\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	_ = m.WriteOpenMetrics(w)
+}
+
+type cacheCounters struct {
+	gets    atomic.Uint64
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+	puts    atomic.Uint64
+	removes atomic.Uint64
+}
+
+func (c *cacheCounters) get(ok bool) {
+	c.gets.Add(1)
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+func (c *cacheCounters) remove(ok bool) {
+	if ok {
+		c.removes.Add(1)
+	}
+}
+
+func (c *cacheCounters) stats(name string, length int, cache any) CacheStats {
+	stats := CacheStats{
+		Name:    name,
+		Gets:    c.gets.Load(),
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Puts:    c.puts.Load(),
+		Removes: c.removes.Load(),
+		Len:     length,
+	}
+	if provider, ok := cache.(EvictionStatsProvider); ok {
+		stats.Evictions = provider.EvictionStats()
+	}
+	return stats
+}
+
+var _ Cache[any] = (*InstrumentedCache[any])(nil)
+
+// InstrumentCache wraps the Cache and records its usage under the given name.
+// If metrics is not nil, the InstrumentedCache is registered with it.
+func InstrumentCache[T any](metrics *Metrics, name string, cache Cache[T]) *InstrumentedCache[T] {
+	c := &InstrumentedCache[T]{
+		name:  name,
+		cache: cache,
+	}
+	if metrics != nil {
+		metrics.register(c)
+	}
+	return c
+}
+
+// InstrumentedCache is a Cache which counts the gets, hits, misses, puts & removes of the wrapped Cache.
+type InstrumentedCache[T any] struct {
+	name     string
+	cache    Cache[T]
+	counters cacheCounters
+}
+
+// Unwrap returns the wrapped Cache.
+func (c *InstrumentedCache[T]) Unwrap() Cache[T] {
+	return c.cache
+}
+
+// Stats returns the CacheStats of the InstrumentedCache approximating the memory usage from up to sampleSize entities.
+func (c *InstrumentedCache[T]) Stats(sampleSize int) CacheStats {
+	return c.stats(sampleSize)
+}
+
+func (c *InstrumentedCache[T]) stats(sampleSize int) CacheStats {
+	stats := c.counters.stats(c.name, c.cache.Len(), c.cache)
+	if sampleSize > 0 {
+		var sampler sizeSampler
+		c.cache.ForEach(func(entity T) {
+			sampler.add(entity, sampleSize)
+		})
+		stats.ApproxBytes = sampler.estimate(stats.Len)
+	}
+	return stats
+}
+
+func (c *InstrumentedCache[T]) Get(id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Get(id)
+	c.counters.get(ok)
+	return entity, ok
+}
+
+func (c *InstrumentedCache[T]) Put(id snowflake.ID, entity T) {
+	c.counters.puts.Add(1)
+	c.cache.Put(id, entity)
+}
+
+func (c *InstrumentedCache[T]) Remove(id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Remove(id)
+	c.counters.remove(ok)
+	return entity, ok
+}
+
+func (c *InstrumentedCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.cache.RemoveIf(func(entity T) bool {
+		ok := filterFunc(entity)
+		c.counters.remove(ok)
+		return ok
+	})
+}
+
+func (c *InstrumentedCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *InstrumentedCache[T]) ForEach(forEachFunc func(entity T)) {
+	c.cache.ForEach(forEachFunc)
+}
+
+var _ GroupedCache[any] = (*InstrumentedGroupedCache[any])(nil)
+
+// InstrumentGroupedCache wraps the GroupedCache and records its usage under the given name.
+// If metrics is not nil, the InstrumentedGroupedCache is registered with it.
+func InstrumentGroupedCache[T any](metrics *Metrics, name string, cache GroupedCache[T]) *InstrumentedGroupedCache[T] {
+	c := &InstrumentedGroupedCache[T]{
+		name:  name,
+		cache: cache,
+	}
+	if metrics != nil {
+		metrics.register(c)
+	}
+	return c
+}
+
+// InstrumentedGroupedCache is a GroupedCache which counts the gets, hits, misses, puts & removes of the wrapped GroupedCache.
+type InstrumentedGroupedCache[T any] struct {
+	name     string
+	cache    GroupedCache[T]
+	counters cacheCounters
+}
+
+// Unwrap returns the wrapped GroupedCache.
+func (c *InstrumentedGroupedCache[T]) Unwrap() GroupedCache[T] {
+	return c.cache
+}
+
+// Stats returns the CacheStats of the InstrumentedGroupedCache approximating the memory usage from up to sampleSize entities.
+func (c *InstrumentedGroupedCache[T]) Stats(sampleSize int) CacheStats {
+	return c.stats(sampleSize)
+}
+
+func (c *InstrumentedGroupedCache[T]) stats(sampleSize int) CacheStats {
+	stats := c.counters.stats(c.name, c.cache.Len(), c.cache)
+	if sampleSize > 0 {
+		var sampler sizeSampler
+		c.cache.ForEach(func(_ snowflake.ID, entity T) {
+			sampler.add(entity, sampleSize)
+		})
+		stats.ApproxBytes = sampler.estimate(stats.Len)
+	}
+	return stats
+}
+
+func (c *InstrumentedGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Get(groupID, id)
+	c.counters.get(ok)
+	return entity, ok
+}
+
+func (c *InstrumentedGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	c.counters.puts.Add(1)
+	c.cache.Put(groupID, id, entity)
+}
+
+func (c *InstrumentedGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Remove(groupID, id)
+	c.counters.remove(ok)
+	return entity, ok
+}
+
+func (c *InstrumentedGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	n := c.cache.GroupLen(groupID)
+	c.cache.GroupRemove(groupID)
+	c.counters.removes.Add(uint64(n))
+}
+
+func (c *InstrumentedGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.cache.RemoveIf(func(groupID snowflake.ID, entity T) bool {
+		ok := filterFunc(groupID, entity)
+		c.counters.remove(ok)
+		return ok
+	})
+}
+
+func (c *InstrumentedGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.cache.GroupRemoveIf(groupID, func(groupID snowflake.ID, entity T) bool {
+		ok := filterFunc(groupID, entity)
+		c.counters.remove(ok)
+		return ok
+	})
+}
+
+func (c *InstrumentedGroupedCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *InstrumentedGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return c.cache.GroupLen(groupID)
+}
+
+func (c *InstrumentedGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	c.cache.ForEach(forEachFunc)
+}
+
+func (c *InstrumentedGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.cache.GroupForEach(groupID, forEachFunc)
+}
+
+// instrumentCache wraps the Cache with InstrumentCache if metrics is not nil.
+func instrumentCache[T any](metrics *Metrics, name string, cache Cache[T]) Cache[T] {
+	if metrics == nil {
+		return cache
+	}
+	return InstrumentCache(metrics, name, cache)
+}
+
+// instrumentGroupedCache wraps the GroupedCache with InstrumentGroupedCache if metrics is not nil.
+func instrumentGroupedCache[T any](metrics *Metrics, name string, cache GroupedCache[T]) GroupedCache[T] {
+	if metrics == nil {
+		return cache
+	}
+	return InstrumentGroupedCache(metrics, name, cache)
+}
@@ -0,0 +1,41 @@
+package cache
+
+// DefaultMetricsConfig returns a MetricsConfig with sensible defaults.
+func DefaultMetricsConfig() *MetricsConfig {
+	return &MetricsConfig{
+		Namespace:  "disgo",
+		SampleSize: 64,
+	}
+}
+
+// MetricsConfig is used to configure Metrics.
+type MetricsConfig struct {
+	// Namespace is the prefix of all metric names written by Metrics.WriteOpenMetrics.
+	Namespace string
+	// SampleSize is the number of entities per cache which are measured to approximate its memory usage. 0 disables the approximation.
+	SampleSize int
+}
+
+// MetricsConfigOpt is used to functionally configure a MetricsConfig.
+type MetricsConfigOpt func(config *MetricsConfig)
+
+// Apply applies the given MetricsConfigOpt(s) to the MetricsConfig.
+func (c *MetricsConfig) Apply(opts []MetricsConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithMetricsNamespace sets the metric name prefix of the MetricsConfig.
+func WithMetricsNamespace(namespace string) MetricsConfigOpt {
+	return func(config *MetricsConfig) {
+		config.Namespace = namespace
+	}
+}
+
+// WithMetricsSampleSize sets the number of entities per cache which are measured to approximate its memory usage.
+func WithMetricsSampleSize(sampleSize int) MetricsConfigOpt {
+	return func(config *MetricsConfig) {
+		config.SampleSize = sampleSize
+	}
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestInstrumentedGroupedCache(t *testing.T) {
+	metrics := NewMetrics()
+	c := InstrumentGroupedCache[string](metrics, "test", NewGroupedCache[string](FlagsAll, FlagsNone, nil))
+
+	c.Put(1, 1, "a")
+	c.Put(1, 2, "bb")
+	c.Put(2, 3, "ccc")
+	_, ok := c.Get(1, 1)
+	assert.True(t, ok)
+	_, ok = c.Get(1, 4)
+	assert.False(t, ok)
+	c.Remove(1, 2)
+	c.GroupRemove(2)
+
+	stats := metrics.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, CacheStats{
+		Name:        "test",
+		Gets:        2,
+		Hits:        1,
+		Misses:      1,
+		Puts:        3,
+		Removes:     2,
+		Len:         1,
+		ApproxBytes: int64(approxSize("a")),
+	}, stats[0])
+	assert.Equal(t, 0.5, stats[0].HitRate())
+
+	var sb strings.Builder
+	require.NoError(t, metrics.WriteOpenMetrics(&sb))
+	assert.Contains(t, sb.String(), `disgo_cache_hits_total{cache="test"} 1`)
+	assert.Contains(t, sb.String(), `disgo_cache_entities{cache="test"} 1`)
+	assert.True(t, strings.HasSuffix(sb.String(), "# EOF\n"))
+}
+
+func TestConfigMetrics(t *testing.T) {
+	metrics := NewMetrics(WithMetricsSampleSize(0))
+	caches := New(WithCaches(FlagsAll), WithMetrics(metrics), WithMessageCacheBounds(WithMaxSize(1)))
+
+	caches.Guild(snowflake.ID(1))
+	for _, stats := range metrics.Stats() {
+		if stats.Name == "guilds" {
+			assert.Equal(t, uint64(1), stats.Misses)
+		}
+	}
+	_, ok := MessageCacheEvictionStats(caches)
+	assert.True(t, ok)
+}
+
+func TestInviteCacheMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	caches := New(WithCaches(FlagInvites), WithMetrics(metrics))
+
+	caches.AddInvite(1, discord.ExtendedInvite{Invite: discord.Invite{Code: "a"}})
+	caches.AddInvite(1, discord.ExtendedInvite{Invite: discord.Invite{Code: "b"}})
+	caches.AddInvite(2, discord.ExtendedInvite{Invite: discord.Invite{Code: "c"}})
+	_, ok := caches.Invite(1, "a")
+	assert.True(t, ok)
+	_, ok = caches.Invite(1, "d")
+	assert.False(t, ok)
+	caches.RemoveInvite(1, "a")
+	caches.RemoveInvitesByGuildID(1)
+
+	var stats CacheStats
+	for _, s := range metrics.Stats() {
+		if s.Name == "invites" {
+			stats = s
+		}
+	}
+	assert.Equal(t, CacheStats{
+		Name:        "invites",
+		Gets:        2,
+		Hits:        1,
+		Misses:      1,
+		Puts:        3,
+		Removes:     2,
+		Len:         1,
+		ApproxBytes: stats.ApproxBytes,
+	}, stats)
+	assert.Positive(t, stats.ApproxBytes)
+}
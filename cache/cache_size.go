@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"reflect"
+	"time"
+)
+
+// maxSizeDepth limits how deep approxSize follows pointers, discord entities are not nested deeper.
+const maxSizeDepth = 16
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// sizeSampler approximates the memory usage of a cache from the first entities passed to add.
+type sizeSampler struct {
+	samples int
+	bytes   int64
+}
+
+func (s *sizeSampler) add(entity any, sampleSize int) {
+	if s.samples >= sampleSize {
+		return
+	}
+	s.samples++
+	s.bytes += int64(approxSize(entity))
+}
+
+// estimate extrapolates the sampled size to all entities.
+func (s *sizeSampler) estimate(entities int) int64 {
+	if s.samples == 0 {
+		return 0
+	}
+	return s.bytes * int64(entities) / int64(s.samples)
+}
+
+// approxSize returns the approximate number of bytes used by the value, including all memory it references.
+// Memory shared by several values, like the same string or *time.Location, is counted for each of them.
+func approxSize(v any) int {
+	if v == nil {
+		return 0
+	}
+	rv := reflect.ValueOf(v)
+	return int(rv.Type().Size()) + heapSize(rv, 0)
+}
+
+// heapSize returns the number of bytes referenced by the value which are not part of its inline size.
+func heapSize(v reflect.Value, depth int) int {
+	if depth > maxSizeDepth {
+		return 0
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len()
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return 0
+		}
+		return int(v.Type().Elem().Size()) + heapSize(v.Elem(), depth+1)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int(elem.Type().Size()) + heapSize(elem, depth+1)
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		size := v.Cap() * int(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			size += heapSize(v.Index(i), depth+1)
+		}
+		return size
+
+	case reflect.Array:
+		var size int
+		for i := 0; i < v.Len(); i++ {
+			size += heapSize(v.Index(i), depth+1)
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() {
+			return 0
+		}
+		entrySize := int(v.Type().Key().Size() + v.Type().Elem().Size())
+		size := v.Len() * entrySize
+		iter := v.MapRange()
+		for iter.Next() {
+			size += heapSize(iter.Key(), depth+1) + heapSize(iter.Value(), depth+1)
+		}
+		return size
+
+	case reflect.Struct:
+		// the location of a time.Time is shared
+		if v.Type() == timeType {
+			return 0
+		}
+		var size int
+		for i := 0; i < v.NumField(); i++ {
+			size += heapSize(v.Field(i), depth+1)
+		}
+		return size
+
+	default:
+		return 0
+	}
+}
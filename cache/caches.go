@@ -1,8 +1,8 @@
 package cache
 
 import (
+	"io"
 	"sync"
-	"time"
 
 	"github.com/disgoorg/snowflake/v2"
 
@@ -175,6 +175,111 @@ func (c *channelCacheImpl) RemoveChannelsByGuildID(guildID snowflake.ID) {
 	})
 }
 
+type PrivateChannelCache interface {
+	PrivateChannel(channelID snowflake.ID) (discord.PrivateChannel, bool)
+	DMChannel(channelID snowflake.ID) (discord.DMChannel, bool)
+	DMChannelByUserID(userID snowflake.ID) (discord.DMChannel, bool)
+	PrivateChannelsForEach(fn func(channel discord.PrivateChannel))
+	PrivateChannelsLen() int
+	AddPrivateChannel(channel discord.PrivateChannel)
+	RemovePrivateChannel(channelID snowflake.ID) (discord.PrivateChannel, bool)
+}
+
+func NewPrivateChannelCache(cache Cache[discord.PrivateChannel]) PrivateChannelCache {
+	return &privateChannelCacheImpl{
+		cache:     cache,
+		dmsByUser: map[snowflake.ID]snowflake.ID{},
+	}
+}
+
+type privateChannelCacheImpl struct {
+	cache Cache[discord.PrivateChannel]
+
+	// dmsByUser maps recipient IDs to discord.DMChannel IDs
+	dmsByUserMu sync.RWMutex
+	dmsByUser   map[snowflake.ID]snowflake.ID
+}
+
+func (c *privateChannelCacheImpl) PrivateChannel(channelID snowflake.ID) (discord.PrivateChannel, bool) {
+	return c.cache.Get(channelID)
+}
+
+func (c *privateChannelCacheImpl) DMChannel(channelID snowflake.ID) (discord.DMChannel, bool) {
+	if ch, ok := c.PrivateChannel(channelID); ok {
+		if dmCh, ok := ch.(discord.DMChannel); ok {
+			return dmCh, true
+		}
+	}
+	return discord.DMChannel{}, false
+}
+
+func (c *privateChannelCacheImpl) DMChannelByUserID(userID snowflake.ID) (discord.DMChannel, bool) {
+	c.dmsByUserMu.RLock()
+	channelID, ok := c.dmsByUser[userID]
+	c.dmsByUserMu.RUnlock()
+	if ok {
+		if dmCh, ok := c.DMChannel(channelID); ok && isDMWith(dmCh, userID) {
+			return dmCh, true
+		}
+	}
+
+	// the index can miss channels which were put into the underlying Cache directly, for example by another process sharing a Backend
+	var (
+		dmChannel discord.DMChannel
+		found     bool
+	)
+	c.cache.ForEach(func(channel discord.PrivateChannel) {
+		if dmCh, ok := channel.(discord.DMChannel); ok && !found && isDMWith(dmCh, userID) {
+			dmChannel = dmCh
+			found = true
+		}
+	})
+	if found {
+		c.dmsByUserMu.Lock()
+		c.dmsByUser[userID] = dmChannel.ID()
+		c.dmsByUserMu.Unlock()
+	}
+	return dmChannel, found
+}
+
+func isDMWith(channel discord.DMChannel, userID snowflake.ID) bool {
+	recipient, ok := channel.Recipient()
+	return ok && recipient.ID == userID
+}
+
+func (c *privateChannelCacheImpl) PrivateChannelsForEach(fn func(channel discord.PrivateChannel)) {
+	c.cache.ForEach(fn)
+}
+
+func (c *privateChannelCacheImpl) PrivateChannelsLen() int {
+	return c.cache.Len()
+}
+
+func (c *privateChannelCacheImpl) AddPrivateChannel(channel discord.PrivateChannel) {
+	c.cache.Put(channel.ID(), channel)
+	if dmCh, ok := channel.(discord.DMChannel); ok {
+		if recipient, ok := dmCh.Recipient(); ok {
+			c.dmsByUserMu.Lock()
+			c.dmsByUser[recipient.ID] = dmCh.ID()
+			c.dmsByUserMu.Unlock()
+		}
+	}
+}
+
+func (c *privateChannelCacheImpl) RemovePrivateChannel(channelID snowflake.ID) (discord.PrivateChannel, bool) {
+	channel, ok := c.cache.Remove(channelID)
+	if dmCh, isDM := channel.(discord.DMChannel); ok && isDM {
+		if recipient, ok := dmCh.Recipient(); ok {
+			c.dmsByUserMu.Lock()
+			if c.dmsByUser[recipient.ID] == channelID {
+				delete(c.dmsByUser, recipient.ID)
+			}
+			c.dmsByUserMu.Unlock()
+		}
+	}
+	return channel, ok
+}
+
 type StageInstanceCache interface {
 	StageInstance(guildID snowflake.ID, stageInstanceID snowflake.ID) (discord.StageInstance, bool)
 	StageInstanceForEach(guildID snowflake.ID, fn func(stageInstance discord.StageInstance))
@@ -538,6 +643,26 @@ type messageCacheImpl struct {
 	cache GroupedCache[discord.Message]
 }
 
+// MessageCacheEvictionStats returns the EvictionStats of the given MessageCache or Caches if it evicts messages.
+func MessageCacheEvictionStats(cache MessageCache) (EvictionStats, bool) {
+	if caches, ok := cache.(*cachesImpl); ok {
+		return MessageCacheEvictionStats(caches.MessageCache)
+	}
+	if provider, ok := cache.(EvictionStatsProvider); ok {
+		return provider.EvictionStats(), true
+	}
+	if impl, ok := cache.(*messageCacheImpl); ok {
+		groupedCache := impl.cache
+		if instrumented, ok := groupedCache.(*InstrumentedGroupedCache[discord.Message]); ok {
+			groupedCache = instrumented.Unwrap()
+		}
+		if provider, ok := groupedCache.(EvictionStatsProvider); ok {
+			return provider.EvictionStats(), true
+		}
+	}
+	return EvictionStats{}, false
+}
+
 func (c *messageCacheImpl) Message(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
 	return c.cache.Get(channelID, messageID)
 }
@@ -671,11 +796,355 @@ func (c *stickerCacheImpl) RemoveStickersByGuildID(guildID snowflake.ID) {
 	c.cache.GroupRemove(guildID)
 }
 
+type AutoModerationRuleCache interface {
+	AutoModerationRule(guildID snowflake.ID, ruleID snowflake.ID) (discord.AutoModerationRule, bool)
+	AutoModerationRulesForEach(guildID snowflake.ID, fn func(rule discord.AutoModerationRule))
+	AutoModerationRulesAllLen() int
+	AutoModerationRulesLen(guildID snowflake.ID) int
+	AddAutoModerationRule(rule discord.AutoModerationRule)
+	RemoveAutoModerationRule(guildID snowflake.ID, ruleID snowflake.ID) (discord.AutoModerationRule, bool)
+	RemoveAutoModerationRulesByGuildID(guildID snowflake.ID)
+}
+
+func NewAutoModerationRuleCache(cache GroupedCache[discord.AutoModerationRule]) AutoModerationRuleCache {
+	return &autoModerationRuleCacheImpl{
+		cache: cache,
+	}
+}
+
+type autoModerationRuleCacheImpl struct {
+	cache GroupedCache[discord.AutoModerationRule]
+}
+
+func (c *autoModerationRuleCacheImpl) AutoModerationRule(guildID snowflake.ID, ruleID snowflake.ID) (discord.AutoModerationRule, bool) {
+	return c.cache.Get(guildID, ruleID)
+}
+
+func (c *autoModerationRuleCacheImpl) AutoModerationRulesForEach(guildID snowflake.ID, fn func(rule discord.AutoModerationRule)) {
+	c.cache.GroupForEach(guildID, fn)
+}
+
+func (c *autoModerationRuleCacheImpl) AutoModerationRulesAllLen() int {
+	return c.cache.Len()
+}
+
+func (c *autoModerationRuleCacheImpl) AutoModerationRulesLen(guildID snowflake.ID) int {
+	return c.cache.GroupLen(guildID)
+}
+
+func (c *autoModerationRuleCacheImpl) AddAutoModerationRule(rule discord.AutoModerationRule) {
+	c.cache.Put(rule.GuildID, rule.ID, rule)
+}
+
+func (c *autoModerationRuleCacheImpl) RemoveAutoModerationRule(guildID snowflake.ID, ruleID snowflake.ID) (discord.AutoModerationRule, bool) {
+	return c.cache.Remove(guildID, ruleID)
+}
+
+func (c *autoModerationRuleCacheImpl) RemoveAutoModerationRulesByGuildID(guildID snowflake.ID) {
+	c.cache.GroupRemove(guildID)
+}
+
+type BanCache interface {
+	Ban(guildID snowflake.ID, userID snowflake.ID) (discord.Ban, bool)
+	BansForEach(guildID snowflake.ID, fn func(ban discord.Ban))
+	BansAllLen() int
+	BansLen(guildID snowflake.ID) int
+	AddBan(guildID snowflake.ID, ban discord.Ban)
+	RemoveBan(guildID snowflake.ID, userID snowflake.ID) (discord.Ban, bool)
+	RemoveBansByGuildID(guildID snowflake.ID)
+}
+
+func NewBanCache(cache GroupedCache[discord.Ban]) BanCache {
+	return &banCacheImpl{
+		cache: cache,
+	}
+}
+
+type banCacheImpl struct {
+	cache GroupedCache[discord.Ban]
+}
+
+func (c *banCacheImpl) Ban(guildID snowflake.ID, userID snowflake.ID) (discord.Ban, bool) {
+	return c.cache.Get(guildID, userID)
+}
+
+func (c *banCacheImpl) BansForEach(guildID snowflake.ID, fn func(ban discord.Ban)) {
+	c.cache.GroupForEach(guildID, fn)
+}
+
+func (c *banCacheImpl) BansAllLen() int {
+	return c.cache.Len()
+}
+
+func (c *banCacheImpl) BansLen(guildID snowflake.ID) int {
+	return c.cache.GroupLen(guildID)
+}
+
+func (c *banCacheImpl) AddBan(guildID snowflake.ID, ban discord.Ban) {
+	c.cache.Put(guildID, ban.User.ID, ban)
+}
+
+func (c *banCacheImpl) RemoveBan(guildID snowflake.ID, userID snowflake.ID) (discord.Ban, bool) {
+	return c.cache.Remove(guildID, userID)
+}
+
+func (c *banCacheImpl) RemoveBansByGuildID(guildID snowflake.ID) {
+	c.cache.GroupRemove(guildID)
+}
+
+type InviteCache interface {
+	Invite(guildID snowflake.ID, code string) (discord.ExtendedInvite, bool)
+	InvitesForEach(guildID snowflake.ID, fn func(invite discord.ExtendedInvite))
+	InvitesAllLen() int
+	InvitesLen(guildID snowflake.ID) int
+	AddInvite(guildID snowflake.ID, invite discord.ExtendedInvite)
+	RemoveInvite(guildID snowflake.ID, code string) (discord.ExtendedInvite, bool)
+	RemoveInvitesByGuildID(guildID snowflake.ID)
+}
+
+// NewInviteCache returns a new InviteCache. As invites are identified by their code instead of a snowflake.ID,
+// it does not use a GroupedCache but applies the Flags and Policy the same way.
+// It counts its usage itself as it can't be wrapped with InstrumentGroupedCache, see WithMetrics.
+func NewInviteCache(flags Flags, neededFlags Flags, policy Policy[discord.ExtendedInvite]) InviteCache {
+	return newInviteCache(flags, neededFlags, policy)
+}
+
+func newInviteCache(flags Flags, neededFlags Flags, policy Policy[discord.ExtendedInvite]) *inviteCacheImpl {
+	return &inviteCacheImpl{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		invites:     make(map[snowflake.ID]map[string]discord.ExtendedInvite),
+	}
+}
+
+type inviteCacheImpl struct {
+	mu          sync.RWMutex
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[discord.ExtendedInvite]
+	invites     map[snowflake.ID]map[string]discord.ExtendedInvite
+	counters    cacheCounters
+}
+
+func (c *inviteCacheImpl) stats(sampleSize int) CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var (
+		n       int
+		sampler sizeSampler
+	)
+	for _, invites := range c.invites {
+		n += len(invites)
+		if sampleSize > 0 {
+			for _, invite := range invites {
+				sampler.add(invite, sampleSize)
+			}
+		}
+	}
+	stats := c.counters.stats("invites", n, nil)
+	stats.ApproxBytes = sampler.estimate(n)
+	return stats
+}
+
+func (c *inviteCacheImpl) Invite(guildID snowflake.ID, code string) (discord.ExtendedInvite, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	invite, ok := c.invites[guildID][code]
+	c.counters.get(ok)
+	return invite, ok
+}
+
+func (c *inviteCacheImpl) InvitesForEach(guildID snowflake.ID, fn func(invite discord.ExtendedInvite)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, invite := range c.invites[guildID] {
+		fn(invite)
+	}
+}
+
+func (c *inviteCacheImpl) InvitesAllLen() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var n int
+	for _, invites := range c.invites {
+		n += len(invites)
+	}
+	return n
+}
+
+func (c *inviteCacheImpl) InvitesLen(guildID snowflake.ID) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.invites[guildID])
+}
+
+func (c *inviteCacheImpl) AddInvite(guildID snowflake.ID, invite discord.ExtendedInvite) {
+	c.counters.puts.Add(1)
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(invite) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.invites[guildID]; !ok {
+		c.invites[guildID] = make(map[string]discord.ExtendedInvite)
+	}
+	c.invites[guildID][invite.Code] = invite
+}
+
+func (c *inviteCacheImpl) RemoveInvite(guildID snowflake.ID, code string) (discord.ExtendedInvite, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	invite, ok := c.invites[guildID][code]
+	if ok {
+		delete(c.invites[guildID], code)
+		if len(c.invites[guildID]) == 0 {
+			delete(c.invites, guildID)
+		}
+	}
+	c.counters.remove(ok)
+	return invite, ok
+}
+
+func (c *inviteCacheImpl) RemoveInvitesByGuildID(guildID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counters.removes.Add(uint64(len(c.invites[guildID])))
+	delete(c.invites, guildID)
+}
+
+type IntegrationCache interface {
+	Integration(guildID snowflake.ID, integrationID snowflake.ID) (discord.Integration, bool)
+	IntegrationsForEach(guildID snowflake.ID, fn func(integration discord.Integration))
+	IntegrationsAllLen() int
+	IntegrationsLen(guildID snowflake.ID) int
+	AddIntegration(guildID snowflake.ID, integration discord.Integration)
+	RemoveIntegration(guildID snowflake.ID, integrationID snowflake.ID) (discord.Integration, bool)
+	RemoveIntegrationsByGuildID(guildID snowflake.ID)
+}
+
+func NewIntegrationCache(cache GroupedCache[discord.Integration]) IntegrationCache {
+	return &integrationCacheImpl{
+		cache: cache,
+	}
+}
+
+type integrationCacheImpl struct {
+	cache GroupedCache[discord.Integration]
+}
+
+func (c *integrationCacheImpl) Integration(guildID snowflake.ID, integrationID snowflake.ID) (discord.Integration, bool) {
+	return c.cache.Get(guildID, integrationID)
+}
+
+func (c *integrationCacheImpl) IntegrationsForEach(guildID snowflake.ID, fn func(integration discord.Integration)) {
+	c.cache.GroupForEach(guildID, fn)
+}
+
+func (c *integrationCacheImpl) IntegrationsAllLen() int {
+	return c.cache.Len()
+}
+
+func (c *integrationCacheImpl) IntegrationsLen(guildID snowflake.ID) int {
+	return c.cache.GroupLen(guildID)
+}
+
+func (c *integrationCacheImpl) AddIntegration(guildID snowflake.ID, integration discord.Integration) {
+	c.cache.Put(guildID, integration.ID(), integration)
+}
+
+func (c *integrationCacheImpl) RemoveIntegration(guildID snowflake.ID, integrationID snowflake.ID) (discord.Integration, bool) {
+	return c.cache.Remove(guildID, integrationID)
+}
+
+func (c *integrationCacheImpl) RemoveIntegrationsByGuildID(guildID snowflake.ID) {
+	c.cache.GroupRemove(guildID)
+}
+
+type WebhookCache interface {
+	Webhook(guildID snowflake.ID, webhookID snowflake.ID) (discord.Webhook, bool)
+	WebhooksForEach(guildID snowflake.ID, fn func(webhook discord.Webhook))
+	WebhooksAllLen() int
+	WebhooksLen(guildID snowflake.ID) int
+	AddWebhook(guildID snowflake.ID, webhook discord.Webhook)
+	RemoveWebhook(guildID snowflake.ID, webhookID snowflake.ID) (discord.Webhook, bool)
+	RemoveWebhooksByChannelID(guildID snowflake.ID, channelID snowflake.ID)
+	RemoveWebhooksByGuildID(guildID snowflake.ID)
+}
+
+func NewWebhookCache(cache GroupedCache[discord.Webhook]) WebhookCache {
+	return &webhookCacheImpl{
+		cache: cache,
+	}
+}
+
+type webhookCacheImpl struct {
+	cache GroupedCache[discord.Webhook]
+}
+
+func (c *webhookCacheImpl) Webhook(guildID snowflake.ID, webhookID snowflake.ID) (discord.Webhook, bool) {
+	return c.cache.Get(guildID, webhookID)
+}
+
+func (c *webhookCacheImpl) WebhooksForEach(guildID snowflake.ID, fn func(webhook discord.Webhook)) {
+	c.cache.GroupForEach(guildID, fn)
+}
+
+func (c *webhookCacheImpl) WebhooksAllLen() int {
+	return c.cache.Len()
+}
+
+func (c *webhookCacheImpl) WebhooksLen(guildID snowflake.ID) int {
+	return c.cache.GroupLen(guildID)
+}
+
+func (c *webhookCacheImpl) AddWebhook(guildID snowflake.ID, webhook discord.Webhook) {
+	c.cache.Put(guildID, webhook.ID(), webhook)
+}
+
+func (c *webhookCacheImpl) RemoveWebhook(guildID snowflake.ID, webhookID snowflake.ID) (discord.Webhook, bool) {
+	return c.cache.Remove(guildID, webhookID)
+}
+
+func (c *webhookCacheImpl) RemoveWebhooksByChannelID(guildID snowflake.ID, channelID snowflake.ID) {
+	c.cache.GroupRemoveIf(guildID, func(_ snowflake.ID, webhook discord.Webhook) bool {
+		return webhookChannelID(webhook) == channelID
+	})
+}
+
+func (c *webhookCacheImpl) RemoveWebhooksByGuildID(guildID snowflake.ID) {
+	c.cache.GroupRemove(guildID)
+}
+
+// webhookChannelID returns the channel ID of the discord.Webhook or 0 if it has none.
+func webhookChannelID(webhook discord.Webhook) snowflake.ID {
+	switch w := webhook.(type) {
+	case discord.IncomingWebhook:
+		return w.ChannelID
+	case discord.ChannelFollowerWebhook:
+		return w.ChannelID
+	default:
+		return 0
+	}
+}
+
 // Caches combines all different entity caches into one with some utility methods.
 type Caches interface {
 	SelfUserCache
 	GuildCache
 	ChannelCache
+	PrivateChannelCache
 	StageInstanceCache
 	GuildScheduledEventCache
 	RoleCache
@@ -686,10 +1155,18 @@ type Caches interface {
 	MessageCache
 	EmojiCache
 	StickerCache
+	AutoModerationRuleCache
+	BanCache
+	InviteCache
+	IntegrationCache
+	WebhookCache
 
 	// CacheFlags returns the current configured FLags of the caches.
 	CacheFlags() Flags
 
+	// BackfillFlags returns the Flags of the caches which are filled from the REST API once a guild becomes ready.
+	BackfillFlags() Flags
+
 	// MemberPermissions returns the calculated permissions of the given member.
 	// This requires the FlagRoles to be set.
 	MemberPermissions(member discord.Member) discord.Permissions
@@ -698,10 +1175,19 @@ type Caches interface {
 	// This requires the FlagRoles and FlagChannels to be set.
 	MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions
 
+	// CalculateMemberPermissions returns the discord.PermissionResult of the given member in the given channel or the guild if channel is nil.
+	// The result explains which role or overwrite granted or denied each permission. See discord.CalculatePermissions.
+	// This requires the FlagRoles to be set. For threads FlagChannels and FlagThreadMembers are needed to resolve the parent channel and membership.
+	CalculateMemberPermissions(member discord.Member, channel discord.GuildChannel) discord.PermissionResult
+
 	// MemberRoles returns all roles of the given member.
 	// This requires the FlagRoles to be set.
 	MemberRoles(member discord.Member) []discord.Role
 
+	// RoleHierarchy returns the discord.RoleHierarchy of the given guild to check whether members can moderate each other or assign roles.
+	// This requires the FlagGuilds and FlagRoles to be set.
+	RoleHierarchy(guildID snowflake.ID) (discord.RoleHierarchy, bool)
+
 	// AudioChannelMembers returns all members which are in the given audio channel.
 	// This requires the FlagVoiceStates to be set.
 	AudioChannelMembers(channel discord.GuildAudioChannel) []discord.Member
@@ -713,6 +1199,21 @@ type Caches interface {
 	// GuildThreadsInChannel returns all discord.GuildThread from the ChannelCache and a bool indicating if it exists.
 	GuildThreadsInChannel(channelID snowflake.ID) []discord.GuildThread
 
+	// ExportSnapshot writes a Snapshot of all caches as JSON to the io.Writer. See TakeSnapshot.
+	ExportSnapshot(w io.Writer) error
+
+	// ImportSnapshot reads a Snapshot written by ExportSnapshot from the io.Reader and restores it. See RestoreSnapshot.
+	ImportSnapshot(r io.Reader) error
+
+	// IsGuildStale returns whether the guild was restored from a Snapshot and not yet confirmed by the gateway.
+	IsGuildStale(guildID snowflake.ID) bool
+
+	// SetGuildStale sets whether the guild was restored from a Snapshot and not yet confirmed by the gateway.
+	SetGuildStale(guildID snowflake.ID, stale bool)
+
+	// StaleGuildIDs returns the IDs of all stale guilds.
+	StaleGuildIDs() []snowflake.ID
+
 	// GuildMessageChannel returns a discord.GuildMessageChannel from the ChannelCache and a bool indicating if it exists.
 	GuildMessageChannel(channelID snowflake.ID) (discord.GuildMessageChannel, bool)
 
@@ -763,6 +1264,7 @@ func New(opts ...ConfigOpt) Caches {
 		SelfUserCache:            config.SelfUserCache,
 		GuildCache:               config.GuildCache,
 		ChannelCache:             config.ChannelCache,
+		PrivateChannelCache:      config.PrivateChannelCache,
 		StageInstanceCache:       config.StageInstanceCache,
 		GuildScheduledEventCache: config.GuildScheduledEventCache,
 		RoleCache:                config.RoleCache,
@@ -773,14 +1275,22 @@ func New(opts ...ConfigOpt) Caches {
 		MessageCache:             config.MessageCache,
 		EmojiCache:               config.EmojiCache,
 		StickerCache:             config.StickerCache,
+		AutoModerationRuleCache:  config.AutoModerationRuleCache,
+		BanCache:                 config.BanCache,
+		InviteCache:              config.InviteCache,
+		IntegrationCache:         config.IntegrationCache,
+		WebhookCache:             config.WebhookCache,
+		staleGuilds:              NewSet[snowflake.ID](),
 	}
 }
 
 type cachesImpl struct {
-	config Config
+	config      Config
+	staleGuilds Set[snowflake.ID]
 
 	GuildCache
 	ChannelCache
+	PrivateChannelCache
 	StageInstanceCache
 	GuildScheduledEventCache
 	RoleCache
@@ -791,6 +1301,11 @@ type cachesImpl struct {
 	MessageCache
 	EmojiCache
 	StickerCache
+	AutoModerationRuleCache
+	BanCache
+	InviteCache
+	IntegrationCache
+	WebhookCache
 	SelfUserCache
 }
 
@@ -798,68 +1313,38 @@ func (c *cachesImpl) CacheFlags() Flags {
 	return c.config.CacheFlags
 }
 
-func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
-	if guild, ok := c.Guild(member.GuildID); ok && guild.OwnerID == member.User.ID {
-		return discord.PermissionsAll
-	}
-
-	var permissions discord.Permissions
-	if publicRole, ok := c.Role(member.GuildID, member.GuildID); ok {
-		permissions = publicRole.Permissions
-	}
+func (c *cachesImpl) BackfillFlags() Flags {
+	return c.config.BackfillFlags
+}
 
-	for _, role := range c.MemberRoles(member) {
-		permissions = permissions.Add(role.Permissions)
-		if permissions.Has(discord.PermissionAdministrator) {
-			return discord.PermissionsAll
-		}
-	}
-	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
-		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
-	}
-	return permissions
+func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
+	return c.CalculateMemberPermissions(member, nil).Permissions
 }
 
 func (c *cachesImpl) MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions {
-	permissions := c.MemberPermissions(member)
-	if permissions.Has(discord.PermissionAdministrator) {
-		return discord.PermissionsAll
-	}
-
-	var (
-		allow discord.Permissions
-		deny  discord.Permissions
-	)
+	return c.CalculateMemberPermissions(member, channel).Permissions
+}
 
-	if overwrite, ok := channel.PermissionOverwrites().Role(channel.GuildID()); ok {
-		permissions |= overwrite.Allow
-		permissions &= ^overwrite.Deny
+func (c *cachesImpl) CalculateMemberPermissions(member discord.Member, channel discord.GuildChannel) discord.PermissionResult {
+	input := discord.PermissionInput{
+		GuildID: member.GuildID,
+		Roles:   c.MemberRoles(member),
+		Member:  member,
+		Channel: channel,
 	}
-
-	for _, roleID := range member.RoleIDs {
-		if roleID == channel.GuildID() {
-			continue
-		}
-
-		if overwrite, ok := channel.PermissionOverwrites().Role(roleID); ok {
-			allow |= overwrite.Allow
-			deny |= overwrite.Deny
-		}
+	if guild, ok := c.Guild(member.GuildID); ok {
+		input.OwnerID = guild.OwnerID
 	}
-
-	if overwrite, ok := channel.PermissionOverwrites().Member(member.User.ID); ok {
-		allow |= overwrite.Allow
-		deny |= overwrite.Deny
+	if publicRole, ok := c.Role(member.GuildID, member.GuildID); ok {
+		input.Roles = append(input.Roles, publicRole)
 	}
-
-	permissions &= ^deny
-	permissions |= allow
-
-	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
-		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
+	if thread, ok := channel.(discord.GuildThread); ok {
+		if parent, ok := c.Channel(*thread.ParentID()); ok {
+			input.Parent = parent
+		}
+		_, input.ThreadMember = c.ThreadMember(thread.ID(), member.User.ID)
 	}
-
-	return permissions
+	return discord.CalculatePermissions(input)
 }
 
 func (c *cachesImpl) MemberRoles(member discord.Member) []discord.Role {
@@ -874,6 +1359,18 @@ func (c *cachesImpl) MemberRoles(member discord.Member) []discord.Role {
 	return roles
 }
 
+func (c *cachesImpl) RoleHierarchy(guildID snowflake.ID) (discord.RoleHierarchy, bool) {
+	guild, ok := c.Guild(guildID)
+	if !ok {
+		return discord.RoleHierarchy{}, false
+	}
+	var roles []discord.Role
+	c.RolesForEach(guildID, func(role discord.Role) {
+		roles = append(roles, role)
+	})
+	return discord.NewRoleHierarchy(guildID, guild.OwnerID, roles), true
+}
+
 func (c *cachesImpl) AudioChannelMembers(channel discord.GuildAudioChannel) []discord.Member {
 	var members []discord.Member
 	c.VoiceStatesForEach(channel.GuildID(), func(state discord.VoiceState) {
@@ -908,6 +1405,9 @@ func (c *cachesImpl) MessageChannel(channelID snowflake.ID) (discord.MessageChan
 			return cCh, true
 		}
 	}
+	if ch, ok := c.PrivateChannel(channelID); ok {
+		return ch, true
+	}
 	return nil, false
 }
 
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func testWebhook(t *testing.T, data string) discord.Webhook {
+	t.Helper()
+	var webhook discord.UnmarshalWebhook
+	require.NoError(t, json.Unmarshal([]byte(data), &webhook))
+	return webhook.Webhook
+}
+
+func TestModerationCaches(t *testing.T) {
+	caches := New(WithCaches(FlagsAll))
+
+	caches.AddAutoModerationRule(discord.AutoModerationRule{ID: 10, GuildID: 1, Name: "rule"})
+	caches.AddBan(1, discord.Ban{User: discord.User{ID: 20}})
+	caches.AddBan(2, discord.Ban{User: discord.User{ID: 21}})
+	caches.AddInvite(1, discord.ExtendedInvite{Invite: discord.Invite{Code: "abc"}, Uses: 3})
+	caches.AddIntegration(1, discord.BotIntegration{IntegrationID: 30, Name: "bot"})
+	caches.AddWebhook(1, testWebhook(t, `{"id":"40","type":1,"channel_id":"100","guild_id":"1"}`))
+	caches.AddWebhook(1, testWebhook(t, `{"id":"41","type":1,"channel_id":"101","guild_id":"1"}`))
+
+	rule, ok := caches.AutoModerationRule(1, 10)
+	require.True(t, ok)
+	assert.Equal(t, "rule", rule.Name)
+	_, ok = caches.Ban(1, 20)
+	assert.True(t, ok)
+	assert.Equal(t, 2, caches.BansAllLen())
+	invite, ok := caches.Invite(1, "abc")
+	require.True(t, ok)
+	assert.Equal(t, 3, invite.Uses)
+	_, ok = caches.Integration(1, 30)
+	assert.True(t, ok)
+	assert.Equal(t, 2, caches.WebhooksLen(1))
+
+	caches.RemoveWebhooksByChannelID(1, 100)
+	_, ok = caches.Webhook(1, 40)
+	assert.False(t, ok)
+	_, ok = caches.Webhook(1, 41)
+	assert.True(t, ok)
+
+	_, ok = caches.RemoveInvite(1, "abc")
+	assert.True(t, ok)
+	assert.Zero(t, caches.InvitesAllLen())
+
+	caches.RemoveAutoModerationRulesByGuildID(1)
+	caches.RemoveBansByGuildID(1)
+	caches.RemoveIntegrationsByGuildID(1)
+	caches.RemoveWebhooksByGuildID(1)
+	assert.Zero(t, caches.AutoModerationRulesLen(1))
+	assert.Zero(t, caches.BansLen(1))
+	assert.Equal(t, 1, caches.BansLen(2), "other guilds should be kept")
+	assert.Zero(t, caches.IntegrationsLen(1))
+	assert.Zero(t, caches.WebhooksLen(1))
+}
+
+func TestModerationCachesFlags(t *testing.T) {
+	caches := New(WithCaches(FlagBans), WithInviteCachePolicy(func(invite discord.ExtendedInvite) bool {
+		return invite.MaxUses > 0
+	}))
+
+	caches.AddBan(1, discord.Ban{User: discord.User{ID: 20}})
+	caches.AddAutoModerationRule(discord.AutoModerationRule{ID: 10, GuildID: 1})
+	caches.AddInvite(1, discord.ExtendedInvite{Invite: discord.Invite{Code: "abc"}, MaxUses: 1})
+
+	assert.Equal(t, 1, caches.BansLen(1))
+	assert.Zero(t, caches.AutoModerationRulesAllLen(), "disabled caches should not store anything")
+	assert.Zero(t, caches.InvitesAllLen(), "disabled caches should not store anything")
+
+	caches = New(WithCaches(FlagInvites), WithInviteCachePolicy(func(invite discord.ExtendedInvite) bool {
+		return invite.MaxUses > 0
+	}))
+	caches.AddInvite(1, discord.ExtendedInvite{Invite: discord.Invite{Code: "abc"}, MaxUses: 1})
+	caches.AddInvite(1, discord.ExtendedInvite{Invite: discord.Invite{Code: "def"}})
+	_, ok := caches.Invite(1, "abc")
+	assert.True(t, ok)
+	_, ok = caches.Invite(1, "def")
+	assert.False(t, ok, "the policy should be applied")
+}
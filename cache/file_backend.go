@@ -0,0 +1,326 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+var _ Backend = (*FileBackend)(nil)
+
+// ErrBackendClosed is returned when a closed Backend is used.
+var ErrBackendClosed = errors.New("backend closed")
+
+const (
+	fileBackendOpPut    byte = 1
+	fileBackendOpDelete byte = 2
+
+	// fileBackendCompactMin is the minimum number of stale records before the log is compacted.
+	fileBackendCompactMin = 1024
+)
+
+// OpenFileBackend opens or creates the key value file at the given path.
+//
+// The FileBackend is a reference Backend implementation. It keeps all values in memory & appends every change to a log file,
+// which is replayed on open & compacted once it contains more stale than live records.
+// The file must only be opened by a single process at a time. Share state between processes with a networked Backend instead.
+func OpenFileBackend(path string) (*FileBackend, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	b := &FileBackend{
+		path:   path,
+		file:   file,
+		values: map[string][]byte{},
+	}
+	if err = b.load(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// FileBackend is a Backend storing its values in an append only log file.
+type FileBackend struct {
+	path   string
+	mu     sync.RWMutex
+	file   *os.File
+	values map[string][]byte
+	// stale is the number of records in the log which were overwritten or deleted
+	stale int
+}
+
+// record layout: crc32 (4 bytes) | op (1 byte) | key length (uvarint) | key | value length (uvarint) | value
+// the checksum covers everything after itself.
+func appendFileBackendRecord(buf []byte, op byte, key string, value []byte) []byte {
+	start := len(buf)
+	buf = append(buf, 0, 0, 0, 0, op)
+	buf = binary.AppendUvarint(buf, uint64(len(key)))
+	buf = append(buf, key...)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	buf = append(buf, value...)
+	binary.BigEndian.PutUint32(buf[start:], crc32.ChecksumIEEE(buf[start+4:]))
+	return buf
+}
+
+// load replays the log. A torn record at the end of the log, for example after a crash, is truncated.
+func (b *FileBackend) load() error {
+	r := bufio.NewReader(b.file)
+	var offset int64
+	for {
+		n, op, key, value, err := readFileBackendRecord(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, ErrInvalidEncoding) {
+				if err = b.file.Truncate(offset); err != nil {
+					return err
+				}
+				_, err = b.file.Seek(offset, io.SeekStart)
+			}
+			return err
+		}
+		offset += n
+
+		if _, ok := b.values[key]; ok {
+			b.stale++
+		}
+		switch op {
+		case fileBackendOpPut:
+			b.values[key] = value
+		case fileBackendOpDelete:
+			delete(b.values, key)
+			b.stale++
+		}
+	}
+}
+
+func readFileBackendRecord(r *bufio.Reader) (int64, byte, string, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, 0, "", nil, io.EOF
+		}
+		return 0, 0, "", nil, io.ErrUnexpectedEOF
+	}
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write(header[4:])
+	tr := io.TeeReader(r, crc)
+
+	key, keyN, err := readFileBackendBytes(tr)
+	if err != nil {
+		return 0, 0, "", nil, err
+	}
+	value, valueN, err := readFileBackendBytes(tr)
+	if err != nil {
+		return 0, 0, "", nil, err
+	}
+	if crc.Sum32() != binary.BigEndian.Uint32(header[:4]) {
+		return 0, 0, "", nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidEncoding)
+	}
+	return int64(len(header) + keyN + valueN), header[4], string(key), value, nil
+}
+
+func readFileBackendBytes(r io.Reader) ([]byte, int, error) {
+	length, err := binary.ReadUvarint(byteReader{r})
+	if err != nil {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	data := make([]byte, length)
+	if _, err = io.ReadFull(r, data); err != nil {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	return data, len(binary.AppendUvarint(nil, length)) + int(length), nil
+}
+
+type byteReader struct {
+	io.Reader
+}
+
+func (r byteReader) ReadByte() (byte, error) {
+	var b [1]byte
+	_, err := io.ReadFull(r.Reader, b[:])
+	return b[0], err
+}
+
+func (b *FileBackend) Get(_ context.Context, keys ...string) (map[string][]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.file == nil {
+		return nil, ErrBackendClosed
+	}
+
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if value, ok := b.values[key]; ok {
+			values[key] = bytes.Clone(value)
+		}
+	}
+	return values, nil
+}
+
+func (b *FileBackend) Put(_ context.Context, entries map[string][]byte) error {
+	var buf []byte
+	for key, value := range entries {
+		buf = appendFileBackendRecord(buf, fileBackendOpPut, key, value)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.file == nil {
+		return ErrBackendClosed
+	}
+	if _, err := b.file.Write(buf); err != nil {
+		return err
+	}
+	for key, value := range entries {
+		if _, ok := b.values[key]; ok {
+			b.stale++
+		}
+		b.values[key] = bytes.Clone(value)
+	}
+	return b.maybeCompact()
+}
+
+func (b *FileBackend) Delete(_ context.Context, keys ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.file == nil {
+		return ErrBackendClosed
+	}
+
+	var buf []byte
+	for _, key := range keys {
+		if _, ok := b.values[key]; ok {
+			buf = appendFileBackendRecord(buf, fileBackendOpDelete, key, nil)
+		}
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	if _, err := b.file.Write(buf); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, ok := b.values[key]; ok {
+			delete(b.values, key)
+			// the put & the delete record are stale now
+			b.stale += 2
+		}
+	}
+	return b.maybeCompact()
+}
+
+func (b *FileBackend) Scan(ctx context.Context, prefix string, fn func(key string, value []byte) bool) error {
+	// collect the matching entries first, so fn can use the FileBackend
+	b.mu.RLock()
+	if b.file == nil {
+		b.mu.RUnlock()
+		return ErrBackendClosed
+	}
+	var keys []string
+	var values [][]byte
+	for key, value := range b.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+			values = append(values, bytes.Clone(value))
+		}
+	}
+	b.mu.RUnlock()
+
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !fn(key, values[i]) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (b *FileBackend) maybeCompact() error {
+	if b.stale < fileBackendCompactMin || b.stale < len(b.values) {
+		return nil
+	}
+	return b.compact()
+}
+
+// Compact rewrites the log file with only the live values.
+func (b *FileBackend) Compact() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.file == nil {
+		return ErrBackendClosed
+	}
+	return b.compact()
+}
+
+func (b *FileBackend) compact() error {
+	tmpPath := b.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(tmp)
+	var buf []byte
+	for key, value := range b.values {
+		buf = appendFileBackendRecord(buf[:0], fileBackendOpPut, key, value)
+		if _, err = w.Write(buf); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, b.path)
+	}
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to compact file backend: %w", err)
+	}
+
+	_ = b.file.Close()
+	b.file = tmp
+	b.stale = 0
+	_, err = b.file.Seek(0, io.SeekEnd)
+	return err
+}
+
+// Sync commits the log file to stable storage.
+func (b *FileBackend) Sync() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.file == nil {
+		return ErrBackendClosed
+	}
+	return b.file.Sync()
+}
+
+// Close syncs & closes the log file.
+func (b *FileBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.file == nil {
+		return nil
+	}
+	err := errors.Join(b.file.Sync(), b.file.Close())
+	b.file = nil
+	return err
+}
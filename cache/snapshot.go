@@ -0,0 +1,221 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// SnapshotVersion is the version of the Snapshot format written by Caches.ExportSnapshot.
+const SnapshotVersion = 1
+
+// ErrUnsupportedSnapshotVersion is returned when a Snapshot with an unknown version is imported.
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+// Snapshot holds the content of all caches of a Caches instance. See Caches.ExportSnapshot & Caches.ImportSnapshot.
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	SelfUser             *discord.OAuth2User           `json:"self_user,omitempty"`
+	Guilds               []discord.Guild               `json:"guilds,omitempty"`
+	UnavailableGuildIDs  []snowflake.ID                `json:"unavailable_guild_ids,omitempty"`
+	Channels             []discord.GuildChannel        `json:"channels,omitempty"`
+	PrivateChannels      []discord.PrivateChannel      `json:"private_channels,omitempty"`
+	StageInstances       []discord.StageInstance       `json:"stage_instances,omitempty"`
+	GuildScheduledEvents []discord.GuildScheduledEvent `json:"guild_scheduled_events,omitempty"`
+	Roles                []discord.Role                `json:"roles,omitempty"`
+	Members              []discord.Member              `json:"members,omitempty"`
+	ThreadMembers        []discord.ThreadMember        `json:"thread_members,omitempty"`
+	Presences            []discord.Presence            `json:"presences,omitempty"`
+	VoiceStates          []discord.VoiceState          `json:"voice_states,omitempty"`
+	Messages             []discord.Message             `json:"messages,omitempty"`
+	Emojis               []discord.Emoji               `json:"emojis,omitempty"`
+	Stickers             []discord.Sticker             `json:"stickers,omitempty"`
+}
+
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	type snapshot Snapshot
+	var v struct {
+		Channels        []discord.UnmarshalChannel `json:"channels,omitempty"`
+		PrivateChannels []discord.UnmarshalChannel `json:"private_channels,omitempty"`
+		snapshot
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*s = Snapshot(v.snapshot)
+	s.Channels = make([]discord.GuildChannel, 0, len(v.Channels))
+	for _, channel := range v.Channels {
+		if guildChannel, ok := channel.Channel.(discord.GuildChannel); ok {
+			s.Channels = append(s.Channels, guildChannel)
+		}
+	}
+	s.PrivateChannels = make([]discord.PrivateChannel, 0, len(v.PrivateChannels))
+	for _, channel := range v.PrivateChannels {
+		if privateChannel, ok := channel.Channel.(discord.PrivateChannel); ok {
+			s.PrivateChannels = append(s.PrivateChannels, privateChannel)
+		}
+	}
+	return nil
+}
+
+// TakeSnapshot returns a Snapshot of all caches of the given Caches.
+// The caches are read one after another, to get a consistent Snapshot no events should be processed meanwhile,
+// for example by taking it after the gateway was closed.
+func TakeSnapshot(caches Caches) Snapshot {
+	snapshot := Snapshot{
+		Version:             SnapshotVersion,
+		CreatedAt:           time.Now().UTC(),
+		UnavailableGuildIDs: caches.UnavailableGuildIDs(),
+	}
+	if selfUser, ok := caches.SelfUser(); ok {
+		snapshot.SelfUser = &selfUser
+	}
+
+	caches.GuildsForEach(func(guild discord.Guild) {
+		snapshot.Guilds = append(snapshot.Guilds, guild)
+
+		caches.StageInstanceForEach(guild.ID, func(stageInstance discord.StageInstance) {
+			snapshot.StageInstances = append(snapshot.StageInstances, stageInstance)
+		})
+		caches.GuildScheduledEventsForEach(guild.ID, func(guildScheduledEvent discord.GuildScheduledEvent) {
+			snapshot.GuildScheduledEvents = append(snapshot.GuildScheduledEvents, guildScheduledEvent)
+		})
+		caches.RolesForEach(guild.ID, func(role discord.Role) {
+			snapshot.Roles = append(snapshot.Roles, role)
+		})
+		caches.MembersForEach(guild.ID, func(member discord.Member) {
+			snapshot.Members = append(snapshot.Members, member)
+		})
+		caches.PresenceForEach(guild.ID, func(presence discord.Presence) {
+			snapshot.Presences = append(snapshot.Presences, presence)
+		})
+		caches.VoiceStatesForEach(guild.ID, func(voiceState discord.VoiceState) {
+			snapshot.VoiceStates = append(snapshot.VoiceStates, voiceState)
+		})
+		caches.EmojisForEach(guild.ID, func(emoji discord.Emoji) {
+			snapshot.Emojis = append(snapshot.Emojis, emoji)
+		})
+		caches.StickersForEach(guild.ID, func(sticker discord.Sticker) {
+			snapshot.Stickers = append(snapshot.Stickers, sticker)
+		})
+	})
+
+	caches.ChannelsForEach(func(channel discord.GuildChannel) {
+		snapshot.Channels = append(snapshot.Channels, channel)
+
+		if _, ok := channel.(discord.GuildThread); ok {
+			caches.ThreadMemberForEach(channel.ID(), func(threadMember discord.ThreadMember) {
+				snapshot.ThreadMembers = append(snapshot.ThreadMembers, threadMember)
+			})
+		}
+		caches.MessagesForEach(channel.ID(), func(message discord.Message) {
+			snapshot.Messages = append(snapshot.Messages, message)
+		})
+	})
+
+	caches.PrivateChannelsForEach(func(channel discord.PrivateChannel) {
+		snapshot.PrivateChannels = append(snapshot.PrivateChannels, channel)
+
+		caches.MessagesForEach(channel.ID(), func(message discord.Message) {
+			snapshot.Messages = append(snapshot.Messages, message)
+		})
+	})
+	return snapshot
+}
+
+// RestoreSnapshot adds all entities of the Snapshot to the given Caches and marks all restored guilds as stale.
+// The Flags & Policy(s) of the caches are applied as usual.
+func RestoreSnapshot(caches Caches, snapshot Snapshot) error {
+	if snapshot.Version != SnapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snapshot.Version)
+	}
+
+	if snapshot.SelfUser != nil {
+		caches.SetSelfUser(*snapshot.SelfUser)
+	}
+	for _, guild := range snapshot.Guilds {
+		caches.AddGuild(guild)
+		caches.SetGuildStale(guild.ID, true)
+	}
+	for _, guildID := range snapshot.UnavailableGuildIDs {
+		caches.SetGuildUnavailable(guildID, true)
+	}
+	for _, channel := range snapshot.Channels {
+		caches.AddChannel(channel)
+	}
+	for _, channel := range snapshot.PrivateChannels {
+		caches.AddPrivateChannel(channel)
+	}
+	for _, stageInstance := range snapshot.StageInstances {
+		caches.AddStageInstance(stageInstance)
+	}
+	for _, guildScheduledEvent := range snapshot.GuildScheduledEvents {
+		caches.AddGuildScheduledEvent(guildScheduledEvent)
+	}
+	for _, role := range snapshot.Roles {
+		caches.AddRole(role)
+	}
+	for _, member := range snapshot.Members {
+		caches.AddMember(member)
+	}
+	for _, threadMember := range snapshot.ThreadMembers {
+		caches.AddThreadMember(threadMember)
+	}
+	for _, presence := range snapshot.Presences {
+		caches.AddPresence(presence)
+	}
+	for _, voiceState := range snapshot.VoiceStates {
+		caches.AddVoiceState(voiceState)
+	}
+	for _, message := range snapshot.Messages {
+		caches.AddMessage(message)
+	}
+	for _, emoji := range snapshot.Emojis {
+		caches.AddEmoji(emoji)
+	}
+	for _, sticker := range snapshot.Stickers {
+		caches.AddSticker(sticker)
+	}
+	return nil
+}
+
+func (c *cachesImpl) ExportSnapshot(w io.Writer) error {
+	return json.NewEncoder(w).Encode(TakeSnapshot(c))
+}
+
+func (c *cachesImpl) ImportSnapshot(r io.Reader) error {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return RestoreSnapshot(c, snapshot)
+}
+
+func (c *cachesImpl) IsGuildStale(guildID snowflake.ID) bool {
+	return c.staleGuilds.Has(guildID)
+}
+
+func (c *cachesImpl) SetGuildStale(guildID snowflake.ID, stale bool) {
+	if stale {
+		c.staleGuilds.Add(guildID)
+	} else {
+		c.staleGuilds.Remove(guildID)
+	}
+}
+
+func (c *cachesImpl) StaleGuildIDs() []snowflake.ID {
+	var guilds []snowflake.ID
+	c.staleGuilds.ForEach(func(guildID snowflake.ID) {
+		guilds = append(guilds, guildID)
+	})
+	return guilds
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func testChannel(t *testing.T, data string) discord.Channel {
+	t.Helper()
+	var channel discord.UnmarshalChannel
+	require.NoError(t, json.Unmarshal([]byte(data), &channel))
+	return channel.Channel
+}
+
+func TestSnapshot(t *testing.T) {
+	caches := New(WithCaches(FlagsAll))
+	caches.AddGuild(discord.Guild{ID: 1, Name: "guild"})
+	caches.AddChannel(testChannel(t, `{"id":"10","guild_id":"1","type":0,"name":"general"}`).(discord.GuildChannel))
+	caches.AddPrivateChannel(testChannel(t, `{"id":"20","type":1}`).(discord.PrivateChannel))
+	caches.AddRole(discord.Role{ID: 30, GuildID: 1, Name: "role"})
+	caches.AddMessage(discord.Message{ID: 100, ChannelID: 10, GuildID: json.Ptr(snowflake.ID(1)), Content: "guild"})
+	caches.AddMessage(discord.Message{ID: 200, ChannelID: 20, Content: "dm"})
+
+	var buf bytes.Buffer
+	require.NoError(t, caches.ExportSnapshot(&buf))
+
+	restored := New(WithCaches(FlagsAll))
+	require.NoError(t, restored.ImportSnapshot(&buf))
+
+	guild, ok := restored.Guild(1)
+	require.True(t, ok)
+	assert.Equal(t, "guild", guild.Name)
+	assert.True(t, restored.IsGuildStale(1), "restored guilds should be stale until the guild create is received")
+	assert.Equal(t, []snowflake.ID{1}, restored.StaleGuildIDs())
+
+	channel, ok := restored.Channel(10)
+	require.True(t, ok)
+	assert.IsType(t, discord.GuildTextChannel{}, channel)
+	assert.Equal(t, "general", channel.Name())
+	_, ok = restored.PrivateChannel(20)
+	assert.True(t, ok)
+	_, ok = restored.Role(1, 30)
+	assert.True(t, ok)
+
+	message, ok := restored.Message(10, 100)
+	require.True(t, ok)
+	assert.Equal(t, "guild", message.Content)
+	_, ok = restored.Message(20, 200)
+	assert.True(t, ok)
+
+	restored.SetGuildStale(1, false)
+	assert.False(t, restored.IsGuildStale(1))
+	assert.Empty(t, restored.StaleGuildIDs())
+}
+
+func TestSnapshotUnsupportedVersion(t *testing.T) {
+	caches := New()
+	err := caches.ImportSnapshot(bytes.NewReader([]byte(fmt.Sprintf(`{"version":%d}`, SnapshotVersion+1))))
+	assert.ErrorIs(t, err, ErrUnsupportedSnapshotVersion)
+}
@@ -47,8 +47,40 @@ type AttachmentKeep struct {
 func (AttachmentKeep) attachmentUpdate() {}
 
 type AttachmentCreate struct {
-	ID          int    `json:"id"`
-	Description string `json:"description"`
+	ID               int    `json:"id"`
+	Description      string `json:"description"`
+	Filename         string `json:"filename,omitempty"`
+	UploadedFilename string `json:"uploaded_filename,omitempty"`
 }
 
 func (AttachmentCreate) attachmentUpdate() {}
+
+// AttachmentUploadsCreate is used to request upload urls for cloud attachments
+type AttachmentUploadsCreate struct {
+	Files []AttachmentUploadCreate `json:"files"`
+}
+
+// AttachmentUploadCreate describes a single file to request an upload url for
+type AttachmentUploadCreate struct {
+	ID       int    `json:"id"`
+	Filename string `json:"filename"`
+	FileSize int64  `json:"file_size"`
+}
+
+// AttachmentUpload is an upload url for a cloud attachment.
+// The file has to be uploaded to the UploadURL with a PUT request & can then be referenced by the UploadFilename in an AttachmentCreate
+type AttachmentUpload struct {
+	ID             int    `json:"id"`
+	UploadURL      string `json:"upload_url"`
+	UploadFilename string `json:"upload_filename"`
+}
+
+// AttachmentCreate returns an AttachmentCreate referencing the uploaded file with the given filename & description
+func (u AttachmentUpload) AttachmentCreate(filename string, description string) AttachmentCreate {
+	return AttachmentCreate{
+		ID:               u.ID,
+		Description:      description,
+		Filename:         filename,
+		UploadedFilename: u.UploadFilename,
+	}
+}
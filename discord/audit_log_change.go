@@ -0,0 +1,409 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// AuditLogChangeKey (https://discord.com/developers/docs/resources/audit-log#audit-log-change-object-audit-log-change-key) is the name of the property which changed.
+// Most keys match the json field of the changed entity.
+type AuditLogChangeKey string
+
+const (
+	AuditLogChangeKeyName                        AuditLogChangeKey = "name"
+	AuditLogChangeKeyDescription                 AuditLogChangeKey = "description"
+	AuditLogChangeKeyIconHash                    AuditLogChangeKey = "icon_hash"
+	AuditLogChangeKeySplashHash                  AuditLogChangeKey = "splash_hash"
+	AuditLogChangeKeyDiscoverySplashHash         AuditLogChangeKey = "discovery_splash_hash"
+	AuditLogChangeKeyBannerHash                  AuditLogChangeKey = "banner_hash"
+	AuditLogChangeKeyOwnerID                     AuditLogChangeKey = "owner_id"
+	AuditLogChangeKeyRegion                      AuditLogChangeKey = "region"
+	AuditLogChangeKeyPreferredLocale             AuditLogChangeKey = "preferred_locale"
+	AuditLogChangeKeyAFKChannelID                AuditLogChangeKey = "afk_channel_id"
+	AuditLogChangeKeyAFKTimeout                  AuditLogChangeKey = "afk_timeout"
+	AuditLogChangeKeyRulesChannelID              AuditLogChangeKey = "rules_channel_id"
+	AuditLogChangeKeyPublicUpdatesChannelID      AuditLogChangeKey = "public_updates_channel_id"
+	AuditLogChangeKeyMFALevel                    AuditLogChangeKey = "mfa_level"
+	AuditLogChangeKeyVerificationLevel           AuditLogChangeKey = "verification_level"
+	AuditLogChangeKeyExplicitContentFilter       AuditLogChangeKey = "explicit_content_filter"
+	AuditLogChangeKeyDefaultMessageNotifications AuditLogChangeKey = "default_message_notifications"
+	AuditLogChangeKeyVanityURLCode               AuditLogChangeKey = "vanity_url_code"
+	AuditLogChangeKeyAddRoles                    AuditLogChangeKey = "$add"
+	AuditLogChangeKeyRemoveRoles                 AuditLogChangeKey = "$remove"
+	AuditLogChangeKeyPruneDeleteDays             AuditLogChangeKey = "prune_delete_days"
+	AuditLogChangeKeyWidgetEnabled               AuditLogChangeKey = "widget_enabled"
+	AuditLogChangeKeyWidgetChannelID             AuditLogChangeKey = "widget_channel_id"
+	AuditLogChangeKeySystemChannelID             AuditLogChangeKey = "system_channel_id"
+	AuditLogChangeKeyPosition                    AuditLogChangeKey = "position"
+	AuditLogChangeKeyTopic                       AuditLogChangeKey = "topic"
+	AuditLogChangeKeyBitrate                     AuditLogChangeKey = "bitrate"
+	AuditLogChangeKeyPermissionOverwrites        AuditLogChangeKey = "permission_overwrites"
+	AuditLogChangeKeyNSFW                        AuditLogChangeKey = "nsfw"
+	AuditLogChangeKeyApplicationID               AuditLogChangeKey = "application_id"
+	AuditLogChangeKeyRateLimitPerUser            AuditLogChangeKey = "rate_limit_per_user"
+	AuditLogChangeKeyPermissions                 AuditLogChangeKey = "permissions"
+	AuditLogChangeKeyColor                       AuditLogChangeKey = "color"
+	AuditLogChangeKeyHoist                       AuditLogChangeKey = "hoist"
+	AuditLogChangeKeyMentionable                 AuditLogChangeKey = "mentionable"
+	AuditLogChangeKeyAllow                       AuditLogChangeKey = "allow"
+	AuditLogChangeKeyDeny                        AuditLogChangeKey = "deny"
+	AuditLogChangeKeyCode                        AuditLogChangeKey = "code"
+	AuditLogChangeKeyChannelID                   AuditLogChangeKey = "channel_id"
+	AuditLogChangeKeyInviterID                   AuditLogChangeKey = "inviter_id"
+	AuditLogChangeKeyMaxUses                     AuditLogChangeKey = "max_uses"
+	AuditLogChangeKeyUses                        AuditLogChangeKey = "uses"
+	AuditLogChangeKeyMaxAge                      AuditLogChangeKey = "max_age"
+	AuditLogChangeKeyTemporary                   AuditLogChangeKey = "temporary"
+	AuditLogChangeKeyDeaf                        AuditLogChangeKey = "deaf"
+	AuditLogChangeKeyMute                        AuditLogChangeKey = "mute"
+	AuditLogChangeKeyNick                        AuditLogChangeKey = "nick"
+	AuditLogChangeKeyAvatarHash                  AuditLogChangeKey = "avatar_hash"
+	AuditLogChangeKeyID                          AuditLogChangeKey = "id"
+	AuditLogChangeKeyType                        AuditLogChangeKey = "type"
+	AuditLogChangeKeyEnableEmoticons             AuditLogChangeKey = "enable_emoticons"
+	AuditLogChangeKeyExpireBehavior              AuditLogChangeKey = "expire_behavior"
+	AuditLogChangeKeyExpireGracePeriod           AuditLogChangeKey = "expire_grace_period"
+	AuditLogChangeKeyUserLimit                   AuditLogChangeKey = "user_limit"
+	AuditLogChangeKeyPrivacyLevel                AuditLogChangeKey = "privacy_level"
+	AuditLogChangeKeyCommunicationDisabledUntil  AuditLogChangeKey = "communication_disabled_until"
+	AuditLogChangeKeyUnicodeEmoji                AuditLogChangeKey = "unicode_emoji"
+	AuditLogChangeKeyArchived                    AuditLogChangeKey = "archived"
+	AuditLogChangeKeyLocked                      AuditLogChangeKey = "locked"
+	AuditLogChangeKeyInvitable                   AuditLogChangeKey = "invitable"
+	AuditLogChangeKeyAutoArchiveDuration         AuditLogChangeKey = "auto_archive_duration"
+	AuditLogChangeKeyDefaultAutoArchiveDuration  AuditLogChangeKey = "default_auto_archive_duration"
+	AuditLogChangeKeyRTCRegion                   AuditLogChangeKey = "rtc_region"
+	AuditLogChangeKeyFlags                       AuditLogChangeKey = "flags"
+)
+
+// auditLogChangeDecoders decodes the values of the known AuditLogChangeKey(s) into their types. Values of other keys are decoded into any.
+var auditLogChangeDecoders = map[AuditLogChangeKey]func(data []byte) (any, error){
+	AuditLogChangeKeyName:                        decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyDescription:                 decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyIconHash:                    decodeAuditLogChangeValue[string],
+	AuditLogChangeKeySplashHash:                  decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyDiscoverySplashHash:         decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyBannerHash:                  decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyOwnerID:                     decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyRegion:                      decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyPreferredLocale:             decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyAFKChannelID:                decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyAFKTimeout:                  decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyRulesChannelID:              decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyPublicUpdatesChannelID:      decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyMFALevel:                    decodeAuditLogChangeValue[MFALevel],
+	AuditLogChangeKeyVerificationLevel:           decodeAuditLogChangeValue[VerificationLevel],
+	AuditLogChangeKeyExplicitContentFilter:       decodeAuditLogChangeValue[ExplicitContentFilterLevel],
+	AuditLogChangeKeyDefaultMessageNotifications: decodeAuditLogChangeValue[MessageNotificationsLevel],
+	AuditLogChangeKeyVanityURLCode:               decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyAddRoles:                    decodeAuditLogChangeValue[[]PartialRole],
+	AuditLogChangeKeyRemoveRoles:                 decodeAuditLogChangeValue[[]PartialRole],
+	AuditLogChangeKeyPruneDeleteDays:             decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyWidgetEnabled:               decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyWidgetChannelID:             decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeySystemChannelID:             decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyPosition:                    decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyTopic:                       decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyBitrate:                     decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyPermissionOverwrites:        decodeAuditLogPermissionOverwrites,
+	AuditLogChangeKeyNSFW:                        decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyApplicationID:               decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyRateLimitPerUser:            decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyPermissions:                 decodeAuditLogChangeValue[Permissions],
+	AuditLogChangeKeyColor:                       decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyHoist:                       decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyMentionable:                 decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyAllow:                       decodeAuditLogChangeValue[Permissions],
+	AuditLogChangeKeyDeny:                        decodeAuditLogChangeValue[Permissions],
+	AuditLogChangeKeyCode:                        decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyChannelID:                   decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyInviterID:                   decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyMaxUses:                     decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyUses:                        decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyMaxAge:                      decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyTemporary:                   decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyDeaf:                        decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyMute:                        decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyNick:                        decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyAvatarHash:                  decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyID:                          decodeAuditLogChangeValue[snowflake.ID],
+	AuditLogChangeKeyEnableEmoticons:             decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyExpireBehavior:              decodeAuditLogChangeValue[IntegrationExpireBehavior],
+	AuditLogChangeKeyExpireGracePeriod:           decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyUserLimit:                   decodeAuditLogChangeValue[int],
+	AuditLogChangeKeyPrivacyLevel:                decodeAuditLogChangeValue[StagePrivacyLevel],
+	AuditLogChangeKeyCommunicationDisabledUntil:  decodeAuditLogChangeValue[time.Time],
+	AuditLogChangeKeyUnicodeEmoji:                decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyArchived:                    decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyLocked:                      decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyInvitable:                   decodeAuditLogChangeValue[bool],
+	AuditLogChangeKeyAutoArchiveDuration:         decodeAuditLogChangeValue[AutoArchiveDuration],
+	AuditLogChangeKeyDefaultAutoArchiveDuration:  decodeAuditLogChangeValue[AutoArchiveDuration],
+	AuditLogChangeKeyRTCRegion:                   decodeAuditLogChangeValue[string],
+	AuditLogChangeKeyFlags:                       decodeAuditLogChangeValue[int],
+}
+
+func decodeAuditLogChangeValue[T any](data []byte) (any, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func decodeAuditLogPermissionOverwrites(data []byte) (any, error) {
+	var v []UnmarshalPermissionOverwrite
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	overwrites := make(PermissionOverwrites, len(v))
+	for i := range v {
+		overwrites[i] = v[i].PermissionOverwrite
+	}
+	return overwrites, nil
+}
+
+// AuditLogChange (https://discord.com/developers/docs/resources/audit-log#audit-log-change-object) is a single changed property of an AuditLogEntry.
+// OldValue is missing for newly set properties & NewValue for removed ones.
+type AuditLogChange struct {
+	Key      AuditLogChangeKey `json:"key"`
+	OldValue json.RawMessage   `json:"old_value,omitempty"`
+	NewValue json.RawMessage   `json:"new_value,omitempty"`
+}
+
+// Decode decodes the old & new value into the type of the AuditLogChangeKey, for example Permissions for AuditLogChangeKeyPermissions.
+// Missing values are returned as nil, values of unknown keys are decoded into any.
+func (c AuditLogChange) Decode() (oldValue any, newValue any, err error) {
+	decode, ok := auditLogChangeDecoders[c.Key]
+	if !ok {
+		decode = decodeAuditLogChangeValue[any]
+	}
+	if c.OldValue != nil {
+		if oldValue, err = decode(c.OldValue); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode old value of %s: %w", c.Key, err)
+		}
+	}
+	if c.NewValue != nil {
+		if newValue, err = decode(c.NewValue); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode new value of %s: %w", c.Key, err)
+		}
+	}
+	return oldValue, newValue, nil
+}
+
+// Permissions decodes the values of AuditLogChangeKeyPermissions, AuditLogChangeKeyAllow & AuditLogChangeKeyDeny.
+func (c AuditLogChange) Permissions() (oldValue *Permissions, newValue *Permissions, err error) {
+	return DecodeAuditLogChange[Permissions](c)
+}
+
+// Snowflake decodes the values of ID keys like AuditLogChangeKeyOwnerID or AuditLogChangeKeyChannelID.
+func (c AuditLogChange) Snowflake() (oldValue *snowflake.ID, newValue *snowflake.ID, err error) {
+	return DecodeAuditLogChange[snowflake.ID](c)
+}
+
+// Time decodes the values of timestamp keys like AuditLogChangeKeyCommunicationDisabledUntil.
+func (c AuditLogChange) Time() (oldValue *time.Time, newValue *time.Time, err error) {
+	return DecodeAuditLogChange[time.Time](c)
+}
+
+// PermissionOverwrites decodes the values of AuditLogChangeKeyPermissionOverwrites.
+func (c AuditLogChange) PermissionOverwrites() (oldValue PermissionOverwrites, newValue PermissionOverwrites, err error) {
+	if c.OldValue != nil {
+		v, err := decodeAuditLogPermissionOverwrites(c.OldValue)
+		if err != nil {
+			return nil, nil, err
+		}
+		oldValue = v.(PermissionOverwrites)
+	}
+	if c.NewValue != nil {
+		v, err := decodeAuditLogPermissionOverwrites(c.NewValue)
+		if err != nil {
+			return nil, nil, err
+		}
+		newValue = v.(PermissionOverwrites)
+	}
+	return oldValue, newValue, nil
+}
+
+// Roles decodes the added or removed roles of AuditLogChangeKeyAddRoles & AuditLogChangeKeyRemoveRoles.
+func (c AuditLogChange) Roles() ([]PartialRole, error) {
+	var roles []PartialRole
+	if c.NewValue == nil {
+		return nil, nil
+	}
+	if err := json.Unmarshal(c.NewValue, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// DecodeAuditLogChange decodes the old & new value of the AuditLogChange into T. Missing values are returned as nil.
+func DecodeAuditLogChange[T any](change AuditLogChange) (oldValue *T, newValue *T, err error) {
+	if change.OldValue != nil {
+		oldValue = new(T)
+		if err = json.Unmarshal(change.OldValue, oldValue); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode old value of %s: %w", change.Key, err)
+		}
+	}
+	if change.NewValue != nil {
+		newValue = new(T)
+		if err = json.Unmarshal(change.NewValue, newValue); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode new value of %s: %w", change.Key, err)
+		}
+	}
+	return oldValue, newValue, nil
+}
+
+// Change returns the AuditLogChange with the given AuditLogChangeKey and a bool indicating if it exists.
+func (e AuditLogEntry) Change(key AuditLogChangeKey) (AuditLogChange, bool) {
+	for _, change := range e.Changes {
+		if change.Key == key {
+			return change, true
+		}
+	}
+	return AuditLogChange{}, false
+}
+
+// RebuildRole applies the changes of the AuditLogEntry to the given Role and returns the Role before & after the entry.
+// Pass the cached Role or an empty one for AuditLogEventRoleCreate. Properties which did not change are taken from the given Role.
+func (e AuditLogEntry) RebuildRole(role Role) (before Role, after Role, err error) {
+	if role.ID == 0 && e.TargetID != nil {
+		role.ID = *e.TargetID
+	}
+	err = e.rebuild(role, &before, &after)
+	return
+}
+
+// RebuildMember applies the changes of the AuditLogEntry to the given Member and returns the Member before & after the entry.
+// Properties which did not change are taken from the given Member. Role changes are applied to Member.RoleIDs.
+func (e AuditLogEntry) RebuildMember(member Member) (before Member, after Member, err error) {
+	if member.User.ID == 0 && e.TargetID != nil {
+		member.User.ID = *e.TargetID
+	}
+	err = e.rebuild(member, &before, &after)
+	return
+}
+
+// RebuildChannel applies the changes of the AuditLogEntry to the given GuildChannel and returns the GuildChannel before & after the entry.
+// The GuildChannel can be nil for AuditLogEventChannelCreate & AuditLogEventChannelDelete, in which case it is built from the changes only.
+// Properties which did not change are taken from the given GuildChannel.
+func (e AuditLogEntry) RebuildChannel(channel GuildChannel) (before GuildChannel, after GuildChannel, err error) {
+	var base any = channel
+	if channel == nil {
+		fields := map[string]any{}
+		if e.TargetID != nil {
+			fields["id"] = *e.TargetID
+		}
+		// the type of created or deleted channels is only known from the changes
+		if change, ok := e.Change(AuditLogChangeKeyType); ok {
+			if change.NewValue != nil {
+				fields["type"] = change.NewValue
+			} else {
+				fields["type"] = change.OldValue
+			}
+		}
+		base = fields
+	}
+
+	var beforeChannel, afterChannel UnmarshalChannel
+	if err = e.rebuild(base, &beforeChannel, &afterChannel); err != nil {
+		return nil, nil, err
+	}
+	var ok bool
+	if before, ok = beforeChannel.Channel.(GuildChannel); !ok {
+		return nil, nil, errors.New("rebuilt channel is not a guild channel")
+	}
+	if after, ok = afterChannel.Channel.(GuildChannel); !ok {
+		return nil, nil, errors.New("rebuilt channel is not a guild channel")
+	}
+	return before, after, nil
+}
+
+// auditLogChangeFields maps the AuditLogChangeKey(s) which differ from the json field of the changed entity.
+var auditLogChangeFields = map[AuditLogChangeKey]string{
+	AuditLogChangeKeyIconHash:            "icon",
+	AuditLogChangeKeySplashHash:          "splash",
+	AuditLogChangeKeyDiscoverySplashHash: "discovery_splash",
+	AuditLogChangeKeyBannerHash:          "banner",
+	AuditLogChangeKeyAvatarHash:          "avatar",
+}
+
+// rebuild marshals base & overwrites its fields with the old or new values of the changes before unmarshalling it into before & after.
+func (e AuditLogEntry) rebuild(base any, before any, after any) error {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return err
+	}
+	for i, v := range []any{before, after} {
+		fields := map[string]json.RawMessage{}
+		if err = json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		if err = e.applyChanges(fields, i == 1); err != nil {
+			return err
+		}
+		rebuilt, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(rebuilt, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e AuditLogEntry) applyChanges(fields map[string]json.RawMessage, after bool) error {
+	for _, change := range e.Changes {
+		if change.Key == AuditLogChangeKeyAddRoles || change.Key == AuditLogChangeKeyRemoveRoles {
+			if err := applyAuditLogRoleChange(fields, change, (change.Key == AuditLogChangeKeyAddRoles) == after); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value := change.OldValue
+		if after {
+			value = change.NewValue
+		}
+		if value == nil {
+			value = json.NullBytes
+		}
+		field, ok := auditLogChangeFields[change.Key]
+		if !ok {
+			field = string(change.Key)
+		}
+		fields[field] = value
+	}
+	return nil
+}
+
+func applyAuditLogRoleChange(fields map[string]json.RawMessage, change AuditLogChange, add bool) error {
+	roles, err := change.Roles()
+	if err != nil {
+		return err
+	}
+	var roleIDs []snowflake.ID
+	if data, ok := fields["roles"]; ok {
+		if err = json.Unmarshal(data, &roleIDs); err != nil {
+			return err
+		}
+	}
+	for _, role := range roles {
+		i := slices.Index(roleIDs, role.ID)
+		if add && i == -1 {
+			roleIDs = append(roleIDs, role.ID)
+		} else if !add && i != -1 {
+			roleIDs = slices.Delete(roleIDs, i, i+1)
+		}
+	}
+	data, err := json.Marshal(roleIDs)
+	if err != nil {
+		return err
+	}
+	fields["roles"] = data
+	return nil
+}
@@ -0,0 +1,70 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogEntryRebuildRole(t *testing.T) {
+	var entry AuditLogEntry
+	err := json.Unmarshal([]byte(`{
+		"id": "1",
+		"target_id": "2",
+		"user_id": "3",
+		"action_type": 31,
+		"changes": [
+			{"key": "name", "old_value": "old", "new_value": "new"},
+			{"key": "permissions", "old_value": "8", "new_value": "2048"},
+			{"key": "icon_hash", "new_value": "abc"}
+		]
+	}`), &entry)
+	require.NoError(t, err)
+
+	change, ok := entry.Change(AuditLogChangeKeyPermissions)
+	require.True(t, ok)
+	oldPermissions, newPermissions, err := change.Permissions()
+	require.NoError(t, err)
+	assert.Equal(t, PermissionAdministrator, *oldPermissions)
+	assert.Equal(t, PermissionSendMessages, *newPermissions)
+
+	before, after, err := entry.RebuildRole(Role{ID: 2, Color: 5, Name: "new"})
+	require.NoError(t, err)
+	assert.Equal(t, Role{ID: 2, Color: 5, Name: "old", Permissions: PermissionAdministrator}, before)
+	assert.Equal(t, "abc", *after.Icon)
+	assert.Equal(t, PermissionSendMessages, after.Permissions)
+	assert.Equal(t, 5, after.Color)
+}
+
+func TestAuditLogEntryRebuildMember(t *testing.T) {
+	var entry AuditLogEntry
+	err := json.Unmarshal([]byte(`{
+		"id": "1",
+		"target_id": "2",
+		"user_id": "3",
+		"action_type": 25,
+		"changes": [
+			{"key": "$add", "new_value": [{"id": "10", "name": "added"}]},
+			{"key": "$remove", "new_value": [{"id": "11", "name": "removed"}]},
+			{"key": "communication_disabled_until", "new_value": "2024-01-01T00:00:00+00:00"}
+		]
+	}`), &entry)
+	require.NoError(t, err)
+
+	oldValue, newValue, err := entry.Changes[2].Decode()
+	require.NoError(t, err)
+	assert.Nil(t, oldValue)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), newValue.(time.Time).UTC())
+
+	before, after, err := entry.RebuildMember(Member{RoleIDs: []snowflake.ID{10, 12}})
+	require.NoError(t, err)
+	assert.Equal(t, snowflake.ID(2), after.User.ID)
+	assert.ElementsMatch(t, []snowflake.ID{12, 11}, before.RoleIDs)
+	assert.ElementsMatch(t, []snowflake.ID{10, 12}, after.RoleIDs)
+	assert.Nil(t, before.CommunicationDisabledUntil)
+	assert.NotNil(t, after.CommunicationDisabledUntil)
+}
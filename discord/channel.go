@@ -98,6 +98,16 @@ type MessageChannel interface {
 	messageChannel()
 }
 
+// PrivateChannel is a MessageChannel outside of a Guild. It is either a DMChannel or a GroupDMChannel.
+type PrivateChannel interface {
+	MessageChannel
+
+	// Recipients returns the User(s) the PrivateChannel is shared with, excluding the current User.
+	Recipients() []User
+
+	privateChannel()
+}
+
 type GuildChannel interface {
 	Channel
 	Mentionable
@@ -185,6 +195,11 @@ func (u *UnmarshalChannel) UnmarshalJSON(data []byte) error {
 		err = json.Unmarshal(data, &v)
 		channel = v
 
+	case ChannelTypeGroupDM:
+		var v GroupDMChannel
+		err = json.Unmarshal(data, &v)
+		channel = v
+
 	case ChannelTypeGuildCategory:
 		var v GuildCategoryChannel
 		err = json.Unmarshal(data, &v)
@@ -360,8 +375,18 @@ func (GuildTextChannel) guildMessageChannel() {}
 var (
 	_ Channel        = (*DMChannel)(nil)
 	_ MessageChannel = (*DMChannel)(nil)
+	_ PrivateChannel = (*DMChannel)(nil)
 )
 
+// NewDMChannel returns a DMChannel with the given ID & recipient.
+// This is useful to build a DMChannel from events which only contain the channel ID, for example a MESSAGE_CREATE.
+func NewDMChannel(id snowflake.ID, recipient User) DMChannel {
+	return DMChannel{
+		id:         id,
+		recipients: []User{recipient},
+	}
+}
+
 type DMChannel struct {
 	id               snowflake.ID
 	lastMessageID    *snowflake.ID
@@ -405,9 +430,24 @@ func (DMChannel) Type() ChannelType {
 }
 
 func (c DMChannel) Name() string {
+	if len(c.recipients) == 0 {
+		return ""
+	}
 	return c.recipients[0].Username
 }
 
+// Recipient returns the User the DMChannel is shared with and a bool indicating if it is known.
+func (c DMChannel) Recipient() (User, bool) {
+	if len(c.recipients) == 0 {
+		return User{}, false
+	}
+	return c.recipients[0], true
+}
+
+func (c DMChannel) Recipients() []User {
+	return c.recipients
+}
+
 func (c DMChannel) LastMessageID() *snowflake.ID {
 	return c.lastMessageID
 }
@@ -422,6 +462,115 @@ func (c DMChannel) CreatedAt() time.Time {
 
 func (DMChannel) channel()        {}
 func (DMChannel) messageChannel() {}
+func (DMChannel) privateChannel() {}
+
+var (
+	_ Channel        = (*GroupDMChannel)(nil)
+	_ MessageChannel = (*GroupDMChannel)(nil)
+	_ PrivateChannel = (*GroupDMChannel)(nil)
+)
+
+type GroupDMChannel struct {
+	id               snowflake.ID
+	name             string
+	icon             *string
+	ownerID          snowflake.ID
+	applicationID    *snowflake.ID
+	lastMessageID    *snowflake.ID
+	recipients       []User
+	lastPinTimestamp *time.Time
+}
+
+func (c *GroupDMChannel) UnmarshalJSON(data []byte) error {
+	var v groupDMChannel
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	c.id = v.ID
+	c.name = v.Name
+	c.icon = v.Icon
+	c.ownerID = v.OwnerID
+	c.applicationID = v.ApplicationID
+	c.lastMessageID = v.LastMessageID
+	c.recipients = v.Recipients
+	c.lastPinTimestamp = v.LastPinTimestamp
+	return nil
+}
+
+func (c GroupDMChannel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(groupDMChannel{
+		ID:               c.id,
+		Type:             c.Type(),
+		Name:             c.name,
+		Icon:             c.icon,
+		OwnerID:          c.ownerID,
+		ApplicationID:    c.applicationID,
+		LastMessageID:    c.lastMessageID,
+		Recipients:       c.recipients,
+		LastPinTimestamp: c.lastPinTimestamp,
+	})
+}
+
+func (c GroupDMChannel) String() string {
+	return channelString(c)
+}
+
+func (c GroupDMChannel) ID() snowflake.ID {
+	return c.id
+}
+
+func (GroupDMChannel) Type() ChannelType {
+	return ChannelTypeGroupDM
+}
+
+func (c GroupDMChannel) Name() string {
+	return c.name
+}
+
+// Icon returns the icon hash of the GroupDMChannel.
+func (c GroupDMChannel) Icon() *string {
+	return c.icon
+}
+
+// IconURL returns the icon URL of the GroupDMChannel or nil if it has no icon.
+func (c GroupDMChannel) IconURL(opts ...CDNOpt) *string {
+	if c.icon == nil {
+		return nil
+	}
+	url := formatAssetURL(ChannelIcon, opts, c.id, *c.icon)
+	return &url
+}
+
+// OwnerID returns the ID of the User who created the GroupDMChannel.
+func (c GroupDMChannel) OwnerID() snowflake.ID {
+	return c.ownerID
+}
+
+// ApplicationID returns the ID of the application which created the GroupDMChannel if it was created by one.
+func (c GroupDMChannel) ApplicationID() *snowflake.ID {
+	return c.applicationID
+}
+
+func (c GroupDMChannel) Recipients() []User {
+	return c.recipients
+}
+
+func (c GroupDMChannel) LastMessageID() *snowflake.ID {
+	return c.lastMessageID
+}
+
+func (c GroupDMChannel) LastPinTimestamp() *time.Time {
+	return c.lastPinTimestamp
+}
+
+func (c GroupDMChannel) CreatedAt() time.Time {
+	return c.id.Time()
+}
+
+func (GroupDMChannel) channel()        {}
+func (GroupDMChannel) messageChannel() {}
+func (GroupDMChannel) privateChannel() {}
 
 var (
 	_ Channel             = (*GuildVoiceChannel)(nil)
@@ -1417,6 +1566,34 @@ func ApplyLastMessageIDToChannel(channel GuildMessageChannel, lastMessageID snow
 	}
 }
 
+// ApplyLastMessageIDToPrivateChannel returns a copy of the PrivateChannel with the given last message ID.
+func ApplyLastMessageIDToPrivateChannel(channel PrivateChannel, lastMessageID snowflake.ID) PrivateChannel {
+	switch c := channel.(type) {
+	case DMChannel:
+		c.lastMessageID = &lastMessageID
+		return c
+	case GroupDMChannel:
+		c.lastMessageID = &lastMessageID
+		return c
+	default:
+		return channel
+	}
+}
+
+// ApplyLastPinTimestampToPrivateChannel returns a copy of the PrivateChannel with the given last pin timestamp.
+func ApplyLastPinTimestampToPrivateChannel(channel PrivateChannel, lastPinTimestamp *time.Time) PrivateChannel {
+	switch c := channel.(type) {
+	case DMChannel:
+		c.lastPinTimestamp = lastPinTimestamp
+		return c
+	case GroupDMChannel:
+		c.lastPinTimestamp = lastPinTimestamp
+		return c
+	default:
+		return channel
+	}
+}
+
 func ApplyLastPinTimestampToChannel(channel GuildMessageChannel, lastPinTimestamp *time.Time) GuildMessageChannel {
 	switch c := channel.(type) {
 	case GuildTextChannel:
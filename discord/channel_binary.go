@@ -0,0 +1,406 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/disgoorg/disgo/internal/bincodec"
+)
+
+// The binary encoding of the guild channels is used by the cache codecs. It doesn't contain the ChannelType, except for GuildThread(s).
+
+func (c GuildTextChannel) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 64)}
+	w.ID(c.id)
+	w.ID(c.guildID)
+	w.Int(int64(c.position))
+	writePermissionOverwrites(&w, c.permissionOverwrites)
+	w.String(c.name)
+	w.OptString(c.topic)
+	w.Bool(c.nsfw)
+	w.OptID(c.lastMessageID)
+	w.Int(int64(c.rateLimitPerUser))
+	w.OptID(c.parentID)
+	w.OptTime(c.lastPinTimestamp)
+	w.Int(int64(c.defaultAutoArchiveDuration))
+	return w.Buf, nil
+}
+
+func (c *GuildTextChannel) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.guildID = r.ID()
+	c.position = int(r.Int())
+	c.permissionOverwrites = readPermissionOverwrites(r)
+	c.name = r.String()
+	c.topic = r.OptString()
+	c.nsfw = r.Bool()
+	c.lastMessageID = r.OptID()
+	c.rateLimitPerUser = int(r.Int())
+	c.parentID = r.OptID()
+	c.lastPinTimestamp = r.OptTime()
+	c.defaultAutoArchiveDuration = AutoArchiveDuration(r.Int())
+	return r.Err()
+}
+
+func (c GuildVoiceChannel) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 64)}
+	w.ID(c.id)
+	w.ID(c.guildID)
+	w.Int(int64(c.position))
+	writePermissionOverwrites(&w, c.permissionOverwrites)
+	w.String(c.name)
+	w.Int(int64(c.bitrate))
+	w.Int(int64(c.UserLimit))
+	w.OptID(c.parentID)
+	w.String(c.rtcRegion)
+	w.Int(int64(c.VideoQualityMode))
+	w.OptID(c.lastMessageID)
+	w.Bool(c.nsfw)
+	w.Int(int64(c.rateLimitPerUser))
+	return w.Buf, nil
+}
+
+func (c *GuildVoiceChannel) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.guildID = r.ID()
+	c.position = int(r.Int())
+	c.permissionOverwrites = readPermissionOverwrites(r)
+	c.name = r.String()
+	c.bitrate = int(r.Int())
+	c.UserLimit = int(r.Int())
+	c.parentID = r.OptID()
+	c.rtcRegion = r.String()
+	c.VideoQualityMode = VideoQualityMode(r.Int())
+	c.lastMessageID = r.OptID()
+	c.nsfw = r.Bool()
+	c.rateLimitPerUser = int(r.Int())
+	return r.Err()
+}
+
+func (c GuildCategoryChannel) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 32)}
+	w.ID(c.id)
+	w.ID(c.guildID)
+	w.Int(int64(c.position))
+	writePermissionOverwrites(&w, c.permissionOverwrites)
+	w.String(c.name)
+	return w.Buf, nil
+}
+
+func (c *GuildCategoryChannel) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.guildID = r.ID()
+	c.position = int(r.Int())
+	c.permissionOverwrites = readPermissionOverwrites(r)
+	c.name = r.String()
+	return r.Err()
+}
+
+func (c GuildNewsChannel) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 64)}
+	w.ID(c.id)
+	w.ID(c.guildID)
+	w.Int(int64(c.position))
+	writePermissionOverwrites(&w, c.permissionOverwrites)
+	w.String(c.name)
+	w.OptString(c.topic)
+	w.Bool(c.nsfw)
+	w.OptID(c.lastMessageID)
+	w.Int(int64(c.rateLimitPerUser))
+	w.OptID(c.parentID)
+	w.OptTime(c.lastPinTimestamp)
+	w.Int(int64(c.defaultAutoArchiveDuration))
+	return w.Buf, nil
+}
+
+func (c *GuildNewsChannel) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.guildID = r.ID()
+	c.position = int(r.Int())
+	c.permissionOverwrites = readPermissionOverwrites(r)
+	c.name = r.String()
+	c.topic = r.OptString()
+	c.nsfw = r.Bool()
+	c.lastMessageID = r.OptID()
+	c.rateLimitPerUser = int(r.Int())
+	c.parentID = r.OptID()
+	c.lastPinTimestamp = r.OptTime()
+	c.defaultAutoArchiveDuration = AutoArchiveDuration(r.Int())
+	return r.Err()
+}
+
+func (c GuildThread) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 64)}
+	w.ID(c.id)
+	w.Int(int64(c.channelType))
+	w.ID(c.guildID)
+	w.String(c.name)
+	w.Bool(c.nsfw)
+	w.OptID(c.lastMessageID)
+	w.OptTime(c.lastPinTimestamp)
+	w.Int(int64(c.rateLimitPerUser))
+	w.ID(c.OwnerID)
+	w.ID(c.parentID)
+	w.Int(int64(c.MessageCount))
+	w.Int(int64(c.TotalMessageSent))
+	w.IDs(c.AppliedTags)
+	w.Int(int64(c.MemberCount))
+	w.Bool(c.ThreadMetadata.Archived)
+	w.Int(int64(c.ThreadMetadata.AutoArchiveDuration))
+	w.Time(c.ThreadMetadata.ArchiveTimestamp)
+	w.Bool(c.ThreadMetadata.Locked)
+	w.Bool(c.ThreadMetadata.Invitable)
+	w.Time(c.ThreadMetadata.CreateTimestamp)
+	return w.Buf, nil
+}
+
+func (c *GuildThread) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.channelType = ChannelType(r.Int())
+	c.guildID = r.ID()
+	c.name = r.String()
+	c.nsfw = r.Bool()
+	c.lastMessageID = r.OptID()
+	c.lastPinTimestamp = r.OptTime()
+	c.rateLimitPerUser = int(r.Int())
+	c.OwnerID = r.ID()
+	c.parentID = r.ID()
+	c.MessageCount = int(r.Int())
+	c.TotalMessageSent = int(r.Int())
+	c.AppliedTags = r.IDs()
+	c.MemberCount = int(r.Int())
+	c.ThreadMetadata = ThreadMetadata{
+		Archived:            r.Bool(),
+		AutoArchiveDuration: AutoArchiveDuration(r.Int()),
+		ArchiveTimestamp:    r.Time(),
+		Locked:              r.Bool(),
+		Invitable:           r.Bool(),
+		CreateTimestamp:     r.Time(),
+	}
+	return r.Err()
+}
+
+func (c GuildStageVoiceChannel) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 64)}
+	w.ID(c.id)
+	w.ID(c.guildID)
+	w.Int(int64(c.position))
+	writePermissionOverwrites(&w, c.permissionOverwrites)
+	w.String(c.name)
+	w.Int(int64(c.bitrate))
+	w.OptID(c.parentID)
+	w.String(c.rtcRegion)
+	w.Int(int64(c.VideoQualityMode))
+	w.OptID(c.lastMessageID)
+	w.Bool(c.nsfw)
+	w.Int(int64(c.rateLimitPerUser))
+	return w.Buf, nil
+}
+
+func (c *GuildStageVoiceChannel) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.guildID = r.ID()
+	c.position = int(r.Int())
+	c.permissionOverwrites = readPermissionOverwrites(r)
+	c.name = r.String()
+	c.bitrate = int(r.Int())
+	c.parentID = r.OptID()
+	c.rtcRegion = r.String()
+	c.VideoQualityMode = VideoQualityMode(r.Int())
+	c.lastMessageID = r.OptID()
+	c.nsfw = r.Bool()
+	c.rateLimitPerUser = int(r.Int())
+	return r.Err()
+}
+
+func (c GuildForumChannel) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 128)}
+	w.ID(c.id)
+	w.ID(c.guildID)
+	w.Int(int64(c.position))
+	writePermissionOverwrites(&w, c.permissionOverwrites)
+	w.String(c.name)
+	w.OptID(c.parentID)
+	w.OptID(c.LastPostID)
+	w.OptString(c.Topic)
+	w.Bool(c.NSFW)
+	w.Int(int64(c.RateLimitPerUser))
+	w.Int(int64(c.Flags))
+	writeChannelTags(&w, c.AvailableTags)
+	writeDefaultReactionEmoji(&w, c.DefaultReactionEmoji)
+	w.Int(int64(c.DefaultThreadRateLimitPerUser))
+	writeDefaultSortOrder(&w, c.DefaultSortOrder)
+	w.Int(int64(c.DefaultForumLayout))
+	return w.Buf, nil
+}
+
+func (c *GuildForumChannel) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.guildID = r.ID()
+	c.position = int(r.Int())
+	c.permissionOverwrites = readPermissionOverwrites(r)
+	c.name = r.String()
+	c.parentID = r.OptID()
+	c.LastPostID = r.OptID()
+	c.Topic = r.OptString()
+	c.NSFW = r.Bool()
+	c.RateLimitPerUser = int(r.Int())
+	c.Flags = ChannelFlags(r.Int())
+	c.AvailableTags = readChannelTags(r)
+	c.DefaultReactionEmoji = readDefaultReactionEmoji(r)
+	c.DefaultThreadRateLimitPerUser = int(r.Int())
+	c.DefaultSortOrder = readDefaultSortOrder(r)
+	c.DefaultForumLayout = DefaultForumLayout(r.Int())
+	return r.Err()
+}
+
+func (c GuildMediaChannel) MarshalBinary() ([]byte, error) {
+	w := bincodec.Writer{Buf: make([]byte, 0, 128)}
+	w.ID(c.id)
+	w.ID(c.guildID)
+	w.Int(int64(c.position))
+	writePermissionOverwrites(&w, c.permissionOverwrites)
+	w.String(c.name)
+	w.OptID(c.parentID)
+	w.OptID(c.LastPostID)
+	w.OptString(c.Topic)
+	w.Bool(c.NSFW)
+	w.Int(int64(c.RateLimitPerUser))
+	w.Int(int64(c.Flags))
+	writeChannelTags(&w, c.AvailableTags)
+	writeDefaultReactionEmoji(&w, c.DefaultReactionEmoji)
+	w.Int(int64(c.DefaultThreadRateLimitPerUser))
+	writeDefaultSortOrder(&w, c.DefaultSortOrder)
+	return w.Buf, nil
+}
+
+func (c *GuildMediaChannel) UnmarshalBinary(data []byte) error {
+	r := bincodec.NewReader(data)
+	c.id = r.ID()
+	c.guildID = r.ID()
+	c.position = int(r.Int())
+	c.permissionOverwrites = readPermissionOverwrites(r)
+	c.name = r.String()
+	c.parentID = r.OptID()
+	c.LastPostID = r.OptID()
+	c.Topic = r.OptString()
+	c.NSFW = r.Bool()
+	c.RateLimitPerUser = int(r.Int())
+	c.Flags = ChannelFlags(r.Int())
+	c.AvailableTags = readChannelTags(r)
+	c.DefaultReactionEmoji = readDefaultReactionEmoji(r)
+	c.DefaultThreadRateLimitPerUser = int(r.Int())
+	c.DefaultSortOrder = readDefaultSortOrder(r)
+	return r.Err()
+}
+
+func writePermissionOverwrites(w *bincodec.Writer, overwrites []PermissionOverwrite) {
+	w.Uint(uint64(len(overwrites)))
+	for _, overwrite := range overwrites {
+		w.Int(int64(overwrite.Type()))
+		w.ID(overwrite.ID())
+		switch o := overwrite.(type) {
+		case RolePermissionOverwrite:
+			w.Int(int64(o.Allow))
+			w.Int(int64(o.Deny))
+		case MemberPermissionOverwrite:
+			w.Int(int64(o.Allow))
+			w.Int(int64(o.Deny))
+		default:
+			w.Int(0)
+			w.Int(0)
+		}
+	}
+}
+
+func readPermissionOverwrites(r *bincodec.Reader) PermissionOverwrites {
+	n := r.Len()
+	if n == 0 {
+		return nil
+	}
+	overwrites := make(PermissionOverwrites, 0, n)
+	for i := 0; i < n; i++ {
+		overwriteType := PermissionOverwriteType(r.Int())
+		id := r.ID()
+		allow := Permissions(r.Int())
+		deny := Permissions(r.Int())
+		switch overwriteType {
+		case PermissionOverwriteTypeRole:
+			overwrites = append(overwrites, RolePermissionOverwrite{RoleID: id, Allow: allow, Deny: deny})
+		case PermissionOverwriteTypeMember:
+			overwrites = append(overwrites, MemberPermissionOverwrite{UserID: id, Allow: allow, Deny: deny})
+		default:
+			r.Fail(fmt.Errorf("%w: unknown permission overwrite type %d", bincodec.ErrInvalidEncoding, overwriteType))
+			return nil
+		}
+	}
+	return overwrites
+}
+
+func writeChannelTags(w *bincodec.Writer, tags []ChannelTag) {
+	w.Uint(uint64(len(tags)))
+	for _, tag := range tags {
+		w.ID(tag.ID)
+		w.String(tag.Name)
+		w.Bool(tag.Moderated)
+		w.OptID(tag.EmojiID)
+		w.OptString(tag.EmojiName)
+	}
+}
+
+func readChannelTags(r *bincodec.Reader) []ChannelTag {
+	n := r.Len()
+	if n == 0 {
+		return nil
+	}
+	tags := make([]ChannelTag, n)
+	for i := range tags {
+		tags[i] = ChannelTag{
+			ID:        r.ID(),
+			Name:      r.String(),
+			Moderated: r.Bool(),
+			EmojiID:   r.OptID(),
+			EmojiName: r.OptString(),
+		}
+	}
+	return tags
+}
+
+func writeDefaultReactionEmoji(w *bincodec.Writer, emoji *DefaultReactionEmoji) {
+	w.Bool(emoji != nil)
+	if emoji != nil {
+		w.OptID(emoji.EmojiID)
+		w.OptString(emoji.EmojiName)
+	}
+}
+
+func readDefaultReactionEmoji(r *bincodec.Reader) *DefaultReactionEmoji {
+	if !r.Bool() {
+		return nil
+	}
+	return &DefaultReactionEmoji{
+		EmojiID:   r.OptID(),
+		EmojiName: r.OptString(),
+	}
+}
+
+func writeDefaultSortOrder(w *bincodec.Writer, sortOrder *DefaultSortOrder) {
+	w.Bool(sortOrder != nil)
+	if sortOrder != nil {
+		w.Int(int64(*sortOrder))
+	}
+}
+
+func readDefaultSortOrder(r *bincodec.Reader) *DefaultSortOrder {
+	if !r.Bool() {
+		return nil
+	}
+	sortOrder := DefaultSortOrder(r.Int())
+	return &sortOrder
+}
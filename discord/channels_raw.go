@@ -15,6 +15,18 @@ type dmChannel struct {
 	LastPinTimestamp *time.Time    `json:"last_pin_timestamp"`
 }
 
+type groupDMChannel struct {
+	ID               snowflake.ID  `json:"id"`
+	Type             ChannelType   `json:"type"`
+	Name             string        `json:"name"`
+	Icon             *string       `json:"icon"`
+	OwnerID          snowflake.ID  `json:"owner_id"`
+	ApplicationID    *snowflake.ID `json:"application_id,omitempty"`
+	LastMessageID    *snowflake.ID `json:"last_message_id"`
+	Recipients       []User        `json:"recipients"`
+	LastPinTimestamp *time.Time    `json:"last_pin_timestamp"`
+}
+
 type guildTextChannel struct {
 	ID                         snowflake.ID          `json:"id"`
 	Type                       ChannelType           `json:"type"`
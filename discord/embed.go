@@ -32,6 +32,15 @@ type Embed struct {
 	Fields      []EmbedField   `json:"fields,omitempty"`
 }
 
+// Validate checks the Embed against the limits of Discord and returns ValidationErrors with all invalid fields.
+func (e Embed) Validate() error {
+	var v validator
+	if total := v.embed("", e); total > EmbedTotalMaxLength {
+		v.addf("", "embed must have at most %d characters, got %d", EmbedTotalMaxLength, total)
+	}
+	return v.err()
+}
+
 // The EmbedResource of an Embed.Image/Embed.Thumbnail/Embed.Video
 type EmbedResource struct {
 	URL      string `json:"url,omitempty"`
@@ -29,6 +29,8 @@ var (
 
 	ErrCheckFailed = errors.New("check failed")
 
+	ErrFileNotReplayable = errors.New("file does not implement io.Seeker and was already sent, it can't be sent again")
+
 	ErrMemberMustBeConnectedToChannel = errors.New("the member must be connected to the channel")
 
 	ErrStickerTypeGuild = errors.New("sticker type must be of type StickerTypeGuild")
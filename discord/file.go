@@ -6,6 +6,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/textproto"
+	"sync"
 
 	"github.com/disgoorg/json"
 
@@ -17,55 +18,242 @@ type Payload interface {
 }
 
 // MultipartBuffer holds the Body & ContentType of the multipart body
+//
+// Deprecated: Use MultipartBody which streams the files instead of copying them into memory.
 type MultipartBuffer struct {
 	Buffer      *bytes.Buffer
 	ContentType string
 }
 
 // PayloadWithFiles returns the given payload as multipart body with all files in it
+//
+// Deprecated: Use NewMultipartBody which streams the files instead of copying them into memory.
 func PayloadWithFiles(v any, files ...*File) (*MultipartBuffer, error) {
-	buffer := &bytes.Buffer{}
-	writer := multipart.NewWriter(buffer)
-	writer.FormDataContentType()
-	defer func() {
-		_ = writer.Close()
-	}()
-
-	payload, err := json.Marshal(v)
+	body, err := NewMultipartBody(v, files...)
 	if err != nil {
 		return nil, err
 	}
+	buffer := &bytes.Buffer{}
+	if _, err = body.WriteTo(buffer); err != nil {
+		return nil, err
+	}
+	return &MultipartBuffer{
+		Buffer:      buffer,
+		ContentType: body.ContentType,
+	}, nil
+}
 
-	part, err := writer.CreatePart(partHeader(`form-data; name="payload_json"`, "application/json"))
+// MultipartBody is a multipart/form-data body holding a json payload & files.
+// The files are streamed from their io.Reader when the body is read instead of being copied into memory.
+// Files with an io.Seeker are rewound every time the body is read. Files without one can only be read once,
+// reading the body again fails with ErrFileNotReplayable, so such bodies are not retried.
+type MultipartBody struct {
+	ContentType string
+
+	boundary string
+	value    any
+	payload  []byte
+	files    []*multipartFile
+	mu       sync.Mutex
+}
+
+type multipartFile struct {
+	file   *File
+	name   string
+	offset int64
+	// size is -1 for files without an io.Seeker
+	size int64
+	// sent is set once a file without an io.Seeker has been read
+	sent bool
+}
+
+// NewMultipartBody returns the given payload as streamed multipart body with all files in it
+func NewMultipartBody(v any, files ...*File) (*MultipartBody, error) {
+	payload, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err = part.Write(payload); err != nil {
-		return nil, err
+	body := &MultipartBody{
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+		value:    v,
+		payload:  payload,
 	}
+	body.ContentType = "multipart/form-data; boundary=" + body.boundary
 
-	for i, file := range files {
+	for _, file := range files {
 		var name string
 		if file.Flags.Has(FileFlagSpoiler) {
 			name = "SPOILER_" + file.Name
 		} else {
 			name = file.Name
 		}
-		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, name), "application/octet-stream"))
+		mFile := &multipartFile{
+			file: file,
+			name: name,
+			size: -1,
+		}
+		if seeker, ok := file.Reader.(io.Seeker); ok {
+			if mFile.offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+				return nil, fmt.Errorf("failed to get offset of file %s: %w", file.Name, err)
+			}
+			end, err := seeker.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get size of file %s: %w", file.Name, err)
+			}
+			mFile.size = end - mFile.offset
+			if _, err = seeker.Seek(mFile.offset, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("failed to rewind file %s: %w", file.Name, err)
+			}
+		}
+		body.files = append(body.files, mFile)
+	}
+
+	return body, nil
+}
+
+// Value returns the value which was marshalled into the json payload
+func (b *MultipartBody) Value() any {
+	return b.value
+}
+
+// Payload returns the json payload of the body without the files
+func (b *MultipartBody) Payload() []byte {
+	return b.payload
+}
+
+// Reader returns a new io.ReadCloser streaming the multipart body.
+// The body is only written once the Reader is read, so a Reader which is never read doesn't block the body.
+// Replayable bodies can be read multiple times, but only one Reader should be used at a time.
+func (b *MultipartBody) Reader() io.ReadCloser {
+	r, w := io.Pipe()
+	return &multipartReader{
+		body: b,
+		r:    r,
+		w:    w,
+	}
+}
+
+// Replayable returns whether the body can be read multiple times, which is the case if all files implement io.Seeker
+func (b *MultipartBody) Replayable() bool {
+	for _, file := range b.files {
+		if file.size < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the length of the body in bytes or -1 if it is not known before reading it.
+// It is safe to call while the body is read.
+func (b *MultipartBody) Len() int64 {
+	var size int64
+	for _, file := range b.files {
+		if file.size < 0 {
+			return -1
+		}
+		size += file.size
+	}
+	counter := &countWriter{}
+	if err := b.write(counter, false); err != nil {
+		return -1
+	}
+	return counter.n + size
+}
+
+// WriteTo writes the multipart body to the given io.Writer
+func (b *MultipartBody) WriteTo(w io.Writer) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	counter := &countWriter{w: w}
+	err := b.write(counter, true)
+	return counter.n, err
+}
+
+func (b *MultipartBody) write(w io.Writer, withFiles bool) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+
+	part, err := writer.CreatePart(partHeader(`form-data; name="payload_json"`, "application/json"))
+	if err != nil {
+		return err
+	}
+
+	if _, err = part.Write(b.payload); err != nil {
+		return err
+	}
+
+	for i, file := range b.files {
+		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, file.name), "application/octet-stream"))
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if !withFiles {
+			continue
 		}
+		if err = file.writeTo(part); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", file.name, err)
+		}
+	}
+	return writer.Close()
+}
 
-		if _, err = io.Copy(part, file.Reader); err != nil {
-			return nil, err
+func (f *multipartFile) writeTo(w io.Writer) error {
+	if seeker, ok := f.file.Reader.(io.Seeker); ok {
+		if _, err := seeker.Seek(f.offset, io.SeekStart); err != nil {
+			return err
 		}
+		_, err := io.Copy(w, f.file.Reader)
+		return err
 	}
 
-	return &MultipartBuffer{
-		Buffer:      buffer,
-		ContentType: writer.FormDataContentType(),
-	}, nil
+	if f.sent {
+		return ErrFileNotReplayable
+	}
+	f.sent = true
+	_, err := io.Copy(w, f.file.Reader)
+	return err
+}
+
+// multipartReader writes its MultipartBody into a pipe on the first Read.
+// Closing it makes a running WriteTo fail, which releases the lock of the MultipartBody.
+type multipartReader struct {
+	body *MultipartBody
+	once sync.Once
+	r    *io.PipeReader
+	w    *io.PipeWriter
+}
+
+func (r *multipartReader) Read(p []byte) (int, error) {
+	r.once.Do(func() {
+		go func() {
+			_, err := r.body.WriteTo(r.w)
+			_ = r.w.CloseWithError(err)
+		}()
+	})
+	return r.r.Read(p)
+}
+
+func (r *multipartReader) Close() error {
+	return r.r.Close()
+}
+
+// countWriter counts the bytes written to it and passes them to w if set
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	if w.w == nil {
+		w.n += int64(len(p))
+		return len(p), nil
+	}
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
 }
 
 func partHeader(contentDisposition string, contentType string) textproto.MIMEHeader {
@@ -0,0 +1,99 @@
+package discord
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readBody(t *testing.T, body *MultipartBody) []byte {
+	t.Helper()
+	r := body.Reader()
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return data
+}
+
+func TestMultipartBodySeekable(t *testing.T) {
+	file := NewFile("test.txt", "", bytes.NewReader([]byte("hello world")))
+	body, err := NewMultipartBody(map[string]string{"content": "test"}, file)
+	require.NoError(t, err)
+
+	assert.True(t, body.Replayable())
+	first := readBody(t, body)
+	second := readBody(t, body)
+	assert.Equal(t, first, second, "seekable files should be rewound when the body is read again")
+	assert.Equal(t, int64(len(first)), body.Len())
+	assert.Contains(t, string(first), "hello world")
+	assert.Contains(t, string(first), `{"content":"test"}`)
+}
+
+func TestMultipartBodyNotSeekable(t *testing.T) {
+	file := NewFile("test.txt", "", io.MultiReader(strings.NewReader("hello world")))
+	body, err := NewMultipartBody(map[string]string{"content": "test"}, file)
+	require.NoError(t, err)
+
+	assert.False(t, body.Replayable())
+	assert.Equal(t, int64(-1), body.Len())
+	assert.Contains(t, string(readBody(t, body)), "hello world")
+
+	_, err = body.WriteTo(io.Discard)
+	assert.ErrorIs(t, err, ErrFileNotReplayable)
+}
+
+func TestMultipartBodyLenWhileReading(t *testing.T) {
+	file := NewFile("test.txt", "", bytes.NewReader(bytes.Repeat([]byte("a"), 1<<20)))
+	body, err := NewMultipartBody(nil, file)
+	require.NoError(t, err)
+
+	r := body.Reader()
+	defer r.Close()
+	length := body.Len()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), length)
+}
+
+func TestMultipartBodyUnreadReader(t *testing.T) {
+	file := NewFile("test.txt", "", bytes.NewReader(bytes.Repeat([]byte("a"), 1<<20)))
+	body, err := NewMultipartBody(nil, file)
+	require.NoError(t, err)
+
+	// a Reader which is never read, like the body of a request which was short-circuited by an Interceptor
+	_ = body.Reader()
+	// give a writer which is started before the Reader is read the time to lock the body
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		// a Reader which is closed after reading only a part of the body
+		r := body.Reader()
+		if _, err := r.Read(make([]byte, 10)); err != nil {
+			done <- err
+			return
+		}
+		_ = r.Close()
+
+		_, err := body.WriteTo(io.Discard)
+		done <- err
+	}()
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("reading the body blocked on a previous Reader")
+	}
+}
+
+func TestPayloadWithFiles(t *testing.T) {
+	buffer, err := PayloadWithFiles(map[string]string{"content": "test"}, NewFile("test.txt", "", strings.NewReader("hello world")))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buffer.ContentType, "multipart/form-data; boundary="))
+	assert.Contains(t, buffer.Buffer.String(), "hello world")
+}
@@ -31,6 +31,18 @@ func (r InteractionResponse) ToBody() (any, error) {
 	return r, nil
 }
 
+// Validate validates the InteractionResponse.Data if it implements Validator.
+// A MessageCreate only needs content for InteractionResponseTypeCreateMessage, deferred responses may only set its flags.
+func (r InteractionResponse) Validate() error {
+	if m, ok := r.Data.(MessageCreate); ok {
+		return prefixValidationErrors("data", m.validate(r.Type == InteractionResponseTypeCreateMessage))
+	}
+	if v, ok := r.Data.(Validator); ok {
+		return prefixValidationErrors("data", v.Validate())
+	}
+	return nil
+}
+
 type InteractionResponseData interface {
 	interactionCallbackData()
 }
@@ -45,6 +57,19 @@ type AutocompleteResult struct {
 
 func (AutocompleteResult) interactionCallbackData() {}
 
+// Validate checks the AutocompleteResult against the limits of Discord and returns ValidationErrors with all invalid fields.
+func (r AutocompleteResult) Validate() error {
+	var v validator
+	v.maxItems("choices", len(r.Choices), AutocompleteMaxChoices)
+	for i, choice := range r.Choices {
+		v.maxLength(joinPath(indexPath("choices", i), "name"), choice.ChoiceName(), AutocompleteChoiceNameMaxLength)
+		if c, ok := choice.(AutocompleteChoiceString); ok {
+			v.maxLength(joinPath(indexPath("choices", i), "value"), c.Value, AutocompleteChoiceValueMaxLength)
+		}
+	}
+	return v.err()
+}
+
 type AutocompleteChoice interface {
 	ChoiceName() string
 
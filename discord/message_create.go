@@ -22,11 +22,30 @@ type MessageCreate struct {
 
 func (MessageCreate) interactionCallbackData() {}
 
+// Validate checks the MessageCreate against the limits of Discord and returns ValidationErrors with all invalid fields.
+func (m MessageCreate) Validate() error {
+	return m.validate(true)
+}
+
+// validate checks the MessageCreate. requireContent is false for payloads which may only set flags, like deferred interaction responses.
+func (m MessageCreate) validate(requireContent bool) error {
+	var v validator
+	v.maxLength("content", m.Content, MessageContentMaxLength)
+	v.embeds("embeds", m.Embeds)
+	v.components("components", m.Components)
+	v.maxItems("sticker_ids", len(m.StickerIDs), MessageMaxStickers)
+	v.maxItems("attachments", max(len(m.Files), len(m.Attachments)), MessageMaxFiles)
+	if requireContent && m.Content == "" && len(m.Embeds) == 0 && len(m.Components) == 0 && len(m.StickerIDs) == 0 && len(m.Files) == 0 && len(m.Attachments) == 0 {
+		v.addf("", "message must have content, embeds, components, stickers or files")
+	}
+	return v.err()
+}
+
 // ToBody returns the MessageCreate ready for body
 func (m MessageCreate) ToBody() (any, error) {
 	if len(m.Files) > 0 {
 		m.Attachments = parseAttachments(m.Files)
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartBody(m, m.Files...)
 	}
 	return m, nil
 }
@@ -35,7 +54,7 @@ func (m MessageCreate) ToResponseBody(response InteractionResponse) (any, error)
 	if len(m.Files) > 0 {
 		m.Attachments = parseAttachments(m.Files)
 		response.Data = m
-		return PayloadWithFiles(response, m.Files...)
+		return NewMultipartBody(response, m.Files...)
 	}
 	return response, nil
 }
@@ -13,6 +13,26 @@ type MessageUpdate struct {
 
 func (MessageUpdate) interactionCallbackData() {}
 
+// Validate checks the MessageUpdate against the limits of Discord and returns ValidationErrors with all invalid fields.
+func (m MessageUpdate) Validate() error {
+	var v validator
+	if m.Content != nil {
+		v.maxLength("content", *m.Content, MessageContentMaxLength)
+	}
+	if m.Embeds != nil {
+		v.embeds("embeds", *m.Embeds)
+	}
+	if m.Components != nil {
+		v.components("components", *m.Components)
+	}
+	files := len(m.Files)
+	if m.Attachments != nil {
+		files += len(*m.Attachments)
+	}
+	v.maxItems("attachments", files, MessageMaxFiles)
+	return v.err()
+}
+
 // ToBody returns the MessageUpdate ready for body
 func (m MessageUpdate) ToBody() (any, error) {
 	if len(m.Files) > 0 {
@@ -22,7 +42,7 @@ func (m MessageUpdate) ToBody() (any, error) {
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartBody(m, m.Files...)
 	}
 	return m, nil
 }
@@ -35,7 +55,7 @@ func (m MessageUpdate) ToResponseBody(response InteractionResponse) (any, error)
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(response, m.Files...)
+		return NewMultipartBody(response, m.Files...)
 	}
 	return response, nil
 }
@@ -0,0 +1,335 @@
+package discord
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// PermissionSourceType is the kind of rule which granted or denied a permission.
+type PermissionSourceType int
+
+// All PermissionSourceType(s)
+const (
+	// PermissionSourceTypeOwner grants all permissions to the guild owner.
+	PermissionSourceTypeOwner PermissionSourceType = iota
+	// PermissionSourceTypeAdministrator grants all permissions to members with a role with PermissionAdministrator.
+	PermissionSourceTypeAdministrator
+	// PermissionSourceTypeRole grants the permissions of a role. The @everyone role has the ID of the guild.
+	PermissionSourceTypeRole
+	// PermissionSourceTypeEveryoneOverwrite is the channel overwrite of the @everyone role.
+	PermissionSourceTypeEveryoneOverwrite
+	// PermissionSourceTypeRoleOverwrite is a channel overwrite of a role.
+	PermissionSourceTypeRoleOverwrite
+	// PermissionSourceTypeMemberOverwrite is the channel overwrite of the member.
+	PermissionSourceTypeMemberOverwrite
+	// PermissionSourceTypeImplicit is one of discord's implicit rules, the permission is implied by PermissionSource.Cause.
+	PermissionSourceTypeImplicit
+	// PermissionSourceTypeThreadMembership denies viewing private threads the member did not join.
+	PermissionSourceTypeThreadMembership
+	// PermissionSourceTypeTimeout denies all permissions except PermissionViewChannel & PermissionReadMessageHistory to timed out members.
+	PermissionSourceTypeTimeout
+)
+
+func (t PermissionSourceType) String() string {
+	switch t {
+	case PermissionSourceTypeOwner:
+		return "Owner"
+	case PermissionSourceTypeAdministrator:
+		return "Administrator"
+	case PermissionSourceTypeRole:
+		return "Role"
+	case PermissionSourceTypeEveryoneOverwrite:
+		return "Everyone Overwrite"
+	case PermissionSourceTypeRoleOverwrite:
+		return "Role Overwrite"
+	case PermissionSourceTypeMemberOverwrite:
+		return "Member Overwrite"
+	case PermissionSourceTypeImplicit:
+		return "Implicit"
+	case PermissionSourceTypeThreadMembership:
+		return "Thread Membership"
+	case PermissionSourceTypeTimeout:
+		return "Timeout"
+	default:
+		return "Unknown"
+	}
+}
+
+// PermissionSource is a rule which granted or denied a permission.
+type PermissionSource struct {
+	Type PermissionSourceType `json:"type"`
+	// ID is the ID of the role or member of the source. It is 0 for implicit rules & timeouts.
+	ID snowflake.ID `json:"id,omitempty"`
+	// Cause is the permission which implied the permission for PermissionSourceTypeImplicit.
+	Cause Permissions `json:"cause,omitempty"`
+}
+
+func (s PermissionSource) String() string {
+	switch s.Type {
+	case PermissionSourceTypeRole, PermissionSourceTypeRoleOverwrite, PermissionSourceTypeAdministrator:
+		return fmt.Sprintf("%s(%s)", s.Type, RoleMention(s.ID))
+	case PermissionSourceTypeMemberOverwrite:
+		return fmt.Sprintf("%s(%s)", s.Type, UserMention(s.ID))
+	case PermissionSourceTypeImplicit:
+		return fmt.Sprintf("%s(%s)", s.Type, s.Cause)
+	default:
+		return s.Type.String()
+	}
+}
+
+// PermissionExplanation explains why a single permission was granted or denied.
+type PermissionExplanation struct {
+	Permission Permissions `json:"permission"`
+	Allowed    bool        `json:"allowed"`
+	// Sources are the rules which decided the permission last. Several roles or role overwrites can decide the same permission.
+	// It is empty if the permission was never granted.
+	Sources []PermissionSource `json:"sources"`
+}
+
+func (e PermissionExplanation) String() string {
+	verb := "denied"
+	if e.Allowed {
+		verb = "allowed"
+	}
+	if len(e.Sources) == 0 {
+		return fmt.Sprintf("%s: %s, not granted by any role", e.Permission, verb)
+	}
+	return fmt.Sprintf("%s: %s by %v", e.Permission, verb, e.Sources)
+}
+
+// PermissionResult is the result of CalculatePermissions.
+type PermissionResult struct {
+	// Permissions are the effective permissions.
+	Permissions Permissions
+	sources     map[Permissions][]PermissionSource
+}
+
+// Explain returns the PermissionExplanation of a single permission.
+func (r PermissionResult) Explain(permission Permissions) PermissionExplanation {
+	return PermissionExplanation{
+		Permission: permission,
+		Allowed:    r.Permissions.Has(permission),
+		Sources:    r.sources[permission],
+	}
+}
+
+// Explanations returns the PermissionExplanation of all known permissions.
+func (r PermissionResult) Explanations() []PermissionExplanation {
+	var explanations []PermissionExplanation
+	forEachPermission(PermissionsAll, func(permission Permissions) {
+		explanations = append(explanations, r.Explain(permission))
+	})
+	return explanations
+}
+
+// PermissionInput holds everything needed to calculate the permissions of a member.
+// It can be filled from the caches or from REST payloads.
+type PermissionInput struct {
+	GuildID snowflake.ID
+	OwnerID snowflake.ID
+	// Roles are the roles of the guild. Only the @everyone role and the roles of the Member are used.
+	Roles []Role
+	// Member is the member to calculate the permissions of. Member.GuildID is not used.
+	Member Member
+
+	// Channel is the channel to calculate the permissions in. If nil, the guild permissions are calculated.
+	Channel GuildChannel
+	// Parent is the parent channel of the Channel if it is a GuildThread. Threads inherit the overwrites of their parent.
+	Parent GuildChannel
+	// ThreadMember is whether the Member joined the Channel if it is a private GuildThread.
+	ThreadMember bool
+}
+
+// voicePermissions are implicitly denied in audio channels without PermissionConnect
+const voicePermissions = PermissionSpeak |
+	PermissionStream |
+	PermissionMuteMembers |
+	PermissionDeafenMembers |
+	PermissionMoveMembers |
+	PermissionUseVAD |
+	PermissionPrioritySpeaker |
+	PermissionUseSoundboard |
+	PermissionUseExternalSounds |
+	PermissionRequestToSpeak |
+	PermissionUseEmbeddedActivities
+
+// sendMessagePermissions are implicitly denied without PermissionSendMessages
+const sendMessagePermissions = PermissionMentionEveryone |
+	PermissionSendTTSMessages |
+	PermissionAttachFiles |
+	PermissionEmbedLinks
+
+// CalculatePermissions calculates the permissions of a member in a guild or channel with discord's implicit rules:
+//   - the guild owner and administrators have all permissions
+//   - overwrites are applied in the order @everyone, roles, member
+//   - threads use the overwrites of their parent, PermissionSendMessagesInThreads replaces PermissionSendMessages
+//     and private threads are only visible to their members & members with PermissionManageThreads
+//   - without PermissionViewChannel all permissions are denied
+//   - without PermissionSendMessages, mentioning everyone, sending TTS messages, attaching files and embedding links is denied
+//   - without PermissionConnect in an audio channel all voice permissions are denied
+//   - timed out members only keep PermissionViewChannel & PermissionReadMessageHistory
+func CalculatePermissions(input PermissionInput) PermissionResult {
+	c := permissionCalculator{
+		sources: map[Permissions][]PermissionSource{},
+	}
+
+	if input.Member.User.ID == input.OwnerID {
+		c.set(PermissionsAll, true, PermissionSource{Type: PermissionSourceTypeOwner})
+		return c.result()
+	}
+
+	c.applyRoles(input)
+	if c.permissions.Has(PermissionAdministrator) {
+		return c.result()
+	}
+
+	if input.Channel != nil {
+		overwriteChannel := input.Channel
+		thread, isThread := input.Channel.(GuildThread)
+		if isThread && input.Parent != nil {
+			overwriteChannel = input.Parent
+		}
+		c.applyOverwrites(input, overwriteChannel.PermissionOverwrites())
+
+		if isThread {
+			c.applyThread(input, thread)
+		}
+		c.applyImplicit(input.Channel)
+	}
+
+	if input.Member.CommunicationDisabledUntil != nil && input.Member.CommunicationDisabledUntil.After(time.Now()) {
+		c.deny(c.permissions&^(PermissionViewChannel|PermissionReadMessageHistory), PermissionSource{Type: PermissionSourceTypeTimeout})
+	}
+	return c.result()
+}
+
+type permissionCalculator struct {
+	permissions Permissions
+	sources     map[Permissions][]PermissionSource
+}
+
+func (c *permissionCalculator) result() PermissionResult {
+	return PermissionResult{
+		Permissions: c.permissions,
+		sources:     c.sources,
+	}
+}
+
+// set sets all bits to allowed and replaces their sources
+func (c *permissionCalculator) set(bits Permissions, allowed bool, sources ...PermissionSource) {
+	forEachPermission(bits, func(permission Permissions) {
+		if allowed {
+			c.permissions |= permission
+		} else {
+			c.permissions &^= permission
+		}
+		c.sources[permission] = sources
+	})
+}
+
+// deny denies all bits which are currently allowed
+func (c *permissionCalculator) deny(bits Permissions, source PermissionSource) {
+	c.set(bits&c.permissions, false, source)
+}
+
+func (c *permissionCalculator) applyRoles(input PermissionInput) {
+	var admins []PermissionSource
+	for _, role := range input.Roles {
+		if role.ID != input.GuildID && !slices.Contains(input.Member.RoleIDs, role.ID) {
+			continue
+		}
+		source := PermissionSource{Type: PermissionSourceTypeRole, ID: role.ID}
+		forEachPermission(role.Permissions, func(permission Permissions) {
+			c.permissions |= permission
+			c.sources[permission] = append(c.sources[permission], source)
+		})
+		if role.Permissions.Has(PermissionAdministrator) {
+			admins = append(admins, PermissionSource{Type: PermissionSourceTypeAdministrator, ID: role.ID})
+		}
+	}
+	if len(admins) > 0 {
+		c.set(PermissionsAll, true, admins...)
+	}
+}
+
+func (c *permissionCalculator) applyOverwrites(input PermissionInput, overwrites PermissionOverwrites) {
+	if overwrite, ok := overwrites.Role(input.GuildID); ok {
+		source := PermissionSource{Type: PermissionSourceTypeEveryoneOverwrite, ID: input.GuildID}
+		c.set(overwrite.Deny, false, source)
+		c.set(overwrite.Allow, true, source)
+	}
+
+	// role overwrites are combined, an allow of any role wins over a deny of another role
+	var (
+		allow        Permissions
+		deny         Permissions
+		allowSources = map[Permissions][]PermissionSource{}
+		denySources  = map[Permissions][]PermissionSource{}
+	)
+	for _, roleID := range input.Member.RoleIDs {
+		if roleID == input.GuildID {
+			continue
+		}
+		overwrite, ok := overwrites.Role(roleID)
+		if !ok {
+			continue
+		}
+		source := PermissionSource{Type: PermissionSourceTypeRoleOverwrite, ID: roleID}
+		allow |= overwrite.Allow
+		deny |= overwrite.Deny
+		forEachPermission(overwrite.Allow, func(permission Permissions) {
+			allowSources[permission] = append(allowSources[permission], source)
+		})
+		forEachPermission(overwrite.Deny, func(permission Permissions) {
+			denySources[permission] = append(denySources[permission], source)
+		})
+	}
+	forEachPermission(deny, func(permission Permissions) {
+		c.set(permission, false, denySources[permission]...)
+	})
+	forEachPermission(allow, func(permission Permissions) {
+		c.set(permission, true, allowSources[permission]...)
+	})
+
+	if overwrite, ok := overwrites.Member(input.Member.User.ID); ok {
+		source := PermissionSource{Type: PermissionSourceTypeMemberOverwrite, ID: input.Member.User.ID}
+		c.set(overwrite.Deny, false, source)
+		c.set(overwrite.Allow, true, source)
+	}
+}
+
+func (c *permissionCalculator) applyThread(input PermissionInput, thread GuildThread) {
+	if c.permissions.Has(PermissionSendMessagesInThreads) {
+		c.set(PermissionSendMessages, true, PermissionSource{Type: PermissionSourceTypeImplicit, Cause: PermissionSendMessagesInThreads})
+	} else {
+		c.deny(PermissionSendMessages, PermissionSource{Type: PermissionSourceTypeImplicit, Cause: PermissionSendMessagesInThreads})
+	}
+
+	if thread.Type() == ChannelTypeGuildPrivateThread && !input.ThreadMember && c.permissions.Missing(PermissionManageThreads) {
+		c.deny(PermissionViewChannel, PermissionSource{Type: PermissionSourceTypeThreadMembership, ID: thread.ID()})
+	}
+}
+
+func (c *permissionCalculator) applyImplicit(channel GuildChannel) {
+	if c.permissions.Missing(PermissionViewChannel) {
+		c.deny(PermissionsAll, PermissionSource{Type: PermissionSourceTypeImplicit, Cause: PermissionViewChannel})
+		return
+	}
+	if c.permissions.Missing(PermissionSendMessages) {
+		c.deny(sendMessagePermissions, PermissionSource{Type: PermissionSourceTypeImplicit, Cause: PermissionSendMessages})
+	}
+	if _, ok := channel.(GuildAudioChannel); ok && c.permissions.Missing(PermissionConnect) {
+		c.deny(voicePermissions, PermissionSource{Type: PermissionSourceTypeImplicit, Cause: PermissionConnect})
+	}
+}
+
+func forEachPermission(permissions Permissions, fn func(permission Permissions)) {
+	for i := 0; i < 63; i++ {
+		if permission := Permissions(1) << i; permissions&permission != 0 {
+			fn(permission)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculatePermissions(t *testing.T) {
+	const (
+		guildID  = 1
+		modRole  = 2
+		userID   = 3
+		parentID = 4
+	)
+	input := PermissionInput{
+		GuildID: guildID,
+		OwnerID: 10,
+		Roles: []Role{
+			{ID: guildID, Permissions: PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionConnect | PermissionSpeak},
+			{ID: modRole, Permissions: PermissionManageMessages},
+		},
+		Member: Member{User: User{ID: userID}, RoleIDs: []snowflake.ID{modRole}},
+	}
+
+	guild := CalculatePermissions(input)
+	assert.True(t, guild.Permissions.Has(PermissionManageMessages))
+	assert.Equal(t, []PermissionSource{{Type: PermissionSourceTypeRole, ID: modRole}}, guild.Explain(PermissionManageMessages).Sources)
+
+	t.Run("send messages denied", func(t *testing.T) {
+		input.Channel = GuildTextChannel{
+			id:      parentID,
+			guildID: guildID,
+			permissionOverwrites: PermissionOverwrites{
+				RolePermissionOverwrite{RoleID: guildID, Deny: PermissionSendMessages},
+			},
+		}
+		result := CalculatePermissions(input)
+		assert.False(t, result.Permissions.Has(PermissionEmbedLinks))
+		assert.Equal(t, []PermissionSource{{Type: PermissionSourceTypeImplicit, Cause: PermissionSendMessages}}, result.Explain(PermissionEmbedLinks).Sources)
+
+		input.Channel = GuildThread{channelType: ChannelTypeGuildPublicThread, guildID: guildID, parentID: parentID}
+		input.Parent = GuildTextChannel{
+			id:      parentID,
+			guildID: guildID,
+			permissionOverwrites: PermissionOverwrites{
+				RolePermissionOverwrite{RoleID: modRole, Allow: PermissionSendMessagesInThreads},
+			},
+		}
+		result = CalculatePermissions(input)
+		assert.True(t, result.Permissions.Has(PermissionSendMessages), "threads use send messages in threads")
+		assert.Equal(t, []PermissionSource{{Type: PermissionSourceTypeImplicit, Cause: PermissionSendMessagesInThreads}}, result.Explain(PermissionSendMessages).Sources)
+		input.Parent = nil
+	})
+
+	t.Run("view channel denied", func(t *testing.T) {
+		input.Channel = GuildVoiceChannel{
+			guildID: guildID,
+			permissionOverwrites: PermissionOverwrites{
+				MemberPermissionOverwrite{UserID: userID, Deny: PermissionViewChannel},
+			},
+		}
+		result := CalculatePermissions(input)
+		assert.Equal(t, PermissionsNone, result.Permissions)
+		assert.Equal(t, []PermissionSource{{Type: PermissionSourceTypeMemberOverwrite, ID: userID}}, result.Explain(PermissionViewChannel).Sources)
+		assert.Equal(t, []PermissionSource{{Type: PermissionSourceTypeImplicit, Cause: PermissionViewChannel}}, result.Explain(PermissionManageMessages).Sources)
+	})
+
+	t.Run("connect denied", func(t *testing.T) {
+		input.Channel = GuildVoiceChannel{
+			guildID: guildID,
+			permissionOverwrites: PermissionOverwrites{
+				RolePermissionOverwrite{RoleID: modRole, Deny: PermissionConnect},
+			},
+		}
+		result := CalculatePermissions(input)
+		assert.False(t, result.Permissions.Has(PermissionSpeak))
+		assert.True(t, result.Permissions.Has(PermissionViewChannel))
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		input.OwnerID = userID
+		assert.Equal(t, PermissionsAll, CalculatePermissions(input).Permissions)
+	})
+}
@@ -0,0 +1,222 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Reasons returned in a ModerationError by the RoleHierarchy checks.
+var (
+	ErrMissingPermission     = errors.New("missing permission")
+	ErrTargetIsOwner         = errors.New("target is the guild owner")
+	ErrTargetIsAdministrator = errors.New("target has the administrator permission")
+	ErrTargetRoleNotLower    = errors.New("target's highest role is not lower than the highest role of the actor")
+	ErrRoleNotLower          = errors.New("role is not lower than the highest role of the actor")
+	ErrRoleManaged           = errors.New("role is managed by an integration")
+	ErrRoleEveryone          = errors.New("the @everyone role can't be assigned")
+	ErrUnknownRole           = errors.New("role is not part of the role hierarchy")
+	ErrCannotModerateSelf    = errors.New("members can't moderate themselves")
+)
+
+// ModerationError is returned by the RoleHierarchy checks if an action is not allowed.
+// Use errors.Is with one of the reasons above to find out why.
+type ModerationError struct {
+	// Reason is one of ErrMissingPermission, ErrTargetIsOwner, ErrTargetIsAdministrator, ErrTargetRoleNotLower, ErrRoleNotLower, ErrRoleManaged, ErrRoleEveryone, ErrUnknownRole or ErrCannotModerateSelf.
+	Reason error
+	// Permission is the missing permission for ErrMissingPermission.
+	Permission Permissions
+	// RoleID is the role which caused ErrTargetRoleNotLower, ErrRoleNotLower, ErrRoleManaged, ErrRoleEveryone or ErrUnknownRole.
+	RoleID snowflake.ID
+}
+
+func (e *ModerationError) Error() string {
+	switch {
+	case errors.Is(e.Reason, ErrMissingPermission):
+		return fmt.Sprintf("%s: %s", e.Reason, e.Permission)
+	case e.RoleID != 0:
+		return fmt.Sprintf("%s: %s", e.Reason, e.RoleID)
+	default:
+		return e.Reason.Error()
+	}
+}
+
+func (e *ModerationError) Unwrap() error {
+	return e.Reason
+}
+
+// CompareRoles compares the positions of two roles. It returns a negative number if a is lower than b, a positive if a is higher and 0 if they are the same role.
+// Roles with the same position are ordered by their ID, the older role is higher.
+func CompareRoles(a Role, b Role) int {
+	if a.Position != b.Position {
+		return a.Position - b.Position
+	}
+	switch {
+	case a.ID < b.ID:
+		return 1
+	case a.ID > b.ID:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// NewRoleHierarchy returns a new RoleHierarchy of a guild with the given owner & roles.
+func NewRoleHierarchy(guildID snowflake.ID, ownerID snowflake.ID, roles []Role) RoleHierarchy {
+	return RoleHierarchy{
+		GuildID: guildID,
+		OwnerID: ownerID,
+		Roles:   roles,
+	}
+}
+
+// RoleHierarchy answers whether a member can moderate another member or assign a role.
+// The checks mirror the ones discord does, so moderation commands can fail early instead of receiving a 50013 Missing Permissions error.
+type RoleHierarchy struct {
+	GuildID snowflake.ID
+	OwnerID snowflake.ID
+	Roles   []Role
+}
+
+// Role returns the role with the given ID and a bool indicating if it exists.
+func (h RoleHierarchy) Role(roleID snowflake.ID) (Role, bool) {
+	for _, role := range h.Roles {
+		if role.ID == roleID {
+			return role, true
+		}
+	}
+	return Role{}, false
+}
+
+// SortedRoles returns the roles from the highest to the lowest.
+func (h RoleHierarchy) SortedRoles() []Role {
+	roles := slices.Clone(h.Roles)
+	slices.SortFunc(roles, func(a, b Role) int {
+		return CompareRoles(b, a)
+	})
+	return roles
+}
+
+// HighestRole returns the highest role of the member. Members without roles have the @everyone role.
+func (h RoleHierarchy) HighestRole(member Member) (Role, bool) {
+	var (
+		highest Role
+		found   bool
+	)
+	for _, role := range h.Roles {
+		if role.ID != h.GuildID && !slices.Contains(member.RoleIDs, role.ID) {
+			continue
+		}
+		if !found || CompareRoles(role, highest) > 0 {
+			highest = role
+			found = true
+		}
+	}
+	return highest, found
+}
+
+// CompareMembers compares the position of two members in the hierarchy. The guild owner is above everyone, others are compared by their highest role.
+func (h RoleHierarchy) CompareMembers(a Member, b Member) int {
+	aOwner, bOwner := a.User.ID == h.OwnerID, b.User.ID == h.OwnerID
+	switch {
+	case aOwner && bOwner:
+		return 0
+	case aOwner:
+		return 1
+	case bOwner:
+		return -1
+	}
+	aRole, _ := h.HighestRole(a)
+	bRole, _ := h.HighestRole(b)
+	return CompareRoles(aRole, bRole)
+}
+
+// Permissions returns the guild permissions of the member. See CalculatePermissions.
+func (h RoleHierarchy) Permissions(member Member) Permissions {
+	return CalculatePermissions(PermissionInput{
+		GuildID: h.GuildID,
+		OwnerID: h.OwnerID,
+		Roles:   h.Roles,
+		Member:  member,
+	}).Permissions
+}
+
+// CanKick returns a ModerationError if the actor can't kick the target.
+func (h RoleHierarchy) CanKick(actor Member, target Member) error {
+	return h.checkMember(actor, target, PermissionKickMembers)
+}
+
+// CanBan returns a ModerationError if the actor can't ban the target.
+func (h RoleHierarchy) CanBan(actor Member, target Member) error {
+	return h.checkMember(actor, target, PermissionBanMembers)
+}
+
+// CanTimeout returns a ModerationError if the actor can't time out the target. Administrators can't be timed out.
+func (h RoleHierarchy) CanTimeout(actor Member, target Member) error {
+	if err := h.checkMember(actor, target, PermissionModerateMembers); err != nil {
+		return err
+	}
+	if h.Permissions(target).Has(PermissionAdministrator) {
+		return &ModerationError{Reason: ErrTargetIsAdministrator}
+	}
+	return nil
+}
+
+// CanEditNickname returns a ModerationError if the actor can't change the nickname of the target.
+// Members can change their own nickname with PermissionChangeNickname, except the owner who can always.
+func (h RoleHierarchy) CanEditNickname(actor Member, target Member) error {
+	if actor.User.ID == target.User.ID {
+		if actor.User.ID == h.OwnerID || h.Permissions(actor).Has(PermissionChangeNickname) {
+			return nil
+		}
+		return &ModerationError{Reason: ErrMissingPermission, Permission: PermissionChangeNickname}
+	}
+	return h.checkMember(actor, target, PermissionManageNicknames)
+}
+
+// CanAssignRole returns a ModerationError if the actor can't add the role to or remove it from members.
+// Roles which are not part of the RoleHierarchy return ErrUnknownRole, as their position can't be compared.
+func (h RoleHierarchy) CanAssignRole(actor Member, roleID snowflake.ID) error {
+	if roleID == h.GuildID {
+		return &ModerationError{Reason: ErrRoleEveryone, RoleID: roleID}
+	}
+	role, ok := h.Role(roleID)
+	if !ok {
+		return &ModerationError{Reason: ErrUnknownRole, RoleID: roleID}
+	}
+	if role.Managed {
+		return &ModerationError{Reason: ErrRoleManaged, RoleID: roleID}
+	}
+	if actor.User.ID == h.OwnerID {
+		return nil
+	}
+	if permissions := h.Permissions(actor); permissions.Missing(PermissionManageRoles) {
+		return &ModerationError{Reason: ErrMissingPermission, Permission: PermissionManageRoles}
+	}
+	if highest, _ := h.HighestRole(actor); CompareRoles(role, highest) >= 0 {
+		return &ModerationError{Reason: ErrRoleNotLower, RoleID: roleID}
+	}
+	return nil
+}
+
+func (h RoleHierarchy) checkMember(actor Member, target Member, permission Permissions) error {
+	if actor.User.ID == target.User.ID {
+		return &ModerationError{Reason: ErrCannotModerateSelf}
+	}
+	if target.User.ID == h.OwnerID {
+		return &ModerationError{Reason: ErrTargetIsOwner}
+	}
+	if actor.User.ID == h.OwnerID {
+		return nil
+	}
+	if h.Permissions(actor).Missing(permission) {
+		return &ModerationError{Reason: ErrMissingPermission, Permission: permission}
+	}
+	if h.CompareMembers(actor, target) <= 0 {
+		targetRole, _ := h.HighestRole(target)
+		return &ModerationError{Reason: ErrTargetRoleNotLower, RoleID: targetRole.ID}
+	}
+	return nil
+}
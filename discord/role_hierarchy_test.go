@@ -0,0 +1,50 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleHierarchy(t *testing.T) {
+	const (
+		guildID    = 1
+		modRole    = 2
+		userRole   = 3
+		botRole    = 4
+		ownerID    = 10
+		modID      = 11
+		userID     = 12
+		otherModID = 13
+	)
+	h := NewRoleHierarchy(guildID, ownerID, []Role{
+		{ID: guildID, Position: 0},
+		{ID: modRole, Position: 2, Permissions: PermissionKickMembers | PermissionManageRoles | PermissionModerateMembers},
+		{ID: userRole, Position: 1},
+		{ID: botRole, Position: 1, Managed: true},
+	})
+	owner := Member{User: User{ID: ownerID}}
+	mod := Member{User: User{ID: modID}, RoleIDs: []snowflake.ID{modRole}}
+	otherMod := Member{User: User{ID: otherModID}, RoleIDs: []snowflake.ID{modRole}}
+	user := Member{User: User{ID: userID}, RoleIDs: []snowflake.ID{userRole}}
+
+	assert.Positive(t, CompareRoles(Role{ID: 5, Position: 1}, Role{ID: 6, Position: 1}), "older role is higher")
+	assert.Positive(t, h.CompareMembers(owner, mod))
+	assert.Positive(t, h.CompareMembers(mod, user))
+	assert.Zero(t, h.CompareMembers(mod, otherMod))
+
+	assert.NoError(t, h.CanKick(mod, user))
+	assert.ErrorIs(t, h.CanKick(mod, otherMod), ErrTargetRoleNotLower)
+	assert.ErrorIs(t, h.CanKick(mod, owner), ErrTargetIsOwner)
+	assert.ErrorIs(t, h.CanKick(user, mod), ErrMissingPermission)
+	assert.ErrorIs(t, h.CanBan(mod, user), ErrMissingPermission)
+	assert.NoError(t, h.CanBan(owner, mod))
+
+	assert.NoError(t, h.CanAssignRole(mod, userRole))
+	assert.ErrorIs(t, h.CanAssignRole(mod, modRole), ErrRoleNotLower)
+	assert.ErrorIs(t, h.CanAssignRole(mod, botRole), ErrRoleManaged)
+	assert.ErrorIs(t, h.CanAssignRole(owner, guildID), ErrRoleEveryone)
+	assert.ErrorIs(t, h.CanAssignRole(owner, 99), ErrUnknownRole, "unknown roles can't be compared, even for the owner")
+	assert.ErrorIs(t, h.CanAssignRole(mod, 99), ErrUnknownRole)
+}
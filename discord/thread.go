@@ -22,7 +22,7 @@ type ThreadChannelPostCreate struct {
 func (c ThreadChannelPostCreate) ToBody() (any, error) {
 	if len(c.Message.Files) > 0 {
 		c.Message.Attachments = parseAttachments(c.Message.Files)
-		return PayloadWithFiles(c, c.Message.Files...)
+		return NewMultipartBody(c, c.Message.Files...)
 	}
 	return c, nil
 }
@@ -0,0 +1,283 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of message payloads enforced by Discord. See Validator.
+const (
+	MessageContentMaxLength  = 2000
+	MessageMaxEmbeds         = 10
+	MessageMaxActionRows     = 5
+	MessageMaxStickers       = 3
+	MessageMaxFiles          = 10
+	WebhookUsernameMaxLength = 80
+	ThreadNameMaxLength      = 100
+
+	EmbedTotalMaxLength       = 6000
+	EmbedTitleMaxLength       = 256
+	EmbedDescriptionMaxLength = 4096
+	EmbedMaxFields            = 25
+	EmbedFieldNameMaxLength   = 256
+	EmbedFieldValueMaxLength  = 1024
+	EmbedFooterTextMaxLength  = 2048
+	EmbedAuthorNameMaxLength  = 256
+
+	ActionRowMaxComponents           = 5
+	ComponentCustomIDMaxLength       = 100
+	ButtonLabelMaxLength             = 80
+	SelectMenuPlaceholderMaxLength   = 150
+	SelectMenuMaxOptions             = 25
+	SelectMenuMaxValues              = 25
+	SelectMenuOptionLabelMaxLength   = 100
+	SelectMenuOptionValueMaxLength   = 100
+	SelectMenuOptionDescMaxLength    = 100
+	SelectMenuMaxDefaultValues       = 25
+	AutocompleteMaxChoices           = 25
+	AutocompleteChoiceNameMaxLength  = 100
+	AutocompleteChoiceValueMaxLength = 100
+)
+
+// Validator is implemented by payloads which can be checked against the limits of Discord before sending them.
+// Validate returns ValidationErrors if the payload would be rejected.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError describes a single invalid field of a payload.
+type ValidationError struct {
+	// Path is the json path of the invalid field, for example embeds[0].fields[3].name
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors is returned by Validator.Validate and holds all invalid fields of a payload.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "invalid payload: " + strings.Join(messages, ", ")
+}
+
+// validator collects the ValidationError(s) of a payload.
+type validator struct {
+	errs      ValidationErrors
+	customIDs map[string]struct{}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validator) addf(path string, format string, a ...any) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+// maxLength checks the length of s in characters and returns it.
+func (v *validator) maxLength(path string, s string, limit int) int {
+	length := utf8.RuneCountInString(s)
+	if length > limit {
+		v.addf(path, "must be at most %d characters long, got %d", limit, length)
+	}
+	return length
+}
+
+func (v *validator) maxItems(path string, n int, limit int) {
+	if n > limit {
+		v.addf(path, "must have at most %d items, got %d", limit, n)
+	}
+}
+
+func joinPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	if field == "" {
+		return path
+	}
+	return path + "." + field
+}
+
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+func (v *validator) embeds(path string, embeds []Embed) {
+	v.maxItems(path, len(embeds), MessageMaxEmbeds)
+	var total int
+	for i, embed := range embeds {
+		total += v.embed(indexPath(path, i), embed)
+	}
+	if total > EmbedTotalMaxLength {
+		v.addf(path, "all embeds must have at most %d characters combined, got %d", EmbedTotalMaxLength, total)
+	}
+}
+
+// embed validates the Embed and returns the number of characters counting towards EmbedTotalMaxLength.
+func (v *validator) embed(path string, embed Embed) int {
+	total := v.maxLength(joinPath(path, "title"), embed.Title, EmbedTitleMaxLength)
+	total += v.maxLength(joinPath(path, "description"), embed.Description, EmbedDescriptionMaxLength)
+	if embed.Footer != nil {
+		total += v.maxLength(joinPath(path, "footer.text"), embed.Footer.Text, EmbedFooterTextMaxLength)
+	}
+	if embed.Author != nil {
+		total += v.maxLength(joinPath(path, "author.name"), embed.Author.Name, EmbedAuthorNameMaxLength)
+	}
+	fieldsPath := joinPath(path, "fields")
+	v.maxItems(fieldsPath, len(embed.Fields), EmbedMaxFields)
+	for i, field := range embed.Fields {
+		fieldPath := indexPath(fieldsPath, i)
+		if field.Name == "" {
+			v.addf(joinPath(fieldPath, "name"), "must not be empty")
+		}
+		if field.Value == "" {
+			v.addf(joinPath(fieldPath, "value"), "must not be empty")
+		}
+		total += v.maxLength(joinPath(fieldPath, "name"), field.Name, EmbedFieldNameMaxLength)
+		total += v.maxLength(joinPath(fieldPath, "value"), field.Value, EmbedFieldValueMaxLength)
+	}
+	return total
+}
+
+func (v *validator) components(path string, components []ContainerComponent) {
+	v.maxItems(path, len(components), MessageMaxActionRows)
+	for i, container := range components {
+		rowPath := indexPath(path, i)
+		row := container.Components()
+		if len(row) == 0 {
+			v.addf(rowPath, "must have at least one component")
+			continue
+		}
+		v.maxItems(joinPath(rowPath, "components"), len(row), ActionRowMaxComponents)
+		for j, component := range row {
+			componentPath := indexPath(joinPath(rowPath, "components"), j)
+			if _, ok := component.(SelectMenuComponent); ok && len(row) > 1 {
+				v.addf(componentPath, "select menus must be the only component in an action row")
+			}
+			v.component(componentPath, component)
+		}
+	}
+}
+
+func (v *validator) customID(path string, customID string) {
+	if customID == "" {
+		v.addf(path, "must not be empty")
+		return
+	}
+	v.maxLength(path, customID, ComponentCustomIDMaxLength)
+	if v.customIDs == nil {
+		v.customIDs = map[string]struct{}{}
+	}
+	if _, ok := v.customIDs[customID]; ok {
+		v.addf(path, "duplicate custom id %q", customID)
+	}
+	v.customIDs[customID] = struct{}{}
+}
+
+func (v *validator) selectMenu(path string, placeholder string, minValues *int, maxValues int, defaultValues int) {
+	v.maxLength(joinPath(path, "placeholder"), placeholder, SelectMenuPlaceholderMaxLength)
+	if minValues != nil && (*minValues < 0 || *minValues > SelectMenuMaxValues) {
+		v.addf(joinPath(path, "min_values"), "must be between 0 and %d, got %d", SelectMenuMaxValues, *minValues)
+	}
+	if maxValues < 0 || maxValues > SelectMenuMaxValues {
+		v.addf(joinPath(path, "max_values"), "must be between 1 and %d, got %d", SelectMenuMaxValues, maxValues)
+	}
+	if minValues != nil && maxValues > 0 && *minValues > maxValues {
+		v.addf(joinPath(path, "min_values"), "must not be greater than max_values")
+	}
+	v.maxItems(joinPath(path, "default_values"), defaultValues, SelectMenuMaxDefaultValues)
+}
+
+func (v *validator) component(path string, component InteractiveComponent) {
+	switch c := component.(type) {
+	case ButtonComponent:
+		v.maxLength(joinPath(path, "label"), c.Label, ButtonLabelMaxLength)
+		if c.Label == "" && c.Emoji == nil {
+			v.addf(path, "buttons must have a label or an emoji")
+		}
+		if c.Style == ButtonStyleLink {
+			if c.URL == "" {
+				v.addf(joinPath(path, "url"), "link buttons must have an url")
+			}
+			if c.CustomID != "" {
+				v.addf(joinPath(path, "custom_id"), "link buttons must not have a custom id")
+			}
+			return
+		}
+		if c.URL != "" {
+			v.addf(joinPath(path, "url"), "only link buttons can have an url")
+		}
+		v.customID(joinPath(path, "custom_id"), c.CustomID)
+
+	case StringSelectMenuComponent:
+		v.customID(joinPath(path, "custom_id"), c.CustomID)
+		v.selectMenu(path, c.Placeholder, c.MinValues, c.MaxValues, 0)
+		optionsPath := joinPath(path, "options")
+		if len(c.Options) == 0 {
+			v.addf(optionsPath, "must have at least one option")
+		}
+		v.maxItems(optionsPath, len(c.Options), SelectMenuMaxOptions)
+		if c.MaxValues > len(c.Options) {
+			v.addf(joinPath(path, "max_values"), "must not be greater than the number of options")
+		}
+		values := make(map[string]struct{}, len(c.Options))
+		for i, option := range c.Options {
+			optionPath := indexPath(optionsPath, i)
+			v.maxLength(joinPath(optionPath, "label"), option.Label, SelectMenuOptionLabelMaxLength)
+			v.maxLength(joinPath(optionPath, "value"), option.Value, SelectMenuOptionValueMaxLength)
+			v.maxLength(joinPath(optionPath, "description"), option.Description, SelectMenuOptionDescMaxLength)
+			if _, ok := values[option.Value]; ok {
+				v.addf(joinPath(optionPath, "value"), "duplicate option value %q", option.Value)
+			}
+			values[option.Value] = struct{}{}
+		}
+
+	case UserSelectMenuComponent:
+		v.customID(joinPath(path, "custom_id"), c.CustomID)
+		v.selectMenu(path, c.Placeholder, c.MinValues, c.MaxValues, len(c.DefaultValues))
+
+	case RoleSelectMenuComponent:
+		v.customID(joinPath(path, "custom_id"), c.CustomID)
+		v.selectMenu(path, c.Placeholder, c.MinValues, c.MaxValues, len(c.DefaultValues))
+
+	case MentionableSelectMenuComponent:
+		v.customID(joinPath(path, "custom_id"), c.CustomID)
+		v.selectMenu(path, c.Placeholder, c.MinValues, c.MaxValues, len(c.DefaultValues))
+
+	case ChannelSelectMenuComponent:
+		v.customID(joinPath(path, "custom_id"), c.CustomID)
+		v.selectMenu(path, c.Placeholder, c.MinValues, c.MaxValues, len(c.DefaultValues))
+
+	default:
+		v.customID(joinPath(path, "custom_id"), component.ID())
+	}
+}
+
+// prefixValidationErrors prepends the given path to all ValidationError(s) of err.
+func prefixValidationErrors(path string, err error) error {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	prefixed := make(ValidationErrors, len(errs))
+	for i, e := range errs {
+		prefixed[i] = ValidationError{Path: joinPath(path, e.Path), Message: e.Message}
+	}
+	return prefixed
+}
@@ -0,0 +1,58 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageCreateValidate(t *testing.T) {
+	assert.NoError(t, NewMessageCreateBuilder().
+		SetContent("hello").
+		AddEmbeds(NewEmbedBuilder().SetTitle("title").AddField("name", "value", false).Build()).
+		AddActionRow(NewPrimaryButton("click", "button")).
+		Validate())
+
+	message := NewMessageCreateBuilder().
+		SetContent(strings.Repeat("a", MessageContentMaxLength+1)).
+		AddEmbeds(
+			NewEmbedBuilder().SetDescription(strings.Repeat("a", EmbedDescriptionMaxLength)).Build(),
+			NewEmbedBuilder().SetDescription(strings.Repeat("a", EmbedDescriptionMaxLength)).AddField("", "value", false).Build(),
+		).
+		AddActionRow(NewPrimaryButton("one", "button"), NewSecondaryButton("two", "button"))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, message.Validate(), &errs)
+	assert.Equal(t, ValidationErrors{
+		{Path: "content", Message: "must be at most 2000 characters long, got 2001"},
+		{Path: "embeds[1].fields[0].name", Message: "must not be empty"},
+		{Path: "embeds", Message: "all embeds must have at most 6000 characters combined, got 8197"},
+		{Path: "components[0].components[1].custom_id", Message: `duplicate custom id "button"`},
+	}, errs)
+
+	assert.NoError(t, MessageCreate{Components: []ContainerComponent{NewActionRow(NewPrimaryButton("click", "button"))}}.Validate(), "components are enough content")
+}
+
+func TestInteractionResponseValidate(t *testing.T) {
+	var errs ValidationErrors
+	err := InteractionResponse{Type: InteractionResponseTypeCreateMessage, Data: MessageCreate{}}.Validate()
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, ValidationErrors{{Path: "data", Message: "message must have content, embeds, components, stickers or files"}}, errs)
+
+	// an ephemeral defer only sets the flags of the message
+	assert.NoError(t, InteractionResponse{Type: InteractionResponseTypeDeferredCreateMessage, Data: MessageCreate{Flags: MessageFlagEphemeral}}.Validate())
+
+	err = InteractionResponse{Type: InteractionResponseTypeDeferredCreateMessage, Data: MessageCreate{Content: strings.Repeat("a", MessageContentMaxLength+1)}}.Validate()
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, "data.content", errs[0].Path, "the limits are still checked for deferred responses")
+}
+
+func TestWebhookMessageCreateValidate(t *testing.T) {
+	var errs ValidationErrors
+	require.ErrorAs(t, WebhookMessageCreate{Flags: MessageFlagSuppressNotifications}.Validate(), &errs)
+	assert.Equal(t, ValidationErrors{{Path: "", Message: "message must have content, embeds, components or files"}}, errs)
+
+	assert.NoError(t, WebhookMessageCreate{Components: []ContainerComponent{NewActionRow(NewPrimaryButton("click", "button"))}}.Validate())
+}
@@ -17,11 +17,26 @@ type WebhookMessageCreate struct {
 	AppliedTags     []snowflake.ID       `json:"applied_tags,omitempty"`
 }
 
+// Validate checks the WebhookMessageCreate against the limits of Discord and returns ValidationErrors with all invalid fields.
+func (m WebhookMessageCreate) Validate() error {
+	var v validator
+	v.maxLength("content", m.Content, MessageContentMaxLength)
+	v.maxLength("username", m.Username, WebhookUsernameMaxLength)
+	v.maxLength("thread_name", m.ThreadName, ThreadNameMaxLength)
+	v.embeds("embeds", m.Embeds)
+	v.components("components", m.Components)
+	v.maxItems("attachments", max(len(m.Files), len(m.Attachments)), MessageMaxFiles)
+	if m.Content == "" && len(m.Embeds) == 0 && len(m.Components) == 0 && len(m.Files) == 0 && len(m.Attachments) == 0 {
+		v.addf("", "message must have content, embeds, components or files")
+	}
+	return v.err()
+}
+
 // ToBody returns the MessageCreate ready for body
 func (m WebhookMessageCreate) ToBody() (any, error) {
 	if len(m.Files) > 0 {
 		m.Attachments = parseAttachments(m.Files)
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartBody(m, m.Files...)
 	}
 	return m, nil
 }
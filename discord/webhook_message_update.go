@@ -10,6 +10,26 @@ type WebhookMessageUpdate struct {
 	AllowedMentions *AllowedMentions      `json:"allowed_mentions,omitempty"`
 }
 
+// Validate checks the WebhookMessageUpdate against the limits of Discord and returns ValidationErrors with all invalid fields.
+func (m WebhookMessageUpdate) Validate() error {
+	var v validator
+	if m.Content != nil {
+		v.maxLength("content", *m.Content, MessageContentMaxLength)
+	}
+	if m.Embeds != nil {
+		v.embeds("embeds", *m.Embeds)
+	}
+	if m.Components != nil {
+		v.components("components", *m.Components)
+	}
+	files := len(m.Files)
+	if m.Attachments != nil {
+		files += len(*m.Attachments)
+	}
+	v.maxItems("attachments", files, MessageMaxFiles)
+	return v.err()
+}
+
 // ToBody returns the WebhookMessageUpdate ready for body
 func (m WebhookMessageUpdate) ToBody() (any, error) {
 	if len(m.Files) > 0 {
@@ -19,7 +39,7 @@ func (m WebhookMessageUpdate) ToBody() (any, error) {
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartBody(m, m.Files...)
 	}
 	return m, nil
 }
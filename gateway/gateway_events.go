@@ -52,6 +52,8 @@ func (EventApplicationCommandPermissionsUpdate) eventData()   {}
 
 type EventChannelCreate struct {
 	discord.GuildChannel
+	// PrivateChannel is set instead of GuildChannel if the channel is a discord.DMChannel or discord.GroupDMChannel.
+	PrivateChannel discord.PrivateChannel
 }
 
 func (e *EventChannelCreate) UnmarshalJSON(data []byte) error {
@@ -59,15 +61,29 @@ func (e *EventChannelCreate) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
 	}
-	e.GuildChannel = v.Channel.(discord.GuildChannel)
+	switch c := v.Channel.(type) {
+	case discord.GuildChannel:
+		e.GuildChannel = c
+	case discord.PrivateChannel:
+		e.PrivateChannel = c
+	}
 	return nil
 }
 
+func (e EventChannelCreate) MarshalJSON() ([]byte, error) {
+	if e.PrivateChannel != nil {
+		return json.Marshal(e.PrivateChannel)
+	}
+	return json.Marshal(e.GuildChannel)
+}
+
 func (EventChannelCreate) messageData() {}
 func (EventChannelCreate) eventData()   {}
 
 type EventChannelUpdate struct {
 	discord.GuildChannel
+	// PrivateChannel is set instead of GuildChannel if the channel is a discord.DMChannel or discord.GroupDMChannel.
+	PrivateChannel discord.PrivateChannel
 }
 
 func (e *EventChannelUpdate) UnmarshalJSON(data []byte) error {
@@ -75,15 +91,29 @@ func (e *EventChannelUpdate) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
 	}
-	e.GuildChannel = v.Channel.(discord.GuildChannel)
+	switch c := v.Channel.(type) {
+	case discord.GuildChannel:
+		e.GuildChannel = c
+	case discord.PrivateChannel:
+		e.PrivateChannel = c
+	}
 	return nil
 }
 
+func (e EventChannelUpdate) MarshalJSON() ([]byte, error) {
+	if e.PrivateChannel != nil {
+		return json.Marshal(e.PrivateChannel)
+	}
+	return json.Marshal(e.GuildChannel)
+}
+
 func (EventChannelUpdate) messageData() {}
 func (EventChannelUpdate) eventData()   {}
 
 type EventChannelDelete struct {
 	discord.GuildChannel
+	// PrivateChannel is set instead of GuildChannel if the channel is a discord.DMChannel or discord.GroupDMChannel.
+	PrivateChannel discord.PrivateChannel
 }
 
 func (e *EventChannelDelete) UnmarshalJSON(data []byte) error {
@@ -91,10 +121,22 @@ func (e *EventChannelDelete) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
 	}
-	e.GuildChannel = v.Channel.(discord.GuildChannel)
+	switch c := v.Channel.(type) {
+	case discord.GuildChannel:
+		e.GuildChannel = c
+	case discord.PrivateChannel:
+		e.PrivateChannel = c
+	}
 	return nil
 }
 
+func (e EventChannelDelete) MarshalJSON() ([]byte, error) {
+	if e.PrivateChannel != nil {
+		return json.Marshal(e.PrivateChannel)
+	}
+	return json.Marshal(e.GuildChannel)
+}
+
 func (EventChannelDelete) messageData() {}
 func (EventChannelDelete) eventData()   {}
 
@@ -461,6 +503,24 @@ func (EventInteractionCreate) eventData()   {}
 
 type EventInviteCreate struct {
 	discord.Invite
+	GuildID   *snowflake.ID `json:"guild_id"`
+	Uses      int           `json:"uses"`
+	MaxUses   int           `json:"max_uses"`
+	MaxAge    int           `json:"max_age"`
+	Temporary bool          `json:"temporary"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ExtendedInvite returns the discord.ExtendedInvite including the metadata sent with the event.
+func (e EventInviteCreate) ExtendedInvite() discord.ExtendedInvite {
+	return discord.ExtendedInvite{
+		Invite:    e.Invite,
+		Uses:      e.Uses,
+		MaxUses:   e.MaxUses,
+		MaxAge:    e.MaxAge,
+		Temporary: e.Temporary,
+		CreatedAt: e.CreatedAt,
+	}
 }
 
 func (EventInviteCreate) messageData() {}
@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+const (
+	testDMChannel      = `{"id":"1","type":1,"last_message_id":"2","recipients":[{"id":"3","username":"user"}]}`
+	testGroupDMChannel = `{"id":"4","type":3,"name":"group","owner_id":"3","recipients":[{"id":"3","username":"user"},{"id":"5","username":"other"}]}`
+	testGuildChannel   = `{"id":"6","type":0,"guild_id":"7","name":"general"}`
+)
+
+// channelEvent is implemented by EventChannelCreate, EventChannelUpdate & EventChannelDelete.
+type channelEvent interface {
+	json.Unmarshaler
+	json.Marshaler
+}
+
+func channelEvents(t *testing.T, data string) map[string]channelEvent {
+	t.Helper()
+	events := map[string]channelEvent{
+		"create": &EventChannelCreate{},
+		"update": &EventChannelUpdate{},
+		"delete": &EventChannelDelete{},
+	}
+	for name, event := range events {
+		require.NoError(t, json.Unmarshal([]byte(data), event), name)
+	}
+	return events
+}
+
+func privateAndGuildChannel(event channelEvent) (discord.PrivateChannel, discord.GuildChannel) {
+	switch e := event.(type) {
+	case *EventChannelCreate:
+		return e.PrivateChannel, e.GuildChannel
+	case *EventChannelUpdate:
+		return e.PrivateChannel, e.GuildChannel
+	case *EventChannelDelete:
+		return e.PrivateChannel, e.GuildChannel
+	}
+	return nil, nil
+}
+
+func TestChannelEventsDMChannel(t *testing.T) {
+	for name, event := range channelEvents(t, testDMChannel) {
+		privateChannel, guildChannel := privateAndGuildChannel(event)
+		assert.Nil(t, guildChannel, name)
+		require.IsType(t, discord.DMChannel{}, privateChannel, name)
+		assert.Equal(t, "3", privateChannel.Recipients()[0].ID.String(), name)
+
+		data, err := event.MarshalJSON()
+		require.NoError(t, err, name)
+		decoded, _ := privateAndGuildChannel(channelEvents(t, string(data))[name])
+		assert.Equal(t, privateChannel, decoded, name)
+	}
+}
+
+func TestChannelEventsGroupDMChannel(t *testing.T) {
+	for name, event := range channelEvents(t, testGroupDMChannel) {
+		privateChannel, guildChannel := privateAndGuildChannel(event)
+		assert.Nil(t, guildChannel, name)
+		require.IsType(t, discord.GroupDMChannel{}, privateChannel, name)
+		assert.Equal(t, "group", privateChannel.Name(), name)
+		assert.Len(t, privateChannel.Recipients(), 2, name)
+	}
+}
+
+func TestChannelEventsGuildChannel(t *testing.T) {
+	for name, event := range channelEvents(t, testGuildChannel) {
+		privateChannel, guildChannel := privateAndGuildChannel(event)
+		assert.Nil(t, privateChannel, name)
+		require.IsType(t, discord.GuildTextChannel{}, guildChannel, name)
+		assert.Equal(t, "7", guildChannel.GuildID().String(), name)
+	}
+}
+
+func TestEventInviteCreate(t *testing.T) {
+	var event EventInviteCreate
+	require.NoError(t, json.Unmarshal([]byte(`{"code":"abc","channel_id":"1","guild_id":"2","uses":3,"max_uses":10,"max_age":60,"temporary":true,"created_at":"2024-01-02T03:04:05Z"}`), &event))
+
+	assert.Equal(t, "abc", event.Invite.Code)
+	require.NotNil(t, event.GuildID)
+	assert.Equal(t, "2", event.GuildID.String())
+
+	invite := event.ExtendedInvite()
+	assert.Equal(t, event.Invite, invite.Invite)
+	assert.Equal(t, 3, invite.Uses)
+	assert.Equal(t, 10, invite.MaxUses)
+	assert.Equal(t, 60, invite.MaxAge)
+	assert.True(t, invite.Temporary)
+	assert.Equal(t, 2024, invite.CreatedAt.Year())
+}
@@ -42,12 +42,14 @@ type gatewayImpl struct {
 	closeHandlerFunc CloseHandlerFunc
 	token            string
 
-	conn          *websocket.Conn
-	connMu        sync.Mutex
+	conn   *websocket.Conn
+	connMu sync.Mutex
+	// heartbeatChan is closed to stop the heartbeat goroutine of the current connection. It is guarded by connMu.
 	heartbeatChan chan struct{}
 	status        Status
 
-	heartbeatInterval     time.Duration
+	heartbeatInterval time.Duration
+	// lastHeartbeatSent & lastHeartbeatReceived are guarded by connMu, the heartbeat goroutines & Latency access them concurrently
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
 }
@@ -132,15 +134,18 @@ func (g *gatewayImpl) Close(ctx context.Context) {
 }
 
 func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
-	if g.heartbeatChan != nil {
-		g.config.Logger.Debug("closing heartbeat goroutines...")
-		g.heartbeatChan <- struct{}{}
+	g.connMu.Lock()
+	connected := g.conn != nil
+	g.connMu.Unlock()
+	if connected {
+		// send locks the rate limiter before the connection, so wait for the command in flight before locking the connection
+		g.config.RateLimiter.Close(ctx)
 	}
 
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
+	g.stopHeartbeat()
 	if g.conn != nil {
-		g.config.RateLimiter.Close(ctx)
 		g.config.Logger.Debug("closing gateway connection", slog.Int("code", code), slog.String("message", message))
 		if err := g.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, message)); err != nil && !errors.Is(err, websocket.ErrCloseSent) {
 			g.config.Logger.Debug("error writing close code", slog.String("err", err.Error()))
@@ -171,21 +176,30 @@ func (g *gatewayImpl) Send(ctx context.Context, op Opcode, d MessageData) error
 	if err != nil {
 		return err
 	}
-	return g.send(ctx, websocket.TextMessage, data)
+	return g.send(ctx, op, websocket.TextMessage, data)
 }
 
-func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) error {
+func (g *gatewayImpl) send(ctx context.Context, op Opcode, messageType int, data []byte) error {
 	g.connMu.Lock()
-	defer g.connMu.Unlock()
-	if g.conn == nil {
+	conn := g.conn
+	g.connMu.Unlock()
+	if conn == nil {
 		return discord.ErrShardNotConnected
 	}
 
-	if err := g.config.RateLimiter.Wait(ctx); err != nil {
+	// wait for the rate limiter before locking the connection, so commands with a higher priority can overtake queued ones
+	if err := g.waitRateLimiter(ctx, op); err != nil {
 		return err
 	}
-
 	defer g.config.RateLimiter.Unlock()
+
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	// the command was meant for a connection which was closed or replaced while waiting for the rate limiter
+	if g.conn != conn {
+		return discord.ErrShardNotConnected
+	}
+
 	if g.config.Logger.Enabled(ctx, slog.LevelDebug) {
 		g.config.Logger.Debug("sending gateway command", slog.String("data", string(data)))
 	}
@@ -193,6 +207,8 @@ func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) er
 }
 
 func (g *gatewayImpl) Latency() time.Duration {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
@@ -200,6 +216,13 @@ func (g *gatewayImpl) Presence() *MessageDataPresenceUpdate {
 	return g.config.Presence
 }
 
+func (g *gatewayImpl) waitRateLimiter(ctx context.Context, op Opcode) error {
+	if rateLimiter, ok := g.config.RateLimiter.(PriorityRateLimiter); ok {
+		return rateLimiter.WaitOpcode(ctx, op)
+	}
+	return g.config.RateLimiter.Wait(ctx)
+}
+
 func (g *gatewayImpl) reconnectTry(ctx context.Context, try int) error {
 	delay := time.Duration(try) * 2 * time.Second
 	if delay > 30*time.Second {
@@ -233,29 +256,47 @@ func (g *gatewayImpl) reconnect() {
 	}
 }
 
-func (g *gatewayImpl) heartbeat() {
-	if g.heartbeatChan == nil {
-		g.heartbeatChan = make(chan struct{})
+// startHeartbeat stops the heartbeat goroutine of the previous Hello & starts a new one.
+func (g *gatewayImpl) startHeartbeat() {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	g.stopHeartbeat()
+	g.lastHeartbeatReceived = time.Now().UTC()
+	g.heartbeatChan = make(chan struct{})
+	go g.heartbeat(g.heartbeatChan, g.heartbeatInterval)
+}
+
+// stopHeartbeat stops the running heartbeat goroutine. g.connMu must be held.
+// Closing the channel never blocks, so it is safe to call from the heartbeat goroutine itself.
+func (g *gatewayImpl) stopHeartbeat() {
+	if g.heartbeatChan != nil {
+		g.config.Logger.Debug("closing heartbeat goroutines...")
+		close(g.heartbeatChan)
+		g.heartbeatChan = nil
 	}
-	heartbeatTicker := time.NewTicker(g.heartbeatInterval)
+}
+
+func (g *gatewayImpl) heartbeat(heartbeatChan <-chan struct{}, interval time.Duration) {
+	heartbeatTicker := time.NewTicker(interval)
 	defer heartbeatTicker.Stop()
 	defer g.config.Logger.Debug("exiting heartbeat goroutine")
 
 	for {
 		select {
-		case <-g.heartbeatChan:
+		case <-heartbeatChan:
 			return
 
 		case <-heartbeatTicker.C:
-			g.sendHeartbeat()
+			g.sendHeartbeat(interval)
 		}
 	}
 }
 
-func (g *gatewayImpl) sendHeartbeat() {
+// sendHeartbeat sends a heartbeat which times out after the heartbeat interval of the connection it is sent on.
+func (g *gatewayImpl) sendHeartbeat(interval time.Duration) {
 	g.config.Logger.Debug("sending heartbeat")
 
-	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
 	defer cancel()
 	if err := g.Send(ctx, OpcodeHeartbeat, MessageDataHeartbeat(*g.config.LastSequenceReceived)); err != nil {
 		if errors.Is(err, discord.ErrShardNotConnected) || errors.Is(err, syscall.EPIPE) {
@@ -268,7 +309,9 @@ func (g *gatewayImpl) sendHeartbeat() {
 		go g.reconnect()
 		return
 	}
+	g.connMu.Lock()
 	g.lastHeartbeatSent = time.Now().UTC()
+	g.connMu.Unlock()
 }
 
 func (g *gatewayImpl) identify() {
@@ -379,8 +422,7 @@ loop:
 		switch message.Op {
 		case OpcodeHello:
 			g.heartbeatInterval = time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond
-			g.lastHeartbeatReceived = time.Now().UTC()
-			go g.heartbeat()
+			g.startHeartbeat()
 
 			if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
 				g.identify()
@@ -421,7 +463,7 @@ loop:
 			g.eventHandlerFunc(message.T, message.S, g.config.ShardID, eventData)
 
 		case OpcodeHeartbeat:
-			g.sendHeartbeat()
+			g.sendHeartbeat(g.heartbeatInterval)
 
 		case OpcodeReconnect:
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -451,11 +493,14 @@ loop:
 
 		case OpcodeHeartbeatACK:
 			newHeartbeat := time.Now().UTC()
+			g.connMu.Lock()
+			lastHeartbeat := g.lastHeartbeatReceived
+			g.lastHeartbeatReceived = newHeartbeat
+			g.connMu.Unlock()
 			g.eventHandlerFunc(EventTypeHeartbeatAck, message.S, g.config.ShardID, EventHeartbeatAck{
-				LastHeartbeat: g.lastHeartbeatReceived,
+				LastHeartbeat: lastHeartbeat,
 				NewHeartbeat:  newHeartbeat,
 			})
-			g.lastHeartbeatReceived = newHeartbeat
 
 		default:
 
@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer starts a websocket server which passes every connection to handle & returns its URL.
+func newTestServer(t *testing.T, handle func(conn *websocket.Conn)) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}))
+	t.Cleanup(s.Close)
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func writeTestMessage(conn *websocket.Conn, op Opcode, d any) error {
+	data, err := json.Marshal(map[string]any{"op": op, "d": d})
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// readTestOpcodes reads the commands of the connection & passes their Opcode(s) to ops until the connection is closed.
+func readTestOpcodes(conn *websocket.Conn, ops chan<- Opcode) {
+	for {
+		var message struct {
+			Op Opcode `json:"op"`
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+		ops <- message.Op
+	}
+}
+
+func waitTestOpcode(t *testing.T, ops <-chan Opcode, op Opcode) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case received := <-ops:
+			if received == op {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for opcode %d", op)
+		}
+	}
+}
+
+// TestGatewayHeartbeatHello checks that a Hello received while the heartbeat goroutine of the previous Hello is running
+// replaces it without a data race. Run it with -race.
+func TestGatewayHeartbeatHello(t *testing.T) {
+	ops := make(chan Opcode, 64)
+	hello := make(chan struct{})
+	url := newTestServer(t, func(conn *websocket.Conn) {
+		if err := writeTestMessage(conn, OpcodeHello, MessageDataHello{HeartbeatInterval: 5}); err != nil {
+			return
+		}
+		go func() {
+			<-hello
+			_ = writeTestMessage(conn, OpcodeHello, MessageDataHello{HeartbeatInterval: 10})
+		}()
+		readTestOpcodes(conn, ops)
+	})
+
+	g := New("token", func(EventType, int, int, EventData) {}, nil, WithURL(url), WithSequence(1), WithAutoReconnect(false))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, g.Open(ctx))
+
+	waitTestOpcode(t, ops, OpcodeHeartbeat)
+	close(hello)
+	waitTestOpcode(t, ops, OpcodeIdentify)
+	waitTestOpcode(t, ops, OpcodeHeartbeat)
+
+	g.Close(ctx)
+	require.NoError(t, ctx.Err())
+}
+
+// blockingRateLimiter holds the lock of a presence update until proceed is closed.
+type blockingRateLimiter struct {
+	*rateLimiterImpl
+	acquired chan struct{}
+	proceed  chan struct{}
+}
+
+func (l *blockingRateLimiter) WaitOpcode(ctx context.Context, op Opcode) error {
+	if err := l.rateLimiterImpl.WaitOpcode(ctx, op); err != nil {
+		return err
+	}
+	if op == OpcodePresenceUpdate {
+		close(l.acquired)
+		<-l.proceed
+	}
+	return nil
+}
+
+func TestGatewayCloseWhileSending(t *testing.T) {
+	ops := make(chan Opcode, 64)
+	url := newTestServer(t, func(conn *websocket.Conn) {
+		if err := writeTestMessage(conn, OpcodeHello, MessageDataHello{HeartbeatInterval: 60000}); err != nil {
+			return
+		}
+		readTestOpcodes(conn, ops)
+	})
+
+	rateLimiter := &blockingRateLimiter{
+		rateLimiterImpl: newTestRateLimiter(),
+		acquired:        make(chan struct{}),
+		proceed:         make(chan struct{}),
+	}
+	g := New("token", func(EventType, int, int, EventData) {}, nil, WithURL(url), WithAutoReconnect(false), WithRateLimiter(rateLimiter))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, g.Open(ctx))
+	waitTestOpcode(t, ops, OpcodeIdentify)
+
+	sendErr := make(chan error, 1)
+	go func() {
+		sendErr <- g.Send(ctx, OpcodePresenceUpdate, MessageDataPresenceUpdate{})
+	}()
+	<-rateLimiter.acquired
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		g.Close(ctx)
+	}()
+	// Close waits for the presence update which holds the rate limiter
+	waitQueued(t, rateLimiter.rateLimiterImpl, 1)
+	close(rateLimiter.proceed)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close deadlocked with the command in flight")
+	}
+	require.NoError(t, <-sendErr)
+	waitTestOpcode(t, ops, OpcodePresenceUpdate)
+}
@@ -2,11 +2,15 @@ package gateway
 
 import (
 	"context"
+	"time"
 )
 
 // CommandsPerMinute is the default number of commands per minute that the Gateway will allow.
 const CommandsPerMinute = 120
 
+// ReservedCommands is the default number of commands per minute which are reserved for PriorityCritical commands.
+const ReservedCommands = 5
+
 // RateLimiter provides handles the rate limiting logic for connecting to Discord's Gateway.
 type RateLimiter interface {
 	// Close gracefully closes the RateLimiter.
@@ -23,3 +27,94 @@ type RateLimiter interface {
 	// Unlock unlocks the RateLimiter and allows the next message to be sent.
 	Unlock()
 }
+
+// PriorityRateLimiter is a RateLimiter which queues messages by the Priority of their Opcode and keeps stats about them.
+// The Gateway uses WaitOpcode instead of RateLimiter.Wait if its RateLimiter implements PriorityRateLimiter.
+//
+// To observe the stats of a Gateway, create the RateLimiter with NewRateLimiter and pass it with WithRateLimiter.
+type PriorityRateLimiter interface {
+	RateLimiter
+
+	// WaitOpcode waits for the RateLimiter to be ready to send a new message with the given Opcode.
+	// Messages are queued by the Priority of their Opcode.
+	// If the context deadline is exceeded, WaitOpcode will return immediately and no message will be sent.
+	WaitOpcode(ctx context.Context, op Opcode) error
+
+	// Stats returns a snapshot of the current state of the RateLimiter.
+	Stats() RateLimiterStats
+}
+
+// Priority decides in which order queued gateway commands are sent.
+// Commands with a higher Priority are always sent before commands with a lower Priority.
+type Priority int
+
+// All Priority(s) from the highest to the lowest.
+const (
+	// PriorityCritical is used for heartbeats & the handshake. These commands can use the reserved budget of the RateLimiter.
+	PriorityCritical Priority = iota
+	// PriorityHigh is used for voice state & presence updates.
+	PriorityHigh
+	// PriorityNormal is used for all other commands.
+	PriorityNormal
+	// PriorityLow is used for bulk commands like requesting guild members.
+	PriorityLow
+)
+
+// Priorities are all Priority(s) from the highest to the lowest.
+var Priorities = []Priority{PriorityCritical, PriorityHigh, PriorityNormal, PriorityLow}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityCritical:
+		return "critical"
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	case PriorityLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultOpcodePriorities are the Priority(s) of the Opcode(s) used by default.
+// Opcode(s) not in this map use PriorityNormal.
+var DefaultOpcodePriorities = map[Opcode]Priority{
+	OpcodeHeartbeat:           PriorityCritical,
+	OpcodeIdentify:            PriorityCritical,
+	OpcodeResume:              PriorityCritical,
+	OpcodeVoiceStateUpdate:    PriorityHigh,
+	OpcodePresenceUpdate:      PriorityHigh,
+	OpcodeRequestGuildMembers: PriorityLow,
+}
+
+// RateLimiterStats is a snapshot of the state of a RateLimiter.
+type RateLimiterStats struct {
+	// Remaining is the number of commands which can still be sent in the current window.
+	Remaining int
+	// Reset is the time the current window ends.
+	Reset time.Time
+	// Lanes holds the stats per Priority.
+	Lanes map[Priority]LaneStats
+}
+
+// LaneStats holds the stats of a single Priority lane of a RateLimiter.
+type LaneStats struct {
+	// Queued is the number of commands currently waiting in the lane.
+	Queued int
+	// Sent is the number of commands which passed the lane.
+	Sent int
+	// TotalWait is the time all sent commands of the lane waited in total.
+	TotalWait time.Duration
+	// MaxWait is the longest time a command of the lane waited.
+	MaxWait time.Duration
+}
+
+// AverageWait returns the average time a command of the lane waited.
+func (s LaneStats) AverageWait() time.Duration {
+	if s.Sent == 0 {
+		return 0
+	}
+	return s.TotalWait / time.Duration(s.Sent)
+}
@@ -9,6 +9,8 @@ func DefaultRateLimiterConfig() *RateLimiterConfig {
 	return &RateLimiterConfig{
 		Logger:            slog.Default(),
 		CommandsPerMinute: CommandsPerMinute,
+		ReservedCommands:  ReservedCommands,
+		OpcodePriorities:  DefaultOpcodePriorities,
 	}
 }
 
@@ -16,6 +18,8 @@ func DefaultRateLimiterConfig() *RateLimiterConfig {
 type RateLimiterConfig struct {
 	Logger            *slog.Logger
 	CommandsPerMinute int
+	ReservedCommands  int
+	OpcodePriorities  map[Opcode]Priority
 }
 
 // RateLimiterConfigOpt is a type alias for a function that takes a RateLimiterConfig and is used to configure your Server.
@@ -41,3 +45,22 @@ func WithCommandsPerMinute(commandsPerMinute int) RateLimiterConfigOpt {
 		config.CommandsPerMinute = commandsPerMinute
 	}
 }
+
+// WithReservedCommands sets the number of commands per minute which can only be used by PriorityCritical commands like heartbeats, identify & resume.
+func WithReservedCommands(reservedCommands int) RateLimiterConfigOpt {
+	return func(config *RateLimiterConfig) {
+		config.ReservedCommands = reservedCommands
+	}
+}
+
+// WithOpcodePriority sets the Priority of the given Opcode.
+func WithOpcodePriority(op Opcode, priority Priority) RateLimiterConfigOpt {
+	return func(config *RateLimiterConfig) {
+		priorities := make(map[Opcode]Priority, len(config.OpcodePriorities)+1)
+		for k, v := range config.OpcodePriorities {
+			priorities[k] = v
+		}
+		priorities[op] = priority
+		config.OpcodePriorities = priorities
+	}
+}
@@ -3,12 +3,15 @@ package gateway
 import (
 	"context"
 	"log/slog"
+	"slices"
+	"sync"
 	"time"
 
-	"github.com/sasha-s/go-csync"
+	"github.com/disgoorg/disgo/discord"
 )
 
 // NewRateLimiter creates a new default RateLimiter with the given RateLimiterConfigOpt(s).
+// The returned RateLimiter implements PriorityRateLimiter.
 func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 	config := DefaultRateLimiterConfig()
 	config.Apply(opts)
@@ -16,59 +19,228 @@ func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 
 	return &rateLimiterImpl{
 		config: *config,
+		lanes:  map[Priority]*lane{},
 	}
 }
 
+var _ PriorityRateLimiter = (*rateLimiterImpl)(nil)
+
 type rateLimiterImpl struct {
-	mu csync.Mutex
+	mu sync.Mutex
+
+	// holder is the waiter which holds the lock, only it can release the lock
+	holder *waiter
+	reset  time.Time
+	used   int
+	timer  *time.Timer
 
-	reset     time.Time
-	remaining int
+	lanes map[Priority]*lane
 
 	config RateLimiterConfig
 }
 
+type lane struct {
+	waiters []*waiter
+	stats   LaneStats
+}
+
+type waiter struct {
+	ch       chan struct{}
+	queuedAt time.Time
+	// closing is true for Close, which keeps the lock until the next Reset
+	closing bool
+	// err is set if the waiter was removed from its lane by Reset
+	err error
+}
+
 func (l *rateLimiterImpl) Close(ctx context.Context) {
-	_ = l.mu.CLock(ctx)
+	_ = l.wait(ctx, PriorityCritical, true)
 }
 
+// Reset releases the lock held by Close & fails all queued commands with discord.ErrShardNotConnected,
+// as they must not be sent on the new connection before it identified or resumed.
+// A command which is still being sent keeps the lock until it unlocks it.
 func (l *rateLimiterImpl) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.reset = time.Time{}
-	l.remaining = 0
-	l.mu = csync.Mutex{}
+	l.used = 0
+	if l.holder != nil && l.holder.closing {
+		l.holder = nil
+	}
+	for _, ln := range l.lanes {
+		for _, w := range ln.waiters {
+			w.err = discord.ErrShardNotConnected
+			close(w.ch)
+		}
+		ln.waiters = nil
+	}
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
 }
 
 func (l *rateLimiterImpl) Wait(ctx context.Context) error {
-	l.config.Logger.Debug("locking gateway rate limiter")
-	if err := l.mu.CLock(ctx); err != nil {
-		return err
-	}
+	return l.wait(ctx, PriorityNormal, false)
+}
 
-	now := time.Now()
+func (l *rateLimiterImpl) WaitOpcode(ctx context.Context, op Opcode) error {
+	return l.wait(ctx, l.priority(op), false)
+}
+
+// wait waits for the lock in the lane of the given Priority. closing is true for Close, which keeps the lock until the next Reset.
+func (l *rateLimiterImpl) wait(ctx context.Context, priority Priority, closing bool) error {
+	l.config.Logger.Debug("locking gateway rate limiter", slog.String("priority", priority.String()))
 
-	var until time.Time
+	w := &waiter{
+		ch:       make(chan struct{}),
+		queuedAt: time.Now(),
+		closing:  closing,
+	}
 
-	if l.remaining == 0 && l.reset.After(now) {
-		until = l.reset
+	l.mu.Lock()
+	if closing && l.holder != nil && l.holder.closing {
+		// the RateLimiter is already closed
+		l.mu.Unlock()
+		return nil
 	}
+	ln := l.lane(priority)
+	ln.waiters = append(ln.waiters, w)
+	l.next()
+	l.mu.Unlock()
 
-	if until.After(now) {
-		select {
-		case <-ctx.Done():
-			l.Unlock()
-			return ctx.Err()
-		case <-time.After(until.Sub(now)):
+	select {
+	case <-w.ch:
+		return w.err
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.holder == w {
+			// we got the lock while the context was cancelled, pass it on
+			l.release()
+		} else if i := slices.Index(ln.waiters, w); i >= 0 {
+			ln.waiters = slices.Delete(ln.waiters, i, i+1)
 		}
+		return ctx.Err()
 	}
-	return nil
 }
 
 func (l *rateLimiterImpl) Unlock() {
 	l.config.Logger.Debug("unlocking gateway rate limiter")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == nil || l.holder.closing {
+		// nobody but Close holds the lock, which is only released by Reset
+		l.config.Logger.Debug("gateway rate limiter unlocked without holding the lock")
+		return
+	}
+	l.release()
+}
+
+// release releases the lock of the current holder. l.mu must be held.
+func (l *rateLimiterImpl) release() {
+	l.holder = nil
+	l.next()
+}
+
+func (l *rateLimiterImpl) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := RateLimiterStats{
+		Remaining: l.config.CommandsPerMinute,
+		Lanes:     make(map[Priority]LaneStats, len(Priorities)),
+	}
+	if l.reset.After(time.Now()) {
+		stats.Remaining -= l.used
+		stats.Reset = l.reset
+	}
+	for _, priority := range Priorities {
+		var laneStats LaneStats
+		if ln, ok := l.lanes[priority]; ok {
+			laneStats = ln.stats
+			laneStats.Queued = len(ln.waiters)
+		}
+		stats.Lanes[priority] = laneStats
+	}
+	return stats
+}
+
+func (l *rateLimiterImpl) priority(op Opcode) Priority {
+	if priority, ok := l.config.OpcodePriorities[op]; ok {
+		return priority
+	}
+	return PriorityNormal
+}
+
+func (l *rateLimiterImpl) lane(priority Priority) *lane {
+	ln, ok := l.lanes[priority]
+	if !ok {
+		ln = &lane{}
+		l.lanes[priority] = ln
+	}
+	return ln
+}
+
+// limit returns the number of commands per window the given Priority can use.
+func (l *rateLimiterImpl) limit(priority Priority) int {
+	if priority == PriorityCritical {
+		return l.config.CommandsPerMinute
+	}
+	return l.config.CommandsPerMinute - l.config.ReservedCommands
+}
+
+// next hands the lock to the first waiter of the highest non-empty lane if the budget allows it.
+// l.mu must be held.
+func (l *rateLimiterImpl) next() {
+	if l.holder != nil {
+		return
+	}
+
 	now := time.Now()
-	if l.reset.Before(now) {
+	if !l.reset.After(now) {
 		l.reset = now.Add(time.Minute)
-		l.remaining = l.config.CommandsPerMinute
+		l.used = 0
 	}
-	l.mu.Unlock()
+
+	for _, priority := range Priorities {
+		ln, ok := l.lanes[priority]
+		if !ok || len(ln.waiters) == 0 {
+			continue
+		}
+		if l.used >= l.limit(priority) {
+			// lower lanes have the same or a smaller budget, so they have to wait for the reset too
+			l.config.Logger.Debug("gateway rate limit budget exhausted", slog.String("priority", priority.String()), slog.Time("reset", l.reset))
+			l.scheduleNext(l.reset.Sub(now))
+			return
+		}
+
+		w := ln.waiters[0]
+		ln.waiters = ln.waiters[1:]
+
+		wait := now.Sub(w.queuedAt)
+		ln.stats.Sent++
+		ln.stats.TotalWait += wait
+		if wait > ln.stats.MaxWait {
+			ln.stats.MaxWait = wait
+		}
+
+		l.used++
+		l.holder = w
+		close(w.ch)
+		return
+	}
+}
+
+func (l *rateLimiterImpl) scheduleNext(d time.Duration) {
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.timer = time.AfterFunc(d, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.timer = nil
+		l.next()
+	})
 }
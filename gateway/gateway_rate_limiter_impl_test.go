@@ -0,0 +1,152 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func newTestRateLimiter(opts ...RateLimiterConfigOpt) *rateLimiterImpl {
+	return NewRateLimiter(opts...).(*rateLimiterImpl)
+}
+
+func waitQueued(t *testing.T, l *rateLimiterImpl, queued int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		var n int
+		for _, laneStats := range l.Stats().Lanes {
+			n += laneStats.Queued
+		}
+		return n == queued
+	}, time.Second, time.Millisecond)
+}
+
+func TestRateLimiterLanePriority(t *testing.T) {
+	l := newTestRateLimiter(WithOpcodePriority(OpcodeResume, PriorityNormal))
+	require.NoError(t, l.WaitOpcode(context.Background(), OpcodeHeartbeat))
+
+	var (
+		mu    sync.Mutex
+		order []Opcode
+		wg    sync.WaitGroup
+	)
+	ops := []Opcode{OpcodeRequestGuildMembers, OpcodeResume, OpcodePresenceUpdate, OpcodeIdentify}
+	for i, op := range ops {
+		wg.Add(1)
+		go func(op Opcode) {
+			defer wg.Done()
+			if err := l.WaitOpcode(context.Background(), op); err != nil {
+				return
+			}
+			mu.Lock()
+			order = append(order, op)
+			mu.Unlock()
+			l.Unlock()
+		}(op)
+		// make sure every command is queued before the lock is released
+		waitQueued(t, l, i+1)
+	}
+
+	l.Unlock()
+	wg.Wait()
+	assert.Equal(t, []Opcode{OpcodeIdentify, OpcodePresenceUpdate, OpcodeResume, OpcodeRequestGuildMembers}, order)
+
+	stats := l.Stats()
+	assert.Equal(t, 2, stats.Lanes[PriorityCritical].Sent)
+	assert.Equal(t, 1, stats.Lanes[PriorityHigh].Sent)
+	assert.Equal(t, 1, stats.Lanes[PriorityNormal].Sent)
+	assert.Equal(t, 1, stats.Lanes[PriorityLow].Sent)
+}
+
+func TestRateLimiterReservedCommands(t *testing.T) {
+	l := newTestRateLimiter(WithCommandsPerMinute(3), WithReservedCommands(1))
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, l.Wait(context.Background()))
+		l.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.WaitOpcode(ctx, OpcodePresenceUpdate), context.DeadlineExceeded, "non critical commands must not use the reserved budget")
+
+	require.NoError(t, l.WaitOpcode(context.Background(), OpcodeHeartbeat), "critical commands can use the reserved budget")
+	l.Unlock()
+	assert.Zero(t, l.Stats().Remaining)
+}
+
+func TestRateLimiterReset(t *testing.T) {
+	l := newTestRateLimiter()
+
+	// a sender of the old connection still holds the lock
+	require.NoError(t, l.Wait(context.Background()))
+
+	queuedErr := make(chan error, 1)
+	go func() {
+		queuedErr <- l.Wait(context.Background())
+	}()
+	waitQueued(t, l, 1)
+
+	l.Reset()
+	assert.ErrorIs(t, <-queuedErr, discord.ErrShardNotConnected, "commands queued for the old connection must not be sent on the new one")
+
+	identified := make(chan error, 1)
+	go func() {
+		identified <- l.WaitOpcode(context.Background(), OpcodeIdentify)
+	}()
+	waitQueued(t, l, 1)
+
+	// the sender of the old connection unlocks its own lock, which passes it on to the identify
+	l.Unlock()
+	require.NoError(t, <-identified)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded, "the identify should hold the lock until it unlocks it")
+
+	l.Unlock()
+	require.NoError(t, l.Wait(context.Background()))
+	l.Unlock()
+
+	assert.Equal(t, CommandsPerMinute-2, l.Stats().Remaining)
+}
+
+func TestRateLimiterCloseReset(t *testing.T) {
+	l := newTestRateLimiter()
+
+	// Close keeps the lock until the connection is reopened, it never unlocks it
+	l.Close(context.Background())
+	l.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	l.Close(ctx)
+	require.NoError(t, ctx.Err(), "Close should not wait for its own lock")
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer waitCancel()
+	assert.ErrorIs(t, l.Wait(waitCtx), context.DeadlineExceeded, "an Unlock must not release the lock of Close")
+
+	l.Reset()
+	require.NoError(t, l.WaitOpcode(ctx, OpcodeIdentify))
+	l.Unlock()
+
+	require.NoError(t, l.Wait(ctx), "the Unlock after the Reset must release the lock")
+	l.Unlock()
+	l.Close(ctx)
+	require.NoError(t, ctx.Err(), "Close should get the lock once the last command was sent")
+}
+
+func TestRateLimiterStats(t *testing.T) {
+	l := newTestRateLimiter()
+
+	stats := l.Stats()
+	assert.Equal(t, CommandsPerMinute, stats.Remaining)
+	assert.Len(t, stats.Lanes, len(Priorities))
+	assert.Empty(t, l.lanes, "Stats should not create lanes")
+}
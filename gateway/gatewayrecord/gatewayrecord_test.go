@@ -0,0 +1,124 @@
+package gatewayrecord
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+type replayedEvent struct {
+	eventType gateway.EventType
+	sequence  int
+	shardID   int
+	event     gateway.EventData
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var buf bytes.Buffer
+	recorder, err := NewRecorder(&buf)
+	require.NoError(t, err)
+
+	var received []string
+	handle := recorder.Wrap(func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		if e, ok := event.(gateway.EventRaw); ok {
+			payload, _ := io.ReadAll(e.Payload)
+			received = append(received, string(payload))
+		}
+	})
+
+	payloads := []struct {
+		eventType gateway.EventType
+		shardID   int
+		payload   string
+	}{
+		{gateway.EventTypeGuildDelete, 0, `{"id":"1"}`},
+		{gateway.EventTypeGuildDelete, 1, `{"id":"2"}`},
+		{gateway.EventTypeTypingStart, 0, `{"channel_id":"3","user_id":"4","timestamp":1}`},
+	}
+	for i, p := range payloads {
+		handle(gateway.EventTypeRaw, i+1, p.shardID, gateway.EventRaw{
+			EventType: p.eventType,
+			Payload:   bytes.NewReader([]byte(p.payload)),
+		})
+	}
+	require.NoError(t, recorder.Close())
+	assert.Equal(t, []string{`{"id":"1"}`, `{"id":"2"}`, `{"channel_id":"3","user_id":"4","timestamp":1}`}, received, "the raw payloads should still be readable by the next handler")
+
+	replayer, err := NewReplayer(bytes.NewReader(buf.Bytes()), WithSpeed(0), WithShardIDs(0))
+	require.NoError(t, err)
+
+	var replayed []replayedEvent
+	n, err := replayer.Replay(context.Background(), func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		replayed = append(replayed, replayedEvent{eventType: eventType, sequence: sequenceNumber, shardID: shardID, event: event})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Len(t, replayed, 2)
+	assert.Equal(t, gateway.EventTypeGuildDelete, replayed[0].eventType)
+	assert.Equal(t, 1, replayed[0].sequence)
+	assert.IsType(t, gateway.EventGuildDelete{}, replayed[0].event)
+	assert.Equal(t, gateway.EventTypeTypingStart, replayed[1].eventType)
+	assert.Equal(t, 3, replayed[1].sequence)
+}
+
+func TestReplaySpeed(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf)
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, writer.Write(Record{
+			Time:      start.Add(time.Duration(i) * 200 * time.Millisecond),
+			Sequence:  i + 1,
+			EventType: gateway.EventTypeGuildDelete,
+			Payload:   []byte(`{"id":"1"}`),
+		}))
+	}
+	require.NoError(t, writer.Flush())
+
+	reader, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	record, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, start.UnixMicro(), record.Time.UnixMicro())
+
+	replayer, err := NewReplayer(bytes.NewReader(buf.Bytes()), WithSpeed(4), WithReplayRawEvents(true))
+	require.NoError(t, err)
+
+	var eventTypes []gateway.EventType
+	replayStart := time.Now()
+	n, err := replayer.Replay(context.Background(), func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		eventTypes = append(eventTypes, eventType)
+	})
+	elapsed := time.Since(replayStart)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Len(t, eventTypes, 6)
+	assert.Equal(t, gateway.EventTypeRaw, eventTypes[0])
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond, "400ms of events at 4x speed should take 100ms")
+	assert.Less(t, elapsed, 400*time.Millisecond)
+}
+
+func TestInvalidRecording(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("nope!")))
+	assert.ErrorIs(t, err, ErrInvalidRecording)
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(Record{Time: time.Now(), EventType: gateway.EventTypeGuildDelete, Payload: []byte(`{"id":"1"}`)}))
+	require.NoError(t, writer.Flush())
+
+	reader, err := NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-2]))
+	require.NoError(t, err)
+	_, err = reader.Read()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
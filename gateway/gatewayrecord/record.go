@@ -0,0 +1,174 @@
+// Package gatewayrecord records gateway dispatches to a compact file and replays them into a gateway.EventHandlerFunc like bot.EventManager.HandleGatewayEvent.
+//
+// Recordings let you reproduce cache bugs or benchmark handlers & caches against real traffic without connecting to Discord.
+package gatewayrecord
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// magic is written at the start of every recording followed by the format version.
+const (
+	magic   = "DGWR"
+	version = 1
+)
+
+// maxFieldSize limits the size of a single field, so corrupted recordings do not allocate huge buffers.
+const maxFieldSize = 64 << 20
+
+// ErrInvalidRecording is returned when a recording does not start with the expected header.
+var ErrInvalidRecording = errors.New("invalid gateway recording")
+
+// Record is a single dispatch received from the gateway.
+type Record struct {
+	// Time is when the dispatch was received.
+	Time      time.Time
+	ShardID   int
+	Sequence  int
+	EventType gateway.EventType
+	// Payload is the raw d field of the dispatch.
+	Payload json.RawMessage
+}
+
+// Writer writes Record(s) in the recording format.
+//
+// Every Record is stored as varints for the time delta in microseconds to the previous Record, the shard id and the sequence,
+// followed by the length prefixed event type & payload. Wrap the io.Writer in a gzip.Writer for even smaller recordings.
+type Writer struct {
+	w        *bufio.Writer
+	lastTime time.Time
+	buf      []byte
+}
+
+// NewWriter creates a new Writer and writes the recording header.
+func NewWriter(w io.Writer) (*Writer, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(magic); err != nil {
+		return nil, err
+	}
+	if err := bw.WriteByte(version); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw}, nil
+}
+
+// Write writes the Record. Records are buffered until Flush is called.
+func (w *Writer) Write(record Record) error {
+	// track the time like the Reader reconstructs it, so rounding errors do not add up
+	var delta int64
+	if w.lastTime.IsZero() {
+		delta = record.Time.UnixMicro()
+		w.lastTime = time.UnixMicro(delta)
+	} else {
+		delta = record.Time.Sub(w.lastTime).Microseconds()
+		w.lastTime = w.lastTime.Add(time.Duration(delta) * time.Microsecond)
+	}
+
+	w.buf = binary.AppendVarint(w.buf[:0], delta)
+	w.buf = binary.AppendUvarint(w.buf, uint64(record.ShardID))
+	w.buf = binary.AppendVarint(w.buf, int64(record.Sequence))
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(record.EventType)))
+	w.buf = append(w.buf, record.EventType...)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(record.Payload)))
+	w.buf = append(w.buf, record.Payload...)
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+// Flush writes all buffered Record(s) to the underlying io.Writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// Reader reads Record(s) written by a Writer.
+type Reader struct {
+	r        *bufio.Reader
+	lastTime time.Time
+}
+
+// NewReader creates a new Reader and validates the recording header.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecording, err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, ErrInvalidRecording
+	}
+	if header[len(magic)] != version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidRecording, header[len(magic)])
+	}
+	return &Reader{r: br}, nil
+}
+
+// Read returns the next Record or io.EOF at the end of the recording.
+func (r *Reader) Read() (Record, error) {
+	delta, err := binary.ReadVarint(r.r)
+	if err != nil {
+		// a clean end of the recording is only possible before a new record
+		return Record{}, err
+	}
+
+	var record Record
+	if r.lastTime.IsZero() {
+		record.Time = time.UnixMicro(delta)
+	} else {
+		record.Time = r.lastTime.Add(time.Duration(delta) * time.Microsecond)
+	}
+	r.lastTime = record.Time
+
+	shardID, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return Record{}, unexpectedEOF(err)
+	}
+	record.ShardID = int(shardID)
+
+	sequence, err := binary.ReadVarint(r.r)
+	if err != nil {
+		return Record{}, unexpectedEOF(err)
+	}
+	record.Sequence = int(sequence)
+
+	eventType, err := r.readField()
+	if err != nil {
+		return Record{}, err
+	}
+	record.EventType = gateway.EventType(eventType)
+
+	if record.Payload, err = r.readField(); err != nil {
+		return Record{}, err
+	}
+	return record, nil
+}
+
+func (r *Reader) readField() ([]byte, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if size > maxFieldSize {
+		return nil, fmt.Errorf("%w: field of %d bytes exceeds the maximum size", ErrInvalidRecording, size)
+	}
+	field := make([]byte, size)
+	if _, err = io.ReadFull(r.r, field); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return field, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package gatewayrecord
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// ErrRecorderClosed is returned when a Record is written to a closed Recorder.
+var ErrRecorderClosed = errors.New("recorder is closed")
+
+var _ bot.EventListener = (*Recorder)(nil)
+
+// NewRecorder creates a new Recorder writing to w. The Recorder does not close w.
+func NewRecorder(w io.Writer, opts ...RecorderConfigOpt) (*Recorder, error) {
+	config := DefaultRecorderConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "gateway_recorder"))
+
+	writer, err := NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		config: *config,
+		writer: writer,
+	}, nil
+}
+
+// Recorder records the dispatches of one or more gateways. The raw payloads are only available with gateway.WithEnableRawEvents.
+//
+// Use it as bot.EventListener with bot.WithEventListeners or call Recorder.HandleGatewayEvent from your own gateway.EventHandlerFunc.
+// The Recorder is safe for concurrent use by multiple shards.
+type Recorder struct {
+	config RecorderConfig
+
+	mu     sync.Mutex
+	writer *Writer
+	closed bool
+}
+
+// OnEvent records events.Raw and ignores all other events.
+func (r *Recorder) OnEvent(event bot.Event) {
+	if e, ok := event.(*events.Raw); ok {
+		r.record(e.SequenceNumber(), e.ShardID(), e.EventRaw)
+	}
+}
+
+// HandleGatewayEvent records gateway.EventTypeRaw events and ignores all other events.
+// It has the signature of a gateway.EventHandlerFunc.
+func (r *Recorder) HandleGatewayEvent(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+	if eventType != gateway.EventTypeRaw {
+		return
+	}
+	if e, ok := event.(gateway.EventRaw); ok {
+		r.record(sequenceNumber, shardID, e)
+	}
+}
+
+// Wrap returns a gateway.EventHandlerFunc which records the dispatches before passing them to next.
+func (r *Recorder) Wrap(next gateway.EventHandlerFunc) gateway.EventHandlerFunc {
+	return func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		r.HandleGatewayEvent(eventType, sequenceNumber, shardID, event)
+		next(eventType, sequenceNumber, shardID, event)
+	}
+}
+
+func (r *Recorder) record(sequenceNumber int, shardID int, event gateway.EventRaw) {
+	if !r.config.records(event.EventType) {
+		return
+	}
+	payload, err := io.ReadAll(event.Payload)
+	if err != nil {
+		r.config.Logger.Error("failed to read raw payload", slog.String("event_type", string(event.EventType)), slog.Any("err", err))
+		return
+	}
+	// other listeners of the raw event still need to read the payload
+	if seeker, ok := event.Payload.(io.Seeker); ok {
+		if _, err = seeker.Seek(0, io.SeekStart); err != nil {
+			r.config.Logger.Error("failed to rewind raw payload", slog.Any("err", err))
+		}
+	}
+
+	if err = r.Record(Record{
+		Time:      time.Now(),
+		ShardID:   shardID,
+		Sequence:  sequenceNumber,
+		EventType: event.EventType,
+		Payload:   payload,
+	}); err != nil {
+		r.config.Logger.Error("failed to record event", slog.String("event_type", string(event.EventType)), slog.Any("err", err))
+	}
+}
+
+// Record writes the Record, for example to add synthetic events to a recording.
+func (r *Recorder) Record(record Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return ErrRecorderClosed
+	}
+	if err := r.writer.Write(record); err != nil {
+		return err
+	}
+	if r.config.FlushEveryRecord {
+		return r.writer.Flush()
+	}
+	return nil
+}
+
+// Flush writes all buffered Record(s) to the underlying io.Writer.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.writer.Flush()
+}
+
+// Close flushes the buffered Record(s) and stops recording.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.writer.Flush()
+}
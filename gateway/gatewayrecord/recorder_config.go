@@ -0,0 +1,60 @@
+package gatewayrecord
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// DefaultRecorderConfig returns a RecorderConfig with sensible defaults.
+func DefaultRecorderConfig() *RecorderConfig {
+	return &RecorderConfig{
+		Logger: slog.Default(),
+	}
+}
+
+// RecorderConfig lets you configure your Recorder instance.
+type RecorderConfig struct {
+	// Logger is the Logger of the Recorder. Defaults to slog.Default().
+	Logger *slog.Logger
+	// EventTypes are the gateway.EventType(s) which are recorded. Defaults to all.
+	EventTypes []gateway.EventType
+	// FlushEveryRecord flushes every Record to the underlying io.Writer, so a crash does not lose the last events. Defaults to false.
+	FlushEveryRecord bool
+}
+
+// RecorderConfigOpt is a type alias for a function that takes a RecorderConfig and is used to configure your Recorder.
+type RecorderConfigOpt func(config *RecorderConfig)
+
+// Apply applies the given RecorderConfigOpt(s) to the RecorderConfig
+func (c *RecorderConfig) Apply(opts []RecorderConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithRecorderLogger sets the Logger of the Recorder.
+func WithRecorderLogger(logger *slog.Logger) RecorderConfigOpt {
+	return func(config *RecorderConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithRecordedEventTypes only records the given gateway.EventType(s).
+func WithRecordedEventTypes(eventTypes ...gateway.EventType) RecorderConfigOpt {
+	return func(config *RecorderConfig) {
+		config.EventTypes = append(config.EventTypes, eventTypes...)
+	}
+}
+
+// WithFlushEveryRecord sets whether every Record is flushed to the underlying io.Writer.
+func WithFlushEveryRecord(flushEveryRecord bool) RecorderConfigOpt {
+	return func(config *RecorderConfig) {
+		config.FlushEveryRecord = flushEveryRecord
+	}
+}
+
+func (c *RecorderConfig) records(eventType gateway.EventType) bool {
+	return len(c.EventTypes) == 0 || slices.Contains(c.EventTypes, eventType)
+}
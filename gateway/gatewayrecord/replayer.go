@@ -0,0 +1,119 @@
+package gatewayrecord
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// NewReplayer creates a new Replayer reading the recording from r.
+func NewReplayer(r io.Reader, opts ...ReplayerConfigOpt) (*Replayer, error) {
+	config := DefaultReplayerConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "gateway_replayer"))
+
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		config: *config,
+		reader: reader,
+	}, nil
+}
+
+// Replayer replays a recording in its original order into a gateway.EventHandlerFunc.
+// Pass bot.EventManager.HandleGatewayEvent of a bot.Client which is not connected to the gateway to run its handlers & caches against the recording.
+type Replayer struct {
+	config ReplayerConfig
+	reader *Reader
+}
+
+// Replay replays all events until the end of the recording or until the context is done. Events are passed to the handler one after another
+// from the calling goroutine, so replays are deterministic. It returns the number of replayed events.
+func (r *Replayer) Replay(ctx context.Context, handler gateway.EventHandlerFunc) (int, error) {
+	var (
+		replayed  int
+		start     time.Time
+		firstTime time.Time
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+		record, err := r.reader.Read()
+		if errors.Is(err, io.EOF) {
+			return replayed, nil
+		}
+		if err != nil {
+			return replayed, fmt.Errorf("failed to read record: %w", err)
+		}
+
+		if len(r.config.ShardIDs) > 0 && !slices.Contains(r.config.ShardIDs, record.ShardID) {
+			continue
+		}
+
+		if start.IsZero() {
+			start = time.Now()
+			firstTime = record.Time
+		} else if err = r.wait(ctx, start.Add(r.scale(record.Time.Sub(firstTime)))); err != nil {
+			return replayed, err
+		}
+
+		if err = r.replay(record, handler); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+}
+
+func (r *Replayer) replay(record Record, handler gateway.EventHandlerFunc) error {
+	eventData, err := gateway.UnmarshalEventData(record.Payload, record.EventType)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s event with sequence %d of shard %d: %w", record.EventType, record.Sequence, record.ShardID, err)
+	}
+	// the gateway drops unknown events as well
+	if _, ok := eventData.(gateway.EventUnknown); ok {
+		r.config.Logger.Debug("unknown event replayed", slog.String("event", string(record.EventType)))
+		return nil
+	}
+
+	if r.config.EnableRawEvents {
+		handler(gateway.EventTypeRaw, record.Sequence, record.ShardID, gateway.EventRaw{
+			EventType: record.EventType,
+			Payload:   bytes.NewReader(record.Payload),
+		})
+	}
+	handler(record.EventType, record.Sequence, record.ShardID, eventData)
+	return nil
+}
+
+// scale divides the recorded duration by the configured speed.
+func (r *Replayer) scale(d time.Duration) time.Duration {
+	if r.config.Speed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(d) / r.config.Speed)
+}
+
+func (r *Replayer) wait(ctx context.Context, until time.Time) error {
+	d := time.Until(until)
+	if r.config.Speed <= 0 || d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
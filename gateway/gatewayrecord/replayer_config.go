@@ -0,0 +1,64 @@
+package gatewayrecord
+
+import (
+	"log/slog"
+)
+
+// DefaultReplayerConfig returns a ReplayerConfig with sensible defaults.
+func DefaultReplayerConfig() *ReplayerConfig {
+	return &ReplayerConfig{
+		Logger: slog.Default(),
+		Speed:  1,
+	}
+}
+
+// ReplayerConfig lets you configure your Replayer instance.
+type ReplayerConfig struct {
+	// Logger is the Logger of the Replayer. Defaults to slog.Default().
+	Logger *slog.Logger
+	// Speed is the factor the recorded time between events is divided by. 1 replays at the original speed, 2 twice as fast
+	// and 0 replays all events without waiting. Defaults to 1.
+	Speed float64
+	// EnableRawEvents also replays a gateway.EventTypeRaw event before every event like gateway.WithEnableRawEvents. Defaults to false.
+	EnableRawEvents bool
+	// ShardIDs are the shards whose events are replayed. Defaults to all.
+	ShardIDs []int
+}
+
+// ReplayerConfigOpt is a type alias for a function that takes a ReplayerConfig and is used to configure your Replayer.
+type ReplayerConfigOpt func(config *ReplayerConfig)
+
+// Apply applies the given ReplayerConfigOpt(s) to the ReplayerConfig
+func (c *ReplayerConfig) Apply(opts []ReplayerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithReplayerLogger sets the Logger of the Replayer.
+func WithReplayerLogger(logger *slog.Logger) ReplayerConfigOpt {
+	return func(config *ReplayerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithSpeed sets the replay speed. 0 replays all events without waiting.
+func WithSpeed(speed float64) ReplayerConfigOpt {
+	return func(config *ReplayerConfig) {
+		config.Speed = speed
+	}
+}
+
+// WithReplayRawEvents sets whether gateway.EventTypeRaw events are replayed.
+func WithReplayRawEvents(enableRawEvents bool) ReplayerConfigOpt {
+	return func(config *ReplayerConfig) {
+		config.EnableRawEvents = enableRawEvents
+	}
+}
+
+// WithShardIDs only replays the events of the given shards.
+func WithShardIDs(shardIDs ...int) ReplayerConfigOpt {
+	return func(config *ReplayerConfig) {
+		config.ShardIDs = append(config.ShardIDs, shardIDs...)
+	}
+}
@@ -0,0 +1,534 @@
+// Package gatewaytest provides an in-process fake of the Discord gateway for testing gateway.Gateway, sharding and bot.Client.
+//
+// Point the gateway at the Server with gateway.WithURL(server.URL), dispatch scripted events with Server.Dispatch and
+// assert on the commands the client sent with Server.WaitForCommand.
+package gatewaytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// ErrShardNotReady is returned when sending to a shard which has no identified or resumed connection.
+var ErrShardNotReady = errors.New("shard has no ready connection")
+
+// Command is a gateway command received from a client.
+type Command struct {
+	// ShardID is the shard of the connection or -1 if the connection has not identified or resumed yet.
+	ShardID int
+	// SessionID is the session of the connection or empty if the connection has not identified or resumed yet.
+	SessionID  string
+	Op         gateway.Opcode
+	D          gateway.MessageData
+	RawD       json.RawMessage
+	ReceivedAt time.Time
+}
+
+// NewServer starts a new Server listening on a local port. Close it after the test.
+func NewServer(opts ...ConfigOpt) *Server {
+	config := DefaultConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "gatewaytest"))
+
+	s := &Server{
+		config:   *config,
+		changed:  make(chan struct{}),
+		sessions: map[string]*session{},
+		conns:    map[int]*conn{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = "ws" + strings.TrimPrefix(s.server.URL, "http")
+	return s
+}
+
+// Server is a fake Discord gateway. It speaks the gateway protocol over websockets with the json encoding and without compression.
+//
+// Sessions are kept after a connection is closed, so clients can resume them and receive all missed dispatches.
+// They are removed when the client closes with websocket.CloseNormalClosure or websocket.CloseGoingAway,
+// or the Server invalidates them.
+type Server struct {
+	// URL is the websocket url of the Server, for example ws://127.0.0.1:1234
+	URL string
+
+	config   Config
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu            sync.Mutex
+	changed       chan struct{}
+	commands      []Command
+	commandCursor int
+	sessionCount  int
+	sessions      map[string]*session
+	conns         map[int]*conn
+}
+
+type session struct {
+	id         string
+	shardID    int
+	shardCount int
+	seq        int
+	dispatches []dispatch
+}
+
+type dispatch struct {
+	seq  int
+	data []byte
+}
+
+type conn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+	// session is nil until the connection identified or resumed
+	session *session
+	closed  bool
+}
+
+type outgoingMessage struct {
+	Op gateway.Opcode    `json:"op"`
+	S  *int              `json:"s"`
+	T  gateway.EventType `json:"t,omitempty"`
+	D  any               `json:"d"`
+}
+
+func (c *conn) write(message outgoingMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return c.writeRaw(data)
+}
+
+func (c *conn) writeRaw(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.ws.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *conn) close(code int, text string) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(time.Second))
+	_ = c.ws.Close()
+}
+
+// Close closes all connections and stops the Server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	for _, c := range s.conns {
+		c.close(websocket.CloseGoingAway, "server closed")
+	}
+	s.mu.Unlock()
+	s.server.Close()
+}
+
+// Commands returns all commands received so far.
+func (s *Server) Commands() []Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	commands := make([]Command, len(s.commands))
+	copy(commands, s.commands)
+	return commands
+}
+
+// NextCommand waits for the next command which was not returned by NextCommand or WaitForCommand yet.
+func (s *Server) NextCommand(ctx context.Context) (Command, error) {
+	return s.WaitForCommand(ctx, func(Command) bool {
+		return true
+	})
+}
+
+// WaitForCommand waits for the next command which matches. Commands which don't match are skipped.
+// Use Commands to inspect skipped commands.
+func (s *Server) WaitForCommand(ctx context.Context, match func(command Command) bool) (Command, error) {
+	var command Command
+	err := s.wait(ctx, func() bool {
+		for s.commandCursor < len(s.commands) {
+			c := s.commands[s.commandCursor]
+			s.commandCursor++
+			if match(c) {
+				command = c
+				return true
+			}
+		}
+		return false
+	})
+	return command, err
+}
+
+// WaitForOpcode waits for the next command with the gateway.Opcode. See WaitForCommand.
+func (s *Server) WaitForOpcode(ctx context.Context, op gateway.Opcode) (Command, error) {
+	return s.WaitForCommand(ctx, func(command Command) bool {
+		return command.Op == op
+	})
+}
+
+// WaitForShard waits until the shard has an identified or resumed connection.
+func (s *Server) WaitForShard(ctx context.Context, shardID int) error {
+	return s.wait(ctx, func() bool {
+		c, ok := s.conns[shardID]
+		return ok && c.session != nil
+	})
+}
+
+// SessionID returns the session of the shard's connection.
+func (s *Server) SessionID(shardID int) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.conns[shardID]
+	if !ok || c.session == nil {
+		return "", false
+	}
+	return c.session.id, true
+}
+
+// Dispatch sends an event to the shard and returns its sequence number.
+// The data is marshalled to json, so it can be a gateway.EventData or raw json.RawMessage.
+func (s *Server) Dispatch(shardID int, eventType gateway.EventType, data any) (int, error) {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.readyConn(shardID)
+	if err != nil {
+		return 0, err
+	}
+	return s.dispatch(c, eventType, rawData)
+}
+
+// RequestHeartbeat sends an OpcodeHeartbeat to the shard which the client must answer with a heartbeat.
+func (s *Server) RequestHeartbeat(shardID int) error {
+	return s.send(shardID, outgoingMessage{Op: gateway.OpcodeHeartbeat})
+}
+
+// Reconnect sends an OpcodeReconnect to the shard. The client is expected to reconnect and resume.
+func (s *Server) Reconnect(shardID int) error {
+	return s.send(shardID, outgoingMessage{Op: gateway.OpcodeReconnect})
+}
+
+// InvalidSession sends an OpcodeInvalidSession to the shard. If resumable is false, the session is removed.
+func (s *Server) InvalidSession(shardID int, resumable bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.readyConn(shardID)
+	if err != nil {
+		return err
+	}
+	if !resumable {
+		delete(s.sessions, c.session.id)
+	}
+	return c.write(outgoingMessage{Op: gateway.OpcodeInvalidSession, D: resumable})
+}
+
+// CloseShard closes the connection of the shard with the close code.
+// For gateway.CloseEventCodeInvalidSeq and gateway.CloseEventCodeSessionTimed the session is removed.
+func (s *Server) CloseShard(shardID int, code gateway.CloseEventCode) error {
+	s.mu.Lock()
+	c, ok := s.conns[shardID]
+	if !ok {
+		s.mu.Unlock()
+		return ErrShardNotReady
+	}
+	if code == gateway.CloseEventCodeInvalidSeq || code == gateway.CloseEventCodeSessionTimed {
+		delete(s.sessions, c.session.id)
+	}
+	s.mu.Unlock()
+
+	c.close(code.Code, code.Description)
+	return nil
+}
+
+func (s *Server) send(shardID int, message outgoingMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.readyConn(shardID)
+	if err != nil {
+		return err
+	}
+	return c.write(message)
+}
+
+// readyConn returns the connection of the shard. s.mu must be held.
+func (s *Server) readyConn(shardID int) (*conn, error) {
+	c, ok := s.conns[shardID]
+	if !ok || c.session == nil {
+		return nil, ErrShardNotReady
+	}
+	return c, nil
+}
+
+// dispatch sends the event and keeps it for resuming. s.mu must be held.
+func (s *Server) dispatch(c *conn, eventType gateway.EventType, rawData json.RawMessage) (int, error) {
+	c.session.seq++
+	seq := c.session.seq
+	data, err := json.Marshal(outgoingMessage{
+		Op: gateway.OpcodeDispatch,
+		S:  &seq,
+		T:  eventType,
+		D:  rawData,
+	})
+	if err != nil {
+		return 0, err
+	}
+	c.session.dispatches = append(c.session.dispatches, dispatch{seq: seq, data: data})
+	return seq, c.writeRaw(data)
+}
+
+// wait calls cond with s.mu held every time the state of the Server changes until it returns true.
+func (s *Server) wait(ctx context.Context, cond func() bool) error {
+	for {
+		s.mu.Lock()
+		if cond() {
+			s.mu.Unlock()
+			return nil
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// notify wakes up all waiting calls. s.mu must be held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.config.Logger.Error("failed to upgrade connection", slog.Any("err", err))
+		return
+	}
+	c := &conn{ws: ws}
+
+	if version := r.URL.Query().Get("v"); version != strconv.Itoa(gateway.Version) {
+		c.close(gateway.CloseEventCodeInvalidAPIVersion.Code, gateway.CloseEventCodeInvalidAPIVersion.Description)
+		return
+	}
+
+	if err = c.write(outgoingMessage{
+		Op: gateway.OpcodeHello,
+		D:  gateway.MessageDataHello{HeartbeatInterval: int(s.config.HeartbeatInterval.Milliseconds())},
+	}); err != nil {
+		s.config.Logger.Error("failed to send hello", slog.Any("err", err))
+		_ = ws.Close()
+		return
+	}
+	s.listen(c)
+}
+
+func (s *Server) listen(c *conn) {
+	defer s.disconnect(c, websocket.CloseAbnormalClosure)
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) {
+				s.disconnect(c, closeErr.Code)
+			}
+			return
+		}
+
+		var message gateway.Message
+		if err = json.Unmarshal(data, &message); err != nil {
+			s.config.Logger.Debug("failed to decode command", slog.Any("err", err))
+			c.close(gateway.CloseEventCodeDecodeError.Code, gateway.CloseEventCodeDecodeError.Description)
+			return
+		}
+
+		if closeCode, ok := s.handleCommand(c, message); !ok {
+			c.close(closeCode.Code, closeCode.Description)
+			return
+		}
+	}
+}
+
+// handleCommand handles the command and records it. It returns false with the close code if the connection has to be closed.
+func (s *Server) handleCommand(c *conn, message gateway.Message) (gateway.CloseEventCode, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	closeCode, ok := s.handleCommandLocked(c, message)
+
+	command := Command{
+		ShardID:    -1,
+		Op:         message.Op,
+		D:          message.D,
+		RawD:       message.RawD,
+		ReceivedAt: time.Now(),
+	}
+	if c.session != nil {
+		command.ShardID = c.session.shardID
+		command.SessionID = c.session.id
+	}
+	s.commands = append(s.commands, command)
+	s.notify()
+	return closeCode, ok
+}
+
+func (s *Server) handleCommandLocked(c *conn, message gateway.Message) (gateway.CloseEventCode, bool) {
+	switch d := message.D.(type) {
+	case gateway.MessageDataHeartbeat:
+		if s.config.HeartbeatACK {
+			if err := c.write(outgoingMessage{Op: gateway.OpcodeHeartbeatACK}); err != nil {
+				s.config.Logger.Debug("failed to send heartbeat ack", slog.Any("err", err))
+			}
+		}
+		return gateway.CloseEventCode{}, true
+
+	case gateway.MessageDataIdentify:
+		return s.identify(c, d)
+
+	case gateway.MessageDataResume:
+		return s.resume(c, d)
+
+	case gateway.MessageDataPresenceUpdate, gateway.MessageDataVoiceStateUpdate, gateway.MessageDataRequestGuildMembers:
+		if c.session == nil {
+			return gateway.CloseEventCodeNotAuthenticated, false
+		}
+		return gateway.CloseEventCode{}, true
+
+	default:
+		return gateway.CloseEventCodeUnknownOpcode, false
+	}
+}
+
+func (s *Server) identify(c *conn, identify gateway.MessageDataIdentify) (gateway.CloseEventCode, bool) {
+	if c.session != nil {
+		return gateway.CloseEventCodeAlreadyAuthenticated, false
+	}
+	if s.config.Token != "" && identify.Token != s.config.Token {
+		return gateway.CloseEventCodeAuthenticationFailed, false
+	}
+
+	shardID, shardCount := 0, 1
+	if identify.Shard != nil {
+		shardID, shardCount = identify.Shard[0], identify.Shard[1]
+	}
+	if shardCount < 1 || shardID < 0 || shardID >= shardCount || (s.config.ShardCount > 0 && shardCount != s.config.ShardCount) {
+		return gateway.CloseEventCodeInvalidShard, false
+	}
+
+	s.sessionCount++
+	sess := &session{
+		id:         "session-" + strconv.Itoa(s.sessionCount),
+		shardID:    shardID,
+		shardCount: shardCount,
+	}
+	s.sessions[sess.id] = sess
+	s.attach(c, sess)
+
+	var guilds []discord.UnavailableGuild
+	for _, guild := range s.config.Guilds {
+		if int((uint64(guild.ID)>>22)%uint64(shardCount)) == shardID {
+			guilds = append(guilds, guild)
+		}
+	}
+	rawReady, err := json.Marshal(gateway.EventReady{
+		Version:          gateway.Version,
+		User:             s.config.User,
+		Guilds:           guilds,
+		SessionID:        sess.id,
+		ResumeGatewayURL: s.URL,
+		Shard:            [2]int{shardID, shardCount},
+		Application:      s.config.Application,
+	})
+	if err != nil {
+		s.config.Logger.Error("failed to marshal ready event", slog.Any("err", err))
+		return gateway.CloseEventCodeUnknownError, false
+	}
+	if _, err = s.dispatch(c, gateway.EventTypeReady, rawReady); err != nil {
+		s.config.Logger.Debug("failed to send ready event", slog.Any("err", err))
+	}
+	return gateway.CloseEventCode{}, true
+}
+
+func (s *Server) resume(c *conn, resume gateway.MessageDataResume) (gateway.CloseEventCode, bool) {
+	if c.session != nil {
+		return gateway.CloseEventCodeAlreadyAuthenticated, false
+	}
+	if s.config.Token != "" && resume.Token != s.config.Token {
+		return gateway.CloseEventCodeAuthenticationFailed, false
+	}
+
+	sess, ok := s.sessions[resume.SessionID]
+	if !ok {
+		if err := c.write(outgoingMessage{Op: gateway.OpcodeInvalidSession, D: false}); err != nil {
+			s.config.Logger.Debug("failed to send invalid session", slog.Any("err", err))
+		}
+		return gateway.CloseEventCode{}, true
+	}
+	if resume.Seq > sess.seq {
+		return gateway.CloseEventCodeInvalidSeq, false
+	}
+	s.attach(c, sess)
+
+	for _, d := range sess.dispatches {
+		if d.seq <= resume.Seq {
+			continue
+		}
+		if err := c.writeRaw(d.data); err != nil {
+			s.config.Logger.Debug("failed to replay event", slog.Any("err", err))
+			return gateway.CloseEventCode{}, true
+		}
+	}
+	if _, err := s.dispatch(c, gateway.EventTypeResumed, nil); err != nil {
+		s.config.Logger.Debug("failed to send resumed event", slog.Any("err", err))
+	}
+	return gateway.CloseEventCode{}, true
+}
+
+// attach makes the connection the current connection of the session's shard and closes the previous one. s.mu must be held.
+func (s *Server) attach(c *conn, sess *session) {
+	if old, ok := s.conns[sess.shardID]; ok && old != c {
+		old.closed = true
+		go old.close(websocket.CloseServiceRestart, "new connection")
+	}
+	c.session = sess
+	s.conns[sess.shardID] = c
+}
+
+// disconnect removes the connection and also the session if the client closed it gracefully.
+func (s *Server) disconnect(c *conn, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	_ = c.ws.Close()
+
+	if c.session == nil {
+		return
+	}
+	if current, ok := s.conns[c.session.shardID]; ok && current == c {
+		delete(s.conns, c.session.shardID)
+	}
+	if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
+		delete(s.sessions, c.session.id)
+	}
+	s.notify()
+}
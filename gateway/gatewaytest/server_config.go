@@ -0,0 +1,110 @@
+package gatewaytest
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:            slog.Default(),
+		HeartbeatInterval: 45 * time.Second,
+		User: discord.OAuth2User{
+			User: discord.User{
+				ID:       1,
+				Username: "test",
+				Bot:      true,
+			},
+		},
+		HeartbeatACK: true,
+	}
+}
+
+// Config lets you configure your Server instance.
+type Config struct {
+	// Logger is the Logger of the Server. Defaults to slog.Default().
+	Logger *slog.Logger
+	// HeartbeatInterval is the interval sent in the OpcodeHello. Defaults to 45 seconds.
+	HeartbeatInterval time.Duration
+	// Token is the token clients must identify & resume with. Defaults to accepting any token.
+	Token string
+	// ShardCount is the shard count clients must identify with. Defaults to accepting any shard count.
+	ShardCount int
+	// User is the bot user sent in the Ready event.
+	User discord.OAuth2User
+	// Application is the application sent in the Ready event.
+	Application discord.PartialApplication
+	// Guilds are the guilds sent in the Ready event. Each shard only receives the guilds it is responsible for.
+	Guilds []discord.UnavailableGuild
+	// HeartbeatACK is whether the Server acknowledges heartbeats. Disable it to simulate a zombie connection. Defaults to true.
+	HeartbeatACK bool
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger for the Server.
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithHeartbeatInterval sets the heartbeat interval the Server sends to clients.
+func WithHeartbeatInterval(interval time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.HeartbeatInterval = interval
+	}
+}
+
+// WithToken sets the token clients must use. Other tokens are rejected with gateway.CloseEventCodeAuthenticationFailed.
+func WithToken(token string) ConfigOpt {
+	return func(config *Config) {
+		config.Token = token
+	}
+}
+
+// WithShardCount sets the shard count clients must use. Other shard counts are rejected with gateway.CloseEventCodeInvalidShard.
+func WithShardCount(shardCount int) ConfigOpt {
+	return func(config *Config) {
+		config.ShardCount = shardCount
+	}
+}
+
+// WithUser sets the bot user sent in the Ready event.
+func WithUser(user discord.OAuth2User) ConfigOpt {
+	return func(config *Config) {
+		config.User = user
+	}
+}
+
+// WithApplication sets the application sent in the Ready event.
+func WithApplication(application discord.PartialApplication) ConfigOpt {
+	return func(config *Config) {
+		config.Application = application
+	}
+}
+
+// WithGuilds sets the guilds sent in the Ready event.
+func WithGuilds(guilds ...discord.UnavailableGuild) ConfigOpt {
+	return func(config *Config) {
+		config.Guilds = guilds
+	}
+}
+
+// WithHeartbeatACK sets whether the Server acknowledges heartbeats.
+func WithHeartbeatACK(ack bool) ConfigOpt {
+	return func(config *Config) {
+		config.HeartbeatACK = ack
+	}
+}
@@ -0,0 +1,63 @@
+package gatewaytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+type receivedEvent struct {
+	eventType gateway.EventType
+	seq       int
+}
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := NewServer(WithToken("token"))
+	defer s.Close()
+
+	events := make(chan receivedEvent, 16)
+	g := gateway.New("token", func(eventType gateway.EventType, seq int, shardID int, event gateway.EventData) {
+		if eventType == gateway.EventTypeHeartbeatAck {
+			return
+		}
+		events <- receivedEvent{eventType: eventType, seq: seq}
+	}, nil, gateway.WithURL(s.URL))
+	defer g.Close(ctx)
+
+	require.NoError(t, g.Open(ctx))
+	identify, err := s.WaitForOpcode(ctx, gateway.OpcodeIdentify)
+	require.NoError(t, err)
+	assert.Equal(t, "token", identify.D.(gateway.MessageDataIdentify).Token)
+	require.NoError(t, s.WaitForShard(ctx, 0))
+	assert.Equal(t, receivedEvent{eventType: gateway.EventTypeReady, seq: 1}, <-events)
+
+	seq, err := s.Dispatch(0, gateway.EventTypeMessageCreate, json.RawMessage(`{"id":"1","channel_id":"2","content":"hi"}`))
+	require.NoError(t, err)
+	assert.Equal(t, receivedEvent{eventType: gateway.EventTypeMessageCreate, seq: seq}, <-events)
+
+	require.NoError(t, s.RequestHeartbeat(0))
+	heartbeat, err := s.WaitForOpcode(ctx, gateway.OpcodeHeartbeat)
+	require.NoError(t, err)
+	assert.Equal(t, gateway.MessageDataHeartbeat(seq), heartbeat.D)
+
+	require.NoError(t, s.Reconnect(0))
+	resume, err := s.WaitForOpcode(ctx, gateway.OpcodeResume)
+	require.NoError(t, err)
+	assert.Equal(t, gateway.MessageDataResume{Token: "token", SessionID: identify.SessionID, Seq: seq}, resume.D)
+	assert.Equal(t, gateway.EventTypeResumed, (<-events).eventType)
+
+	require.NoError(t, s.InvalidSession(0, false))
+	identify, err = s.WaitForOpcode(ctx, gateway.OpcodeIdentify)
+	require.NoError(t, err)
+	assert.NotEqual(t, resume.SessionID, identify.SessionID)
+	assert.Equal(t, receivedEvent{eventType: gateway.EventTypeReady, seq: 1}, <-events)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+)
+
+// maxBansPerRequest is the maximum number of bans returned by a single get guild bans request
+const maxBansPerRequest = 1000
+
+// backfillGuild fills the caches enabled in cache.Caches.BackfillFlags of a guild which just became ready from the REST API.
+// Caches for which the bot is missing the required permissions are skipped.
+func backfillGuild(client bot.Client, guildID snowflake.ID) {
+	flags := client.Caches().BackfillFlags() & client.Caches().CacheFlags()
+	logger := client.Logger().With(slog.String("name", "cache_backfill"), slog.String("guild_id", guildID.String()))
+
+	if flags.Has(cache.FlagAutoModerationRules) && hasGuildPermissions(client, guildID, discord.PermissionManageGuild) {
+		rules, err := client.Rest().GetAutoModerationRules(guildID)
+		if err != nil {
+			logger.Error("failed to backfill auto moderation rules", slog.Any("err", err))
+		}
+		for _, rule := range rules {
+			client.Caches().AddAutoModerationRule(rule)
+		}
+	}
+
+	if flags.Has(cache.FlagBans) && hasGuildPermissions(client, guildID, discord.PermissionBanMembers) {
+		var after snowflake.ID
+		for {
+			bans, err := client.Rest().GetBans(guildID, 0, after, maxBansPerRequest)
+			if err != nil {
+				logger.Error("failed to backfill bans", slog.Any("err", err))
+				break
+			}
+			for _, ban := range bans {
+				client.Caches().AddBan(guildID, ban)
+			}
+			if len(bans) < maxBansPerRequest {
+				break
+			}
+			after = bans[len(bans)-1].User.ID
+		}
+	}
+
+	if flags.Has(cache.FlagInvites) && hasGuildPermissions(client, guildID, discord.PermissionManageGuild) {
+		invites, err := client.Rest().GetGuildExtendedInvites(guildID)
+		if err != nil {
+			logger.Error("failed to backfill invites", slog.Any("err", err))
+		}
+		for _, invite := range invites {
+			client.Caches().AddInvite(guildID, invite)
+		}
+	}
+
+	if flags.Has(cache.FlagIntegrations) && hasGuildPermissions(client, guildID, discord.PermissionManageGuild) {
+		integrations, err := client.Rest().GetIntegrations(guildID)
+		if err != nil {
+			logger.Error("failed to backfill integrations", slog.Any("err", err))
+		}
+		for _, integration := range integrations {
+			client.Caches().AddIntegration(guildID, integration)
+		}
+	}
+
+	if flags.Has(cache.FlagWebhooks) && hasGuildPermissions(client, guildID, discord.PermissionManageWebhooks) {
+		webhooks, err := client.Rest().GetAllWebhooks(guildID)
+		if err != nil {
+			logger.Error("failed to backfill webhooks", slog.Any("err", err))
+		}
+		for _, webhook := range webhooks {
+			client.Caches().AddWebhook(guildID, webhook)
+		}
+	}
+}
+
+// backfillChannelWebhooks refetches the webhooks of a channel after a webhooks update.
+func backfillChannelWebhooks(client bot.Client, guildID snowflake.ID, channelID snowflake.ID) {
+	if !hasGuildPermissions(client, guildID, discord.PermissionManageWebhooks) {
+		return
+	}
+	webhooks, err := client.Rest().GetWebhooks(channelID)
+	if err != nil {
+		client.Logger().Error("failed to backfill channel webhooks", slog.String("channel_id", channelID.String()), slog.Any("err", err))
+		return
+	}
+	for _, webhook := range webhooks {
+		client.Caches().AddWebhook(guildID, webhook)
+	}
+}
+
+// hasGuildPermissions returns whether the bot has the permissions in the guild.
+// If the bot's member is not cached, its permissions are unknown & false is returned to not run into missing permission errors.
+func hasGuildPermissions(client bot.Client, guildID snowflake.ID, permissions discord.Permissions) bool {
+	member, ok := client.Caches().Member(guildID, client.ID())
+	if !ok {
+		return false
+	}
+	return client.Caches().MemberPermissions(member).Has(permissions)
+}
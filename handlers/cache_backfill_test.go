@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// backfillRest is a rest.Rest which returns the entities used to backfill a guild.
+type backfillRest struct {
+	rest.Rest
+	bans     []discord.Ban
+	requests []string
+}
+
+func (r *backfillRest) GetAutoModerationRules(guildID snowflake.ID, _ ...rest.RequestOpt) ([]discord.AutoModerationRule, error) {
+	r.requests = append(r.requests, "auto_moderation_rules")
+	return []discord.AutoModerationRule{{ID: 10, GuildID: guildID}}, nil
+}
+
+func (r *backfillRest) GetBans(_ snowflake.ID, _ snowflake.ID, after snowflake.ID, limit int, _ ...rest.RequestOpt) ([]discord.Ban, error) {
+	r.requests = append(r.requests, "bans")
+	var bans []discord.Ban
+	for _, ban := range r.bans {
+		if ban.User.ID > after && len(bans) < limit {
+			bans = append(bans, ban)
+		}
+	}
+	return bans, nil
+}
+
+func (r *backfillRest) GetGuildExtendedInvites(_ snowflake.ID, _ ...rest.RequestOpt) ([]discord.ExtendedInvite, error) {
+	r.requests = append(r.requests, "invites")
+	return []discord.ExtendedInvite{{Invite: discord.Invite{Code: "abc"}, Uses: 1}}, nil
+}
+
+func (r *backfillRest) GetIntegrations(_ snowflake.ID, _ ...rest.RequestOpt) ([]discord.Integration, error) {
+	r.requests = append(r.requests, "integrations")
+	return []discord.Integration{discord.BotIntegration{IntegrationID: 30}}, nil
+}
+
+func (r *backfillRest) GetAllWebhooks(_ snowflake.ID, _ ...rest.RequestOpt) ([]discord.Webhook, error) {
+	r.requests = append(r.requests, "webhooks")
+	return nil, nil
+}
+
+func newBackfillClient(permissions discord.Permissions, cacheMember bool) (*testClient, *backfillRest) {
+	caches := cache.New(cache.WithCaches(cache.FlagsAll), cache.WithBackfill(cache.FlagAutoModerationRules, cache.FlagBans, cache.FlagInvites, cache.FlagIntegrations))
+	caches.SetSelfUser(discord.OAuth2User{User: discord.User{ID: 2}})
+	caches.AddGuild(discord.Guild{ID: 1, OwnerID: 3})
+	caches.AddRole(discord.Role{ID: 1, GuildID: 1, Permissions: permissions})
+	if cacheMember {
+		caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 2}})
+	}
+
+	restClient := &backfillRest{}
+	for i := 1; i <= maxBansPerRequest+1; i++ {
+		restClient.bans = append(restClient.bans, discord.Ban{User: discord.User{ID: snowflake.ID(100 + i)}})
+	}
+	return &testClient{caches: caches, rest: restClient}, restClient
+}
+
+func TestBackfillGuild(t *testing.T) {
+	client, restClient := newBackfillClient(discord.PermissionManageGuild|discord.PermissionBanMembers, true)
+	backfillGuild(client, 1)
+
+	// webhooks are not in the backfill flags
+	assert.Equal(t, []string{"auto_moderation_rules", "bans", "bans", "invites", "integrations"}, restClient.requests)
+	assert.Equal(t, 1, client.caches.AutoModerationRulesLen(1))
+	assert.Equal(t, maxBansPerRequest+1, client.caches.BansLen(1), "all pages of bans should be fetched")
+	invite, ok := client.caches.Invite(1, "abc")
+	assert.True(t, ok)
+	assert.Equal(t, 1, invite.Uses)
+	assert.Equal(t, 1, client.caches.IntegrationsLen(1))
+}
+
+func TestBackfillGuildMissingPermissions(t *testing.T) {
+	client, restClient := newBackfillClient(discord.PermissionBanMembers, true)
+	backfillGuild(client, 1)
+
+	assert.Equal(t, []string{"bans", "bans"}, restClient.requests)
+	assert.Zero(t, client.caches.AutoModerationRulesLen(1))
+}
+
+func TestBackfillGuildUncachedMember(t *testing.T) {
+	client, restClient := newBackfillClient(discord.PermissionsAll, false)
+	backfillGuild(client, 1)
+
+	assert.Empty(t, restClient.requests, "nothing should be requested without knowing the bot's permissions")
+}
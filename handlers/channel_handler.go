@@ -10,6 +10,13 @@ import (
 )
 
 func gatewayHandlerChannelCreate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventChannelCreate) {
+	if event.PrivateChannel != nil {
+		client.Caches().AddPrivateChannel(event.PrivateChannel)
+		return
+	}
+	if event.GuildChannel == nil {
+		return
+	}
 	client.Caches().AddChannel(event.GuildChannel)
 
 	client.EventManager().DispatchEvent(&events.GuildChannelCreate{
@@ -23,6 +30,13 @@ func gatewayHandlerChannelCreate(client bot.Client, sequenceNumber int, shardID
 }
 
 func gatewayHandlerChannelUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventChannelUpdate) {
+	if event.PrivateChannel != nil {
+		client.Caches().AddPrivateChannel(event.PrivateChannel)
+		return
+	}
+	if event.GuildChannel == nil {
+		return
+	}
 	oldGuildChannel, _ := client.Caches().Channel(event.ID())
 	client.Caches().AddChannel(event.GuildChannel)
 
@@ -58,6 +72,13 @@ func gatewayHandlerChannelUpdate(client bot.Client, sequenceNumber int, shardID
 }
 
 func gatewayHandlerChannelDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventChannelDelete) {
+	if event.PrivateChannel != nil {
+		client.Caches().RemovePrivateChannel(event.PrivateChannel.ID())
+		return
+	}
+	if event.GuildChannel == nil {
+		return
+	}
 	client.Caches().RemoveChannel(event.ID())
 
 	client.EventManager().DispatchEvent(&events.GuildChannelDelete{
@@ -72,6 +93,9 @@ func gatewayHandlerChannelDelete(client bot.Client, sequenceNumber int, shardID
 
 func gatewayHandlerChannelPinsUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventChannelPinsUpdate) {
 	if event.GuildID == nil {
+		if channel, ok := client.Caches().PrivateChannel(event.ChannelID); ok {
+			client.Caches().AddPrivateChannel(discord.ApplyLastPinTimestampToPrivateChannel(channel, event.LastPinTimestamp))
+		}
 		client.EventManager().DispatchEvent(&events.DMChannelPinsUpdate{
 			GenericEvent:        events.NewGenericEvent(client, sequenceNumber, shardID),
 			ChannelID:           event.ChannelID,
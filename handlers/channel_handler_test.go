@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestPrivateChannelEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		create  string
+		update  string
+		channel discord.ChannelType
+	}{
+		{
+			name:    "dm",
+			create:  `{"id":"1","type":1,"recipients":[{"id":"3","username":"user"}]}`,
+			update:  `{"id":"1","type":1,"last_message_id":"2","recipients":[{"id":"3","username":"user"}]}`,
+			channel: discord.ChannelTypeDM,
+		},
+		{
+			name:    "group dm",
+			create:  `{"id":"1","type":3,"name":"group","recipients":[{"id":"3","username":"user"},{"id":"4","username":"other"}]}`,
+			update:  `{"id":"1","type":3,"name":"renamed","last_message_id":"2","recipients":[{"id":"3","username":"user"},{"id":"4","username":"other"}]}`,
+			channel: discord.ChannelTypeGroupDM,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &testClient{caches: cache.New(cache.WithCaches(cache.FlagsAll))}
+
+			var create gateway.EventChannelCreate
+			require.NoError(t, json.Unmarshal([]byte(tt.create), &create))
+			gatewayHandlerChannelCreate(client, 0, 0, create)
+			channel, ok := client.caches.PrivateChannel(1)
+			require.True(t, ok)
+			assert.Equal(t, tt.channel, channel.Type())
+			assert.Nil(t, channel.LastMessageID())
+
+			var update gateway.EventChannelUpdate
+			require.NoError(t, json.Unmarshal([]byte(tt.update), &update))
+			gatewayHandlerChannelUpdate(client, 0, 0, update)
+			channel, ok = client.caches.PrivateChannel(1)
+			require.True(t, ok)
+			assert.Equal(t, tt.channel, channel.Type())
+			require.NotNil(t, channel.LastMessageID())
+			assert.Equal(t, "2", channel.LastMessageID().String())
+			assert.Equal(t, update.PrivateChannel.Name(), channel.Name())
+
+			var del gateway.EventChannelDelete
+			require.NoError(t, json.Unmarshal([]byte(tt.update), &del))
+			gatewayHandlerChannelDelete(client, 0, 0, del)
+			_, ok = client.caches.PrivateChannel(1)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestUpdatePrivateChannel(t *testing.T) {
+	client := &testClient{caches: cache.New(cache.WithCaches(cache.FlagsAll))}
+	client.caches.SetSelfUser(discord.OAuth2User{User: discord.User{ID: 4}})
+
+	// the bot's own messages don't tell who the recipient is
+	updatePrivateChannel(client, discord.Message{ID: 2, ChannelID: 1, Author: discord.User{ID: 4}})
+	_, ok := client.caches.PrivateChannel(1)
+	assert.False(t, ok, "unknown channels should not be cached from the bot's own messages")
+
+	updatePrivateChannel(client, discord.Message{ID: 2, ChannelID: 1, Author: discord.User{ID: 3, Username: "user"}})
+	channel, ok := client.caches.PrivateChannel(1)
+	require.True(t, ok)
+	require.IsType(t, discord.DMChannel{}, channel)
+	recipient, ok := channel.(discord.DMChannel).Recipient()
+	require.True(t, ok)
+	assert.Equal(t, discord.User{ID: 3, Username: "user"}, recipient)
+	require.NotNil(t, channel.LastMessageID())
+	assert.Equal(t, "2", channel.LastMessageID().String())
+
+	updatePrivateChannel(client, discord.Message{ID: 5, ChannelID: 1, Author: discord.User{ID: 4}})
+	channel, ok = client.caches.PrivateChannel(1)
+	require.True(t, ok)
+	require.NotNil(t, channel.LastMessageID())
+	assert.Equal(t, "5", channel.LastMessageID().String(), "the bot's own messages should update known channels")
+}
@@ -7,6 +7,8 @@ import (
 )
 
 func gatewayHandlerAutoModerationRuleCreate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventAutoModerationRuleCreate) {
+	client.Caches().AddAutoModerationRule(event.AutoModerationRule)
+
 	client.EventManager().DispatchEvent(&events.AutoModerationRuleCreate{
 		GenericAutoModerationRule: &events.GenericAutoModerationRule{
 			GenericEvent:       events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -16,6 +18,8 @@ func gatewayHandlerAutoModerationRuleCreate(client bot.Client, sequenceNumber in
 }
 
 func gatewayHandlerAutoModerationRuleUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventAutoModerationRuleUpdate) {
+	client.Caches().AddAutoModerationRule(event.AutoModerationRule)
+
 	client.EventManager().DispatchEvent(&events.AutoModerationRuleUpdate{
 		GenericAutoModerationRule: &events.GenericAutoModerationRule{
 			GenericEvent:       events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -25,6 +29,8 @@ func gatewayHandlerAutoModerationRuleUpdate(client bot.Client, sequenceNumber in
 }
 
 func gatewayHandlerAutoModerationRuleDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventAutoModerationRuleDelete) {
+	client.Caches().RemoveAutoModerationRule(event.GuildID, event.ID)
+
 	client.EventManager().DispatchEvent(&events.AutoModerationRuleDelete{
 		GenericAutoModerationRule: &events.GenericAutoModerationRule{
 			GenericEvent:       events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -7,6 +7,11 @@ import (
 )
 
 func gatewayHandlerGuildBanAdd(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildBanAdd) {
+	// the event doesn't contain the reason, keep the one of an already cached ban
+	ban, _ := client.Caches().Ban(event.GuildID, event.User.ID)
+	ban.User = event.User
+	client.Caches().AddBan(event.GuildID, ban)
+
 	client.EventManager().DispatchEvent(&events.GuildBan{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
 		GuildID:      event.GuildID,
@@ -15,6 +20,8 @@ func gatewayHandlerGuildBanAdd(client bot.Client, sequenceNumber int, shardID in
 }
 
 func gatewayHandlerGuildBanRemove(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildBanRemove) {
+	client.Caches().RemoveBan(event.GuildID, event.User.ID)
+
 	client.EventManager().DispatchEvent(&events.GuildUnban{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
 		GuildID:      event.GuildID,
@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"log/slog"
+
+	"github.com/disgoorg/snowflake/v2"
+
 	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
@@ -11,6 +16,10 @@ func gatewayHandlerGuildCreate(client bot.Client, sequenceNumber int, shardID in
 	wasUnready := client.Caches().IsGuildUnready(event.ID)
 	wasUnavailable := client.Caches().IsGuildUnavailable(event.ID)
 
+	if client.Caches().IsGuildStale(event.ID) {
+		refreshStaleGuild(client, event)
+	}
+
 	client.Caches().AddGuild(event.Guild)
 
 	for _, channel := range event.Channels {
@@ -77,10 +86,13 @@ func gatewayHandlerGuildCreate(client bot.Client, sequenceNumber int, shardID in
 				GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
 			})
 		}
+		if client.Caches().BackfillFlags() != cache.FlagsNone {
+			go backfillGuild(client, event.ID)
+		}
 		if client.MemberChunkingManager().MemberChunkingFilter()(event.ID) {
 			go func() {
 				if _, err := client.MemberChunkingManager().RequestMembersWithQuery(event.ID, "", 0); err != nil {
-					client.Logger().Error("failed to chunk guild on guild_create. error: ", err)
+					client.Logger().Error("failed to chunk guild on guild_create", slog.Any("err", err))
 				}
 			}()
 		}
@@ -112,21 +124,7 @@ func gatewayHandlerGuildUpdate(client bot.Client, sequenceNumber int, shardID in
 }
 
 func gatewayHandlerGuildDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildDelete) {
-	guild, _ := client.Caches().RemoveGuild(event.ID)
-	client.Caches().RemoveVoiceStatesByGuildID(event.ID)
-	client.Caches().RemovePresencesByGuildID(event.ID)
-	// TODO: figure out a better way to remove thread members from cache via guild id without requiring cached GuildThreads
-	client.Caches().ChannelsForEach(func(channel discord.GuildChannel) {
-		if guildThread, ok := channel.(discord.GuildThread); ok && guildThread.GuildID() == event.ID {
-			client.Caches().RemoveThreadMembersByThreadID(guildThread.ID())
-		}
-	})
-	client.Caches().RemoveChannelsByGuildID(event.ID)
-	client.Caches().RemoveEmojisByGuildID(event.ID)
-	client.Caches().RemoveStickersByGuildID(event.ID)
-	client.Caches().RemoveRolesByGuildID(event.ID)
-	client.Caches().RemoveStageInstancesByGuildID(event.ID)
-	client.Caches().RemoveMessagesByGuildID(event.ID)
+	guild := removeGuild(client, event.ID)
 
 	if event.Unavailable {
 		client.Caches().SetGuildUnavailable(event.ID, true)
@@ -149,6 +147,73 @@ func gatewayHandlerGuildDelete(client bot.Client, sequenceNumber int, shardID in
 	}
 }
 
+// removeGuild removes the guild and all of its entities from the caches.
+func removeGuild(client bot.Client, guildID snowflake.ID) discord.Guild {
+	guild, _ := client.Caches().RemoveGuild(guildID)
+	client.Caches().SetGuildStale(guildID, false)
+	client.Caches().RemoveVoiceStatesByGuildID(guildID)
+	client.Caches().RemovePresencesByGuildID(guildID)
+	removeGuildThreadMembers(client, guildID)
+	client.Caches().RemoveChannelsByGuildID(guildID)
+	client.Caches().RemoveEmojisByGuildID(guildID)
+	client.Caches().RemoveStickersByGuildID(guildID)
+	client.Caches().RemoveRolesByGuildID(guildID)
+	client.Caches().RemoveStageInstancesByGuildID(guildID)
+	client.Caches().RemoveMessagesByGuildID(guildID)
+	client.Caches().RemoveAutoModerationRulesByGuildID(guildID)
+	client.Caches().RemoveBansByGuildID(guildID)
+	client.Caches().RemoveInvitesByGuildID(guildID)
+	client.Caches().RemoveIntegrationsByGuildID(guildID)
+	client.Caches().RemoveWebhooksByGuildID(guildID)
+	return guild
+}
+
+// refreshStaleGuild removes the entities of a guild restored from a snapshot which are sent completely in the guild create again.
+// Messages of channels which were deleted while offline are removed, the other messages are kept.
+// Members & presences are only sent partially, so they are kept & updated by the following events.
+func refreshStaleGuild(client bot.Client, event gateway.EventGuildCreate) {
+	guildID := event.ID
+	client.Caches().SetGuildStale(guildID, false)
+
+	channelIDs := make(map[snowflake.ID]struct{}, len(event.Channels)+len(event.Threads))
+	for _, channel := range event.Channels {
+		channelIDs[channel.ID()] = struct{}{}
+	}
+	for _, thread := range event.Threads {
+		channelIDs[thread.ID()] = struct{}{}
+	}
+	var deletedChannelIDs []snowflake.ID
+	client.Caches().ChannelsForEach(func(channel discord.GuildChannel) {
+		if channel.GuildID() != guildID {
+			return
+		}
+		if _, ok := channelIDs[channel.ID()]; !ok {
+			deletedChannelIDs = append(deletedChannelIDs, channel.ID())
+		}
+	})
+	for _, channelID := range deletedChannelIDs {
+		client.Caches().RemoveMessagesByChannelID(channelID)
+	}
+
+	client.Caches().RemoveVoiceStatesByGuildID(guildID)
+	removeGuildThreadMembers(client, guildID)
+	client.Caches().RemoveChannelsByGuildID(guildID)
+	client.Caches().RemoveEmojisByGuildID(guildID)
+	client.Caches().RemoveStickersByGuildID(guildID)
+	client.Caches().RemoveRolesByGuildID(guildID)
+	client.Caches().RemoveStageInstancesByGuildID(guildID)
+	client.Caches().RemoveGuildScheduledEventsByGuildID(guildID)
+}
+
+func removeGuildThreadMembers(client bot.Client, guildID snowflake.ID) {
+	// TODO: figure out a better way to remove thread members from cache via guild id without requiring cached GuildThreads
+	client.Caches().ChannelsForEach(func(channel discord.GuildChannel) {
+		if guildThread, ok := channel.(discord.GuildThread); ok && guildThread.GuildID() == guildID {
+			client.Caches().RemoveThreadMembersByThreadID(guildThread.ID())
+		}
+	})
+}
+
 func gatewayHandlerGuildAuditLogEntryCreate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildAuditLogEntryCreate) {
 	client.EventManager().DispatchEvent(&events.GuildAuditLogEntryCreate{
 		GenericEvent:  events.NewGenericEvent(client, sequenceNumber, shardID),
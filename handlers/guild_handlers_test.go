@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// testClient is a bot.Client which only provides its cache.Caches, rest.Rest & a discarding logger.
+type testClient struct {
+	bot.Client
+	caches cache.Caches
+	rest   rest.Rest
+}
+
+func (c *testClient) Caches() cache.Caches {
+	return c.caches
+}
+
+func (c *testClient) Rest() rest.Rest {
+	return c.rest
+}
+
+func (c *testClient) Logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func (c *testClient) ID() snowflake.ID {
+	if selfUser, ok := c.caches.SelfUser(); ok {
+		return selfUser.ID
+	}
+	return 0
+}
+
+func testGuildChannel(t *testing.T, data string) discord.GuildChannel {
+	t.Helper()
+	var channel discord.UnmarshalChannel
+	require.NoError(t, json.Unmarshal([]byte(data), &channel))
+	return channel.Channel.(discord.GuildChannel)
+}
+
+func TestRefreshStaleGuild(t *testing.T) {
+	general := testGuildChannel(t, `{"id":"10","guild_id":"1","type":0,"name":"general"}`)
+	deleted := testGuildChannel(t, `{"id":"11","guild_id":"1","type":0,"name":"deleted"}`)
+
+	caches := cache.New(cache.WithCaches(cache.FlagsAll))
+	caches.AddGuild(discord.Guild{ID: 1})
+	caches.AddChannel(general)
+	caches.AddChannel(deleted)
+	caches.AddRole(discord.Role{ID: 20, GuildID: 1})
+	caches.AddMessage(discord.Message{ID: 100, ChannelID: 10})
+	caches.AddMessage(discord.Message{ID: 101, ChannelID: 11})
+
+	var buf bytes.Buffer
+	require.NoError(t, caches.ExportSnapshot(&buf))
+	restored := cache.New(cache.WithCaches(cache.FlagsAll))
+	require.NoError(t, restored.ImportSnapshot(&buf))
+	require.True(t, restored.IsGuildStale(1))
+
+	// channel 11 was deleted while the bot was offline
+	refreshStaleGuild(&testClient{caches: restored}, gateway.EventGuildCreate{
+		GatewayGuild: discord.GatewayGuild{
+			RestGuild: discord.RestGuild{Guild: discord.Guild{ID: 1}},
+			Channels:  []discord.GuildChannel{general},
+		},
+	})
+
+	assert.False(t, restored.IsGuildStale(1))
+	_, ok := restored.Message(10, 100)
+	assert.True(t, ok, "messages of existing channels should be kept")
+	_, ok = restored.Message(11, 101)
+	assert.False(t, ok, "messages of deleted channels should be removed")
+	_, ok = restored.Role(1, 20)
+	assert.False(t, ok, "roles are sent again in the guild create")
+}
@@ -7,6 +7,8 @@ import (
 )
 
 func gatewayHandlerIntegrationCreate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventIntegrationCreate) {
+	client.Caches().AddIntegration(event.GuildID, event.Integration)
+
 	client.EventManager().DispatchEvent(&events.IntegrationCreate{
 		GenericIntegration: &events.GenericIntegration{
 			GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -17,6 +19,8 @@ func gatewayHandlerIntegrationCreate(client bot.Client, sequenceNumber int, shar
 }
 
 func gatewayHandlerIntegrationUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventIntegrationUpdate) {
+	client.Caches().AddIntegration(event.GuildID, event.Integration)
+
 	client.EventManager().DispatchEvent(&events.IntegrationUpdate{
 		GenericIntegration: &events.GenericIntegration{
 			GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -27,6 +31,8 @@ func gatewayHandlerIntegrationUpdate(client bot.Client, sequenceNumber int, shar
 }
 
 func gatewayHandlerIntegrationDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventIntegrationDelete) {
+	client.Caches().RemoveIntegration(event.GuildID, event.ID)
+
 	client.EventManager().DispatchEvent(&events.IntegrationDelete{
 		GenericEvent:  events.NewGenericEvent(client, sequenceNumber, shardID),
 		GuildID:       event.GuildID,
@@ -32,6 +32,13 @@ func respond(client bot.Client, respondFunc httpserver.RespondFunc, interaction
 func handleInteraction(client bot.Client, sequenceNumber int, shardID int, respondFunc httpserver.RespondFunc, interaction discord.Interaction) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
+	// only cache DMs between the bot & the user, user installed apps also receive interactions from DMs between other users
+	if channel, ok := interaction.Channel().MessageChannel.(discord.DMChannel); ok && interaction.GuildID() == nil {
+		if recipient, ok := channel.Recipient(); ok && recipient.ID == interaction.User().ID {
+			client.Caches().AddPrivateChannel(channel)
+		}
+	}
+
 	client.EventManager().DispatchEvent(&events.InteractionCreate{
 		GenericEvent: genericEvent,
 		Interaction:  interaction,
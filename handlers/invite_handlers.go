@@ -1,18 +1,19 @@
 package handlers
 
 import (
-	"github.com/disgoorg/snowflake/v2"
-
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
 )
 
 func gatewayHandlerInviteCreate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventInviteCreate) {
-	var guildID *snowflake.ID
-	if event.Guild != nil {
+	guildID := event.GuildID
+	if guildID == nil && event.Guild != nil {
 		guildID = &event.Guild.ID
 	}
+	if guildID != nil {
+		client.Caches().AddInvite(*guildID, event.ExtendedInvite())
+	}
 
 	client.EventManager().DispatchEvent(&events.InviteCreate{
 		GenericInvite: &events.GenericInvite{
@@ -26,6 +27,10 @@ func gatewayHandlerInviteCreate(client bot.Client, sequenceNumber int, shardID i
 }
 
 func gatewayHandlerInviteDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventInviteDelete) {
+	if event.GuildID != nil {
+		client.Caches().RemoveInvite(*event.GuildID, event.Code)
+	}
+
 	client.EventManager().DispatchEvent(&events.InviteDelete{
 		GenericInvite: &events.GenericInvite{
 			GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
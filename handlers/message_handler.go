@@ -31,6 +31,10 @@ func gatewayHandlerMessageCreate(client bot.Client, sequenceNumber int, shardID
 		client.Caches().AddChannel(channel)
 	}
 
+	if event.GuildID == nil {
+		updatePrivateChannel(client, event.Message)
+	}
+
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 	client.EventManager().DispatchEvent(&events.MessageCreate{
 		GenericMessage: &events.GenericMessage{
@@ -157,3 +161,17 @@ func handleMessageDelete(client bot.Client, sequenceNumber int, shardID int, mes
 		})
 	}
 }
+
+// updatePrivateChannel caches the discord.DMChannel of a message sent outside a guild or updates the last message ID of the cached discord.PrivateChannel.
+// Bots don't receive CHANNEL_CREATE for DMs & can't be in group DMs, so an unknown channel is a discord.DMChannel with the author as recipient.
+// The bot's own messages don't tell who the recipient is, so they only update known channels.
+func updatePrivateChannel(client bot.Client, message discord.Message) {
+	if channel, ok := client.Caches().PrivateChannel(message.ChannelID); ok {
+		client.Caches().AddPrivateChannel(discord.ApplyLastMessageIDToPrivateChannel(channel, message.ID))
+		return
+	}
+	if message.Author.ID == client.ID() {
+		return
+	}
+	client.Caches().AddPrivateChannel(discord.ApplyLastMessageIDToPrivateChannel(discord.NewDMChannel(message.ChannelID, message.Author), message.ID))
+}
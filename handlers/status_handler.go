@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"github.com/disgoorg/snowflake/v2"
+
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/sharding"
 )
 
 func gatewayHandlerRaw(client bot.Client, sequenceNumber int, shardID int, event gateway.EventRaw) {
@@ -23,10 +26,19 @@ func gatewayHandlerHeartbeatAck(client bot.Client, sequenceNumber int, shardID i
 func gatewayHandlerReady(client bot.Client, sequenceNumber int, shardID int, event gateway.EventReady) {
 	client.Caches().SetSelfUser(event.User)
 
+	guildIDs := make(map[snowflake.ID]struct{}, len(event.Guilds))
 	for _, guild := range event.Guilds {
+		guildIDs[guild.ID] = struct{}{}
 		client.Caches().SetGuildUnready(guild.ID, true)
 	}
 
+	// remove the guilds restored from a snapshot which we left in the meantime
+	for _, guildID := range client.Caches().StaleGuildIDs() {
+		if _, ok := guildIDs[guildID]; !ok && guildOnShard(client, guildID, shardID) {
+			removeGuild(client, guildID)
+		}
+	}
+
 	client.EventManager().DispatchEvent(&events.Ready{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
 		EventReady:   event,
@@ -34,7 +46,26 @@ func gatewayHandlerReady(client bot.Client, sequenceNumber int, shardID int, eve
 }
 
 func gatewayHandlerResumed(client bot.Client, sequenceNumber int, shardID int, _ gateway.EventData) {
+	// the missed events were replayed, so the guilds restored from a snapshot are up-to-date
+	for _, guildID := range client.Caches().StaleGuildIDs() {
+		if guildOnShard(client, guildID, shardID) {
+			client.Caches().SetGuildStale(guildID, false)
+		}
+	}
+
 	client.EventManager().DispatchEvent(&events.Resumed{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
 	})
 }
+
+// guildOnShard returns whether the guild belongs to the given shard.
+func guildOnShard(client bot.Client, guildID snowflake.ID, shardID int) bool {
+	if !client.HasShardManager() {
+		return true
+	}
+	shard := client.ShardManager().Shard(shardID)
+	if shard == nil {
+		return true
+	}
+	return sharding.ShardIDByGuild(guildID, shard.ShardCount()) == shardID
+}
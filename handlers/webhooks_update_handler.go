@@ -2,11 +2,18 @@ package handlers
 
 import (
 	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
 )
 
 func gatewayHandlerWebhooksUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventWebhooksUpdate) {
+	// the event doesn't tell what changed, so drop the webhooks of the channel and refetch them if requested
+	client.Caches().RemoveWebhooksByChannelID(event.GuildID, event.ChannelID)
+	if client.Caches().BackfillFlags().Has(cache.FlagWebhooks) && client.Caches().CacheFlags().Has(cache.FlagWebhooks) {
+		go backfillChannelWebhooks(client, event.GuildID, event.ChannelID)
+	}
+
 	client.EventManager().DispatchEvent(&events.WebhooksUpdate{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
 		GuildId:      event.GuildID,
@@ -121,7 +121,7 @@ func HandleInteraction(publicKey PublicKey, logger *slog.Logger, handleFunc Even
 
 		var v EventInteractionCreate
 		if err := json.NewDecoder(buff).Decode(&v); err != nil {
-			logger.Error("error while decoding interaction: ", err)
+			logger.Error("error while decoding interaction", slog.Any("err", err))
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			return
 		}
@@ -187,7 +187,10 @@ func HandleInteraction(publicKey PublicKey, logger *slog.Logger, handleFunc Even
 		rsBody := &bytes.Buffer{}
 		multiWriter := io.MultiWriter(w, rsBody)
 
-		if multiPart, ok := body.(*discord.MultipartBuffer); ok {
+		if multiPart, ok := body.(*discord.MultipartBody); ok {
+			w.Header().Set("Content-Type", multiPart.ContentType)
+			_, err = multiPart.WriteTo(multiWriter)
+		} else if multiPart, ok := body.(*discord.MultipartBuffer); ok {
 			w.Header().Set("Content-Type", multiPart.ContentType)
 			_, err = io.Copy(multiWriter, multiPart.Buffer)
 		} else {
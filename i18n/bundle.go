@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// NewBundle returns a new empty Bundle which falls back to the given default discord.Locale.
+func NewBundle(defaultLocale discord.Locale) *Bundle {
+	return &Bundle{
+		defaultLocale: defaultLocale,
+		catalogs:      map[discord.Locale]map[string]Message{},
+	}
+}
+
+// Bundle holds the Message(s) of all discord.Locale(s) keyed by their message key.
+// Keys of nested catalogs are joined with a dot, for example "commands.ping.name".
+//
+// Translations are looked up in the requested discord.Locale, then in other loaded locales of the same language
+// (for example en-GB for en-US) and at last in the default discord.Locale.
+// If no translation exists at all, the key itself is returned.
+type Bundle struct {
+	defaultLocale discord.Locale
+
+	mu       sync.RWMutex
+	catalogs map[discord.Locale]map[string]Message
+}
+
+// DefaultLocale returns the discord.Locale which is used if a translation is missing.
+func (b *Bundle) DefaultLocale() discord.Locale {
+	return b.defaultLocale
+}
+
+// Locales returns all discord.Locale(s) which have Message(s) in the Bundle.
+func (b *Bundle) Locales() []discord.Locale {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	locales := make([]discord.Locale, 0, len(b.catalogs))
+	for locale := range b.catalogs {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// AddMessages adds the Message(s) to the catalog of the discord.Locale. Existing keys are overwritten.
+func (b *Bundle) AddMessages(locale discord.Locale, messages map[string]Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	catalog, ok := b.catalogs[locale]
+	if !ok {
+		catalog = make(map[string]Message, len(messages))
+		b.catalogs[locale] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+// Message returns the Message for the key in exactly this discord.Locale without any fallback.
+func (b *Bundle) Message(locale discord.Locale, key string) (Message, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	message, ok := b.catalogs[locale][key]
+	return message, ok
+}
+
+// Lookup returns the Message for the key with the fallback described on Bundle and the discord.Locale it was found in.
+// The discord.Locale(s) are tried in order before falling back to the default discord.Locale.
+func (b *Bundle) Lookup(key string, locales ...discord.Locale) (Message, discord.Locale, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, locale := range locales {
+		if message, ok := b.catalogs[locale][key]; ok {
+			return message, locale, true
+		}
+	}
+	for _, locale := range locales {
+		if message, found, ok := b.lookupLanguage(locale, key); ok {
+			return message, found, true
+		}
+	}
+	if message, ok := b.catalogs[b.defaultLocale][key]; ok {
+		return message, b.defaultLocale, true
+	}
+	return Message{}, "", false
+}
+
+func (b *Bundle) lookupLanguage(locale discord.Locale, key string) (Message, discord.Locale, bool) {
+	lang := language(locale)
+	var (
+		found   discord.Locale
+		message Message
+	)
+	for catalogLocale, catalog := range b.catalogs {
+		if catalogLocale == locale || language(catalogLocale) != lang {
+			continue
+		}
+		// pick the smallest matching locale to be deterministic
+		if m, ok := catalog[key]; ok && (found == "" || catalogLocale < found) {
+			found = catalogLocale
+			message = m
+		}
+	}
+	return message, found, found != ""
+}
+
+// Translate returns the translation of the key in the discord.Locale with the placeholders replaced by vars.
+func (b *Bundle) Translate(locale discord.Locale, key string, vars Vars) string {
+	return b.translate([]discord.Locale{locale}, key, -1, vars)
+}
+
+// TranslatePlural returns the plural form of the key for count in the discord.Locale.
+// The placeholder {count} is replaced by count unless vars contains it.
+func (b *Bundle) TranslatePlural(locale discord.Locale, key string, count int, vars Vars) string {
+	return b.translate([]discord.Locale{locale}, key, count, vars)
+}
+
+// Localizations returns the translations of the key in all discord.Locale(s) except the default discord.Locale.
+// This is the format used by the name & description localizations of application commands.
+// Nil is returned if there are no translations.
+func (b *Bundle) Localizations(key string, vars Vars) map[discord.Locale]string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var localizations map[discord.Locale]string
+	for locale, catalog := range b.catalogs {
+		if locale == b.defaultLocale {
+			continue
+		}
+		message, ok := catalog[key]
+		if !ok {
+			continue
+		}
+		if localizations == nil {
+			localizations = map[discord.Locale]string{}
+		}
+		localizations[locale] = Format(message.Other, vars)
+	}
+	return localizations
+}
+
+// translate looks up the key and formats it. A negative count means the Message is used without plural forms.
+func (b *Bundle) translate(locales []discord.Locale, key string, count int, vars Vars) string {
+	message, locale, ok := b.Lookup(key, locales...)
+	if !ok {
+		return key
+	}
+	if count < 0 {
+		return Format(message.Other, vars)
+	}
+
+	text := message.Form(PluralFormFor(locale, count))
+	if count == 0 && message.Zero != "" {
+		text = message.Zero
+	}
+	if _, ok = vars["count"]; !ok {
+		withCount := make(Vars, len(vars)+1)
+		for name, value := range vars {
+			withCount[name] = value
+		}
+		withCount["count"] = strconv.Itoa(count)
+		vars = withCount
+	}
+	return Format(text, vars)
+}
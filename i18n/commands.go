@@ -0,0 +1,150 @@
+package i18n
+
+import (
+	"github.com/disgoorg/disgo/discord"
+)
+
+// LocalizeCommands returns copies of the discord.ApplicationCommandCreate(s) with their name & description localizations filled from the Bundle.
+// The keys are derived from the names of the commands, options and choices:
+//
+//	commands.<command>.name
+//	commands.<command>.description
+//	commands.<command>.options.<option>.name
+//	commands.<command>.options.<option>.description
+//	commands.<command>.options.<option>.choices.<choice>
+//	commands.<command>.options.<subcommand>.options.<option>.name
+//
+// Localizations which are already set are kept. Commands which are not a value of
+// discord.SlashCommandCreate, discord.UserCommandCreate or discord.MessageCommandCreate are returned as they are.
+func (b *Bundle) LocalizeCommands(commands []discord.ApplicationCommandCreate) []discord.ApplicationCommandCreate {
+	localized := make([]discord.ApplicationCommandCreate, len(commands))
+	for i, command := range commands {
+		localized[i] = b.localizeCommand(command)
+	}
+	return localized
+}
+
+func (b *Bundle) localizeCommand(command discord.ApplicationCommandCreate) discord.ApplicationCommandCreate {
+	key := "commands." + command.CommandName()
+	switch c := command.(type) {
+	case discord.SlashCommandCreate:
+		c.NameLocalizations = b.mergeLocalizations(c.NameLocalizations, key+".name")
+		c.DescriptionLocalizations = b.mergeLocalizations(c.DescriptionLocalizations, key+".description")
+		c.Options = b.localizeOptions(key, c.Options)
+		return c
+	case discord.UserCommandCreate:
+		c.NameLocalizations = b.mergeLocalizations(c.NameLocalizations, key+".name")
+		return c
+	case discord.MessageCommandCreate:
+		c.NameLocalizations = b.mergeLocalizations(c.NameLocalizations, key+".name")
+		return c
+	}
+	return command
+}
+
+func (b *Bundle) localizeOptions(prefix string, options []discord.ApplicationCommandOption) []discord.ApplicationCommandOption {
+	if options == nil {
+		return nil
+	}
+	localized := make([]discord.ApplicationCommandOption, len(options))
+	for i, option := range options {
+		localized[i] = b.localizeOption(prefix+".options."+option.OptionName(), option)
+	}
+	return localized
+}
+
+func (b *Bundle) localizeOption(key string, option discord.ApplicationCommandOption) discord.ApplicationCommandOption {
+	name := key + ".name"
+	description := key + ".description"
+	switch o := option.(type) {
+	case discord.ApplicationCommandOptionSubCommand:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		o.Options = b.localizeOptions(key, o.Options)
+		return o
+	case discord.ApplicationCommandOptionSubCommandGroup:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		if o.Options != nil {
+			subCommands := make([]discord.ApplicationCommandOptionSubCommand, len(o.Options))
+			for i, subCommand := range o.Options {
+				subCommands[i] = b.localizeOption(key+".options."+subCommand.Name, subCommand).(discord.ApplicationCommandOptionSubCommand)
+			}
+			o.Options = subCommands
+		}
+		return o
+	case discord.ApplicationCommandOptionString:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		if o.Choices != nil {
+			choices := make([]discord.ApplicationCommandOptionChoiceString, len(o.Choices))
+			for i, choice := range o.Choices {
+				choice.NameLocalizations = b.mergeLocalizations(choice.NameLocalizations, key+".choices."+choice.Name)
+				choices[i] = choice
+			}
+			o.Choices = choices
+		}
+		return o
+	case discord.ApplicationCommandOptionInt:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		if o.Choices != nil {
+			choices := make([]discord.ApplicationCommandOptionChoiceInt, len(o.Choices))
+			for i, choice := range o.Choices {
+				choice.NameLocalizations = b.mergeLocalizations(choice.NameLocalizations, key+".choices."+choice.Name)
+				choices[i] = choice
+			}
+			o.Choices = choices
+		}
+		return o
+	case discord.ApplicationCommandOptionFloat:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		if o.Choices != nil {
+			choices := make([]discord.ApplicationCommandOptionChoiceFloat, len(o.Choices))
+			for i, choice := range o.Choices {
+				choice.NameLocalizations = b.mergeLocalizations(choice.NameLocalizations, key+".choices."+choice.Name)
+				choices[i] = choice
+			}
+			o.Choices = choices
+		}
+		return o
+	case discord.ApplicationCommandOptionBool:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		return o
+	case discord.ApplicationCommandOptionUser:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		return o
+	case discord.ApplicationCommandOptionChannel:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		return o
+	case discord.ApplicationCommandOptionRole:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		return o
+	case discord.ApplicationCommandOptionMentionable:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		return o
+	case discord.ApplicationCommandOptionAttachment:
+		o.NameLocalizations = b.mergeLocalizations(o.NameLocalizations, name)
+		o.DescriptionLocalizations = b.mergeLocalizations(o.DescriptionLocalizations, description)
+		return o
+	}
+	return option
+}
+
+// mergeLocalizations returns a copy of existing with the missing Localizations of the key added.
+func (b *Bundle) mergeLocalizations(existing map[discord.Locale]string, key string) map[discord.Locale]string {
+	localizations := b.Localizations(key, nil)
+	if localizations == nil {
+		return existing
+	}
+	for locale, text := range existing {
+		localizations[locale] = text
+	}
+	return localizations
+}
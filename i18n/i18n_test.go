@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+const testCatalogEN = `{
+	"greeting": "Hello {name}!",
+	"apples": {"zero": "no apples", "one": "{count} apple", "other": "{count} apples"},
+	"commands": {"ping": {"name": "ping", "description": "Replies with pong"}}
+}`
+
+const testCatalogRU = `
+# comment
+greeting = "Привет, {name}!"
+apples = { one = "{count} яблоко", few = "{count} яблока", many = "{count} яблок", other = "{count} яблока" }
+
+[commands.ping]
+name = 'пинг'
+description = """
+Отвечает понгом"""
+"options".target.name = "цель"
+`
+
+func newTestBundle(t *testing.T) *Bundle {
+	b := NewBundle(discord.LocaleEnglishUS)
+	require.NoError(t, b.LoadJSON(discord.LocaleEnglishUS, []byte(testCatalogEN)))
+	require.NoError(t, b.LoadTOML(discord.LocaleRussian, []byte(testCatalogRU)))
+	return b
+}
+
+func TestBundleTranslate(t *testing.T) {
+	b := newTestBundle(t)
+
+	assert.Equal(t, "Привет, Anna!", b.Translate(discord.LocaleRussian, "greeting", Vars{"name": "Anna"}))
+	assert.Equal(t, "Hello Anna!", b.Translate(discord.LocaleGerman, "greeting", Vars{"name": "Anna"}))
+	assert.Equal(t, "Hello {name}!", b.Translate(discord.LocaleEnglishGB, "greeting", nil))
+	assert.Equal(t, "missing.key", b.Translate(discord.LocaleRussian, "missing.key", nil))
+
+	assert.Equal(t, "no apples", b.TranslatePlural(discord.LocaleEnglishUS, "apples", 0, nil))
+	assert.Equal(t, "1 apple", b.TranslatePlural(discord.LocaleEnglishUS, "apples", 1, nil))
+	assert.Equal(t, "21 яблоко", b.TranslatePlural(discord.LocaleRussian, "apples", 21, nil))
+	assert.Equal(t, "3 яблока", b.TranslatePlural(discord.LocaleRussian, "apples", 3, nil))
+	assert.Equal(t, "11 яблок", b.TranslatePlural(discord.LocaleRussian, "apples", 11, nil))
+
+	l := b.Localizer(discord.LocaleGerman, discord.LocaleRussian)
+	assert.Equal(t, "Привет, Anna!", l.Translate("greeting", Vars{"name": "Anna"}))
+}
+
+func TestBundleLocalizeCommands(t *testing.T) {
+	b := newTestBundle(t)
+
+	commands := b.LocalizeCommands([]discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:        "ping",
+			Description: "Replies with pong",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionUser{
+					Name:              "target",
+					Description:       "The user to ping",
+					NameLocalizations: map[discord.Locale]string{discord.LocaleGerman: "ziel"},
+				},
+			},
+		},
+	})
+
+	command := commands[0].(discord.SlashCommandCreate)
+	assert.Equal(t, map[discord.Locale]string{discord.LocaleRussian: "пинг"}, command.NameLocalizations)
+	assert.Equal(t, map[discord.Locale]string{discord.LocaleRussian: "Отвечает понгом"}, command.DescriptionLocalizations)
+	assert.Equal(t, map[discord.Locale]string{
+		discord.LocaleRussian: "цель",
+		discord.LocaleGerman:  "ziel",
+	}, command.Options[0].(discord.ApplicationCommandOptionUser).NameLocalizations)
+	assert.Nil(t, command.Options[0].(discord.ApplicationCommandOptionUser).DescriptionLocalizations)
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	for _, data := range []string{
+		"key = 1",
+		"key = \"unterminated",
+		"[[array]]",
+		"key = \"a\"\nkey = \"b\"",
+		"key = \"a\"\n[key]",
+	} {
+		_, err := parseTOML(data)
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,132 @@
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// LoadJSON loads a JSON catalog into the discord.Locale.
+// Keys map to strings or to nested objects. Objects whose keys are all PluralForm(s) and contain "other" are plural Message(s).
+//
+//	{
+//		"greeting": "Hello {name}!",
+//		"items": {"one": "{count} item", "other": "{count} items"},
+//		"commands": {"ping": {"name": "ping", "description": "Replies with pong"}}
+//	}
+func (b *Bundle) LoadJSON(locale discord.Locale, data []byte) error {
+	var table map[string]any
+	if err := json.Unmarshal(data, &table); err != nil {
+		return fmt.Errorf("failed to parse catalog of locale %s: %w", locale, err)
+	}
+	return b.loadTable(locale, table)
+}
+
+// LoadTOML loads a TOML catalog into the discord.Locale. The structure is the same as for LoadJSON.
+// Only the subset of TOML needed for catalogs is supported: tables, dotted keys, inline tables and strings.
+//
+//	greeting = "Hello {name}!"
+//	items = { one = "{count} item", other = "{count} items" }
+//
+//	[commands.ping]
+//	name = "ping"
+//	description = "Replies with pong"
+func (b *Bundle) LoadTOML(locale discord.Locale, data []byte) error {
+	table, err := parseTOML(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse catalog of locale %s: %w", locale, err)
+	}
+	return b.loadTable(locale, table)
+}
+
+// LoadFile loads a .json or .toml catalog file. The file name without the extension must be a discord.Locale, for example de.json or en-US.toml.
+func (b *Bundle) LoadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return b.load(filepath.Base(name), data)
+}
+
+// LoadFS loads all .json and .toml catalog files in the directory of the fs.FS, for example an embed.FS.
+// See LoadFile for how the files need to be named.
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isCatalogFile(entry.Name()) {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err = b.load(entry.Name(), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) load(name string, data []byte) error {
+	ext := path.Ext(name)
+	locale := discord.Locale(strings.TrimSuffix(name, ext))
+	if _, ok := discord.Locales[locale]; !ok {
+		return fmt.Errorf("catalog file %s is not named after a locale", name)
+	}
+
+	switch ext {
+	case ".json":
+		return b.LoadJSON(locale, data)
+	case ".toml":
+		return b.LoadTOML(locale, data)
+	default:
+		return fmt.Errorf("unsupported catalog file %s", name)
+	}
+}
+
+func (b *Bundle) loadTable(locale discord.Locale, table map[string]any) error {
+	messages := map[string]Message{}
+	if err := flattenTable(messages, "", table); err != nil {
+		return fmt.Errorf("invalid catalog of locale %s: %w", locale, err)
+	}
+	b.AddMessages(locale, messages)
+	return nil
+}
+
+func flattenTable(messages map[string]Message, prefix string, table map[string]any) error {
+	for key, value := range table {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			messages[key] = Message{Other: v}
+		case map[string]any:
+			if message, ok := messageFromTable(v); ok {
+				messages[key] = message
+				continue
+			}
+			if err := flattenTable(messages, key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("value of %s must be a string or table, got %T", key, value)
+		}
+	}
+	return nil
+}
+
+func isCatalogFile(name string) bool {
+	ext := path.Ext(name)
+	return ext == ".json" || ext == ".toml"
+}
@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"github.com/disgoorg/disgo/discord"
+)
+
+// LocaleProvider is implemented by every discord.Interaction and therefore by all events of the handler package.
+type LocaleProvider interface {
+	Locale() discord.Locale
+	GuildLocale() *discord.Locale
+}
+
+// Localizer translates keys of a Bundle into a fixed list of discord.Locale(s).
+type Localizer struct {
+	bundle  *Bundle
+	locales []discord.Locale
+}
+
+// Localizer returns a Localizer which tries the discord.Locale(s) in order before falling back to the default discord.Locale.
+func (b *Bundle) Localizer(locales ...discord.Locale) Localizer {
+	return Localizer{
+		bundle:  b,
+		locales: locales,
+	}
+}
+
+// ForInteraction returns a Localizer for responding to an interaction.
+// It prefers the locale of the user, then the locale of the guild and falls back to the default discord.Locale.
+//
+//	func(e *handler.CommandEvent) error {
+//		l := bundle.ForInteraction(e)
+//		return e.CreateMessage(discord.MessageCreate{Content: l.Translate("ping.response", nil)})
+//	}
+func (b *Bundle) ForInteraction(interaction LocaleProvider) Localizer {
+	locales := []discord.Locale{interaction.Locale()}
+	if guildLocale := interaction.GuildLocale(); guildLocale != nil && *guildLocale != interaction.Locale() {
+		locales = append(locales, *guildLocale)
+	}
+	return b.Localizer(locales...)
+}
+
+// Locale returns the discord.Locale the Localizer prefers.
+func (l Localizer) Locale() discord.Locale {
+	if len(l.locales) == 0 {
+		return l.bundle.defaultLocale
+	}
+	return l.locales[0]
+}
+
+// Translate returns the translation of the key with the placeholders replaced by vars.
+func (l Localizer) Translate(key string, vars Vars) string {
+	return l.bundle.translate(l.locales, key, -1, vars)
+}
+
+// TranslatePlural returns the plural form of the key for count. See Bundle.TranslatePlural.
+func (l Localizer) TranslatePlural(key string, count int, vars Vars) string {
+	return l.bundle.translate(l.locales, key, count, vars)
+}
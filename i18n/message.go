@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Vars are the values for the {name} placeholders of a Message.
+type Vars map[string]any
+
+// Message is a single translated text. Messages without plural forms only have Other set.
+// Which plural form is used for a count depends on the plural rules of the language, see PluralFormFor.
+type Message struct {
+	// Zero is used for a count of 0 in all languages if set
+	Zero  string
+	One   string
+	Two   string
+	Few   string
+	Many  string
+	Other string
+}
+
+// Plural returns whether the Message has any plural forms besides Other.
+func (m Message) Plural() bool {
+	return m.Zero != "" || m.One != "" || m.Two != "" || m.Few != "" || m.Many != ""
+}
+
+// Form returns the text for the given PluralForm. If the Message does not have this form, Other is returned.
+func (m Message) Form(form PluralForm) string {
+	var text string
+	switch form {
+	case PluralFormZero:
+		text = m.Zero
+	case PluralFormOne:
+		text = m.One
+	case PluralFormTwo:
+		text = m.Two
+	case PluralFormFew:
+		text = m.Few
+	case PluralFormMany:
+		text = m.Many
+	}
+	if text == "" {
+		return m.Other
+	}
+	return text
+}
+
+// Format replaces all {name} placeholders in text with the matching value of vars.
+// Placeholders without a value are kept as they are.
+func Format(text string, vars Vars) string {
+	if len(vars) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	for {
+		start := strings.IndexByte(text, '{')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '}')
+		if end == -1 {
+			break
+		}
+		end += start
+
+		b.WriteString(text[:start])
+		if value, ok := vars[text[start+1:end]]; ok {
+			b.WriteString(fmt.Sprint(value))
+		} else {
+			b.WriteString(text[start : end+1])
+		}
+		text = text[end+1:]
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+// messageFromTable returns the Message of a table whose keys are all plural forms.
+// Tables with other keys are nested catalogs and not messages.
+func messageFromTable(table map[string]any) (Message, bool) {
+	if _, ok := table[string(PluralFormOther)]; !ok {
+		return Message{}, false
+	}
+	var message Message
+	for key, value := range table {
+		text, ok := value.(string)
+		if !ok {
+			return Message{}, false
+		}
+		switch PluralForm(key) {
+		case PluralFormZero:
+			message.Zero = text
+		case PluralFormOne:
+			message.One = text
+		case PluralFormTwo:
+			message.Two = text
+		case PluralFormFew:
+			message.Few = text
+		case PluralFormMany:
+			message.Many = text
+		case PluralFormOther:
+			message.Other = text
+		default:
+			return Message{}, false
+		}
+	}
+	return message, true
+}
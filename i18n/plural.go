@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// PluralForm is a CLDR plural category. Messages have one text per PluralForm their language uses.
+type PluralForm string
+
+const (
+	PluralFormZero  PluralForm = "zero"
+	PluralFormOne   PluralForm = "one"
+	PluralFormTwo   PluralForm = "two"
+	PluralFormFew   PluralForm = "few"
+	PluralFormMany  PluralForm = "many"
+	PluralFormOther PluralForm = "other"
+)
+
+// PluralFormFor returns the PluralForm used for count in the language of the discord.Locale.
+// The rules follow the CLDR cardinal plural rules for integers of all languages Discord supports.
+func PluralFormFor(locale discord.Locale, count int) PluralForm {
+	if count < 0 {
+		count = -count
+	}
+	mod10 := count % 10
+	mod100 := count % 100
+
+	switch language(locale) {
+	case "ja", "ko", "zh", "th", "vi", "id":
+		return PluralFormOther
+
+	case "fr", "pt", "hi":
+		if count <= 1 {
+			return PluralFormOne
+		}
+		return PluralFormOther
+
+	case "ru", "uk":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralFormOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFormFew
+		default:
+			return PluralFormMany
+		}
+
+	case "hr":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralFormOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFormFew
+		default:
+			return PluralFormOther
+		}
+
+	case "pl":
+		switch {
+		case count == 1:
+			return PluralFormOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFormFew
+		default:
+			return PluralFormMany
+		}
+
+	case "cs":
+		switch {
+		case count == 1:
+			return PluralFormOne
+		case count >= 2 && count <= 4:
+			return PluralFormFew
+		default:
+			return PluralFormOther
+		}
+
+	case "ro":
+		switch {
+		case count == 1:
+			return PluralFormOne
+		case count == 0 || (mod100 >= 2 && mod100 <= 19):
+			return PluralFormFew
+		default:
+			return PluralFormOther
+		}
+
+	case "lt":
+		switch {
+		case mod10 == 1 && (mod100 < 11 || mod100 > 19):
+			return PluralFormOne
+		case mod10 >= 2 && (mod100 < 11 || mod100 > 19):
+			return PluralFormFew
+		default:
+			return PluralFormOther
+		}
+
+	default:
+		if count == 1 {
+			return PluralFormOne
+		}
+		return PluralFormOther
+	}
+}
+
+// language returns the language part of the discord.Locale without the region.
+func language(locale discord.Locale) string {
+	lang, _, _ := strings.Cut(string(locale), "-")
+	return lang
+}
@@ -0,0 +1,377 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseTOML parses the subset of TOML used by catalogs into nested maps.
+// Supported are comments, [tables], bare, quoted & dotted keys, inline tables and all four kinds of strings.
+// Any other value like numbers, arrays or [[arrays of tables]] is an error.
+func parseTOML(s string) (map[string]any, error) {
+	p := &tomlParser{s: s, line: 1}
+	root := map[string]any{}
+	current := root
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return root, nil
+		}
+
+		var err error
+		if p.peek() == '[' {
+			current, err = p.parseTableHeader(root)
+		} else {
+			err = p.parseKeyValue(current)
+		}
+		if err == nil {
+			err = p.expectLineEnd()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("toml: line %d: %w", p.line, err)
+		}
+	}
+}
+
+type tomlParser struct {
+	s    string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *tomlParser) advance(n int) {
+	p.line += strings.Count(p.s[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// skipSpace skips spaces & tabs on the current line.
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, newlines and comments.
+func (p *tomlParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			p.advance(1)
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	if end := strings.IndexByte(p.s[p.pos:], '\n'); end != -1 {
+		p.pos += end
+		return
+	}
+	p.pos = len(p.s)
+}
+
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpace()
+	if p.peek() == '#' {
+		p.skipComment()
+	}
+	if p.peek() == '\r' {
+		p.pos++
+	}
+	if p.eof() {
+		return nil
+	}
+	if p.peek() != '\n' {
+		return fmt.Errorf("unexpected %q after value", p.peek())
+	}
+	p.advance(1)
+	return nil
+}
+
+func (p *tomlParser) parseTableHeader(root map[string]any) (map[string]any, error) {
+	p.pos++
+	if p.peek() == '[' {
+		return nil, errors.New("arrays of tables are not supported")
+	}
+	p.skipSpace()
+	keys, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.peek() != ']' {
+		return nil, errors.New("expected ] after table name")
+	}
+	p.pos++
+	return subTable(root, keys)
+}
+
+func (p *tomlParser) parseKeyValue(table map[string]any) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.peek() != '=' {
+		return fmt.Errorf("expected = after key %s", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	parent, err := subTable(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	key := keys[len(keys)-1]
+	if _, ok := parent[key]; ok {
+		return fmt.Errorf("duplicate key %s", strings.Join(keys, "."))
+	}
+	parent[key] = value
+	return nil
+}
+
+// parseKey parses a bare, quoted or dotted key and returns its parts.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		var (
+			key string
+			err error
+		)
+		switch p.peek() {
+		case '"':
+			key, err = p.parseBasicString()
+		case '\'':
+			key, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, fmt.Errorf("expected key, got %q", p.peek())
+			}
+			key = p.s[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		p.skipSpace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+		p.skipSpace()
+	}
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	switch {
+	case strings.HasPrefix(p.s[p.pos:], `"""`):
+		return p.parseMultilineString(`"""`, true)
+	case strings.HasPrefix(p.s[p.pos:], `'''`):
+		return p.parseMultilineString(`'''`, false)
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	case p.peek() == '{':
+		return p.parseInlineTable()
+	default:
+		return nil, errors.New("unsupported value, catalogs may only contain strings and tables")
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (map[string]any, error) {
+	p.pos++
+	table := map[string]any{}
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		p.skipSpace()
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, errors.New("expected , or } in inline table")
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.s[p.pos:], "'\n")
+	if end == -1 || p.s[p.pos+end] != '\'' {
+		return "", errors.New("unterminated string")
+	}
+	str := p.s[p.pos : p.pos+end]
+	p.pos += end + 1
+	return str, nil
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", errors.New("unterminated string")
+		}
+		c := p.peek()
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *tomlParser) parseMultilineString(delim string, basic bool) (string, error) {
+	p.pos += len(delim)
+	// a newline directly after the opening delimiter is trimmed
+	if strings.HasPrefix(p.s[p.pos:], "\r\n") {
+		p.advance(2)
+	} else if p.peek() == '\n' {
+		p.advance(1)
+	}
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", errors.New("unterminated multi-line string")
+		}
+		if strings.HasPrefix(p.s[p.pos:], delim) {
+			// up to two quotes directly before the closing delimiter belong to the string
+			for strings.HasPrefix(p.s[p.pos+1:], delim) {
+				b.WriteByte(p.s[p.pos])
+				p.pos++
+			}
+			p.pos += len(delim)
+			return b.String(), nil
+		}
+
+		c := p.peek()
+		if basic && c == '\\' {
+			if end := strings.TrimLeft(p.s[p.pos+1:], " \t\r"); strings.HasPrefix(end, "\n") {
+				// a line ending backslash trims all whitespace up to the next non-whitespace character
+				p.advance(len(p.s) - p.pos - len(end))
+				for !p.eof() && strings.IndexByte(" \t\r\n", p.peek()) != -1 {
+					p.advance(1)
+				}
+				continue
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.advance(1)
+	}
+}
+
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	if p.pos+1 >= len(p.s) {
+		return errors.New("unterminated escape sequence")
+	}
+	c := p.s[p.pos+1]
+	p.pos += 2
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.s) {
+			return errors.New("invalid unicode escape sequence")
+		}
+		code, err := strconv.ParseUint(p.s[p.pos:p.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return errors.New("invalid unicode escape sequence")
+		}
+		b.WriteRune(rune(code))
+		p.pos += size
+	default:
+		return fmt.Errorf("invalid escape sequence \\%c", c)
+	}
+	return nil
+}
+
+// subTable returns the nested table of the keys and creates missing tables.
+func subTable(table map[string]any, keys []string) (map[string]any, error) {
+	for i, key := range keys {
+		value, ok := table[key]
+		if !ok {
+			sub := map[string]any{}
+			table[key] = sub
+			table = sub
+			continue
+		}
+		sub, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("key %s is already defined as a string", strings.Join(keys[:i+1], "."))
+		}
+		table = sub
+	}
+	return table, nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
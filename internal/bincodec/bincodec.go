@@ -0,0 +1,231 @@
+// Package bincodec implements the compact binary encoding used by the cache codecs.
+package bincodec
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrInvalidEncoding is returned by a Reader when the data can't be decoded.
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// Writer appends varint encoded values to Buf.
+type Writer struct {
+	Buf []byte
+}
+
+func (w *Writer) Uint8(v uint8) {
+	w.Buf = append(w.Buf, v)
+}
+
+func (w *Writer) Bool(v bool) {
+	if v {
+		w.Buf = append(w.Buf, 1)
+	} else {
+		w.Buf = append(w.Buf, 0)
+	}
+}
+
+func (w *Writer) Uint(v uint64) {
+	w.Buf = binary.AppendUvarint(w.Buf, v)
+}
+
+func (w *Writer) Int(v int64) {
+	w.Buf = binary.AppendVarint(w.Buf, v)
+}
+
+func (w *Writer) ID(id snowflake.ID) {
+	w.Uint(uint64(id))
+}
+
+func (w *Writer) OptID(id *snowflake.ID) {
+	w.Bool(id != nil)
+	if id != nil {
+		w.ID(*id)
+	}
+}
+
+func (w *Writer) IDs(ids []snowflake.ID) {
+	w.Uint(uint64(len(ids)))
+	for _, id := range ids {
+		w.ID(id)
+	}
+}
+
+func (w *Writer) String(s string) {
+	w.Uint(uint64(len(s)))
+	w.Buf = append(w.Buf, s...)
+}
+
+func (w *Writer) OptString(s *string) {
+	w.Bool(s != nil)
+	if s != nil {
+		w.String(*s)
+	}
+}
+
+func (w *Writer) OptInt(i *int) {
+	w.Bool(i != nil)
+	if i != nil {
+		w.Int(int64(*i))
+	}
+}
+
+// Time writes the time as unix nanoseconds, the zero time is written as 0
+func (w *Writer) Time(t time.Time) {
+	if t.IsZero() {
+		w.Int(0)
+		return
+	}
+	w.Int(t.UnixNano())
+}
+
+func (w *Writer) OptTime(t *time.Time) {
+	w.Bool(t != nil)
+	if t != nil {
+		w.Time(*t)
+	}
+}
+
+// Reader reads the values written by Writer. The first error is kept & all following reads return zero values.
+type Reader struct {
+	buf []byte
+	err error
+}
+
+// NewReader returns a new Reader reading from buf.
+func NewReader(buf []byte) *Reader {
+	return &Reader{buf: buf}
+}
+
+// Err returns the first error which occurred while reading.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Fail sets the error of the Reader if it has none yet & stops reading.
+func (r *Reader) Fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+	r.buf = nil
+}
+
+// Bytes returns the bytes which were not read yet.
+func (r *Reader) Bytes() []byte {
+	return r.buf
+}
+
+func (r *Reader) Uint8() uint8 {
+	if len(r.buf) < 1 {
+		r.Fail(ErrInvalidEncoding)
+		return 0
+	}
+	v := r.buf[0]
+	r.buf = r.buf[1:]
+	return v
+}
+
+func (r *Reader) Bool() bool {
+	return r.Uint8() == 1
+}
+
+func (r *Reader) Uint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.Fail(ErrInvalidEncoding)
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *Reader) Int() int64 {
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.Fail(ErrInvalidEncoding)
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *Reader) ID() snowflake.ID {
+	return snowflake.ID(r.Uint())
+}
+
+func (r *Reader) OptID() *snowflake.ID {
+	if !r.Bool() {
+		return nil
+	}
+	id := r.ID()
+	return &id
+}
+
+func (r *Reader) IDs() []snowflake.ID {
+	n := r.Len()
+	if n == 0 {
+		return nil
+	}
+	ids := make([]snowflake.ID, n)
+	for i := range ids {
+		ids[i] = r.ID()
+	}
+	return ids
+}
+
+// Len reads the length of a list. Every element takes at least one byte, so longer lists fail the Reader.
+func (r *Reader) Len() int {
+	n := r.Uint()
+	if n > uint64(len(r.buf)) {
+		r.Fail(ErrInvalidEncoding)
+		return 0
+	}
+	return int(n)
+}
+
+func (r *Reader) String() string {
+	n := r.Uint()
+	if n > uint64(len(r.buf)) {
+		r.Fail(ErrInvalidEncoding)
+		return ""
+	}
+	s := string(r.buf[:n])
+	r.buf = r.buf[n:]
+	return s
+}
+
+func (r *Reader) OptString() *string {
+	if !r.Bool() {
+		return nil
+	}
+	s := r.String()
+	return &s
+}
+
+func (r *Reader) OptInt() *int {
+	if !r.Bool() {
+		return nil
+	}
+	i := int(r.Int())
+	return &i
+}
+
+func (r *Reader) Time() time.Time {
+	v := r.Int()
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, v).UTC()
+}
+
+func (r *Reader) OptTime() *time.Time {
+	if !r.Bool() {
+		return nil
+	}
+	t := r.Time()
+	return &t
+}
@@ -0,0 +1,181 @@
+package markdown
+
+import (
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Node is a single element of parsed markdown. Use a type switch to find out which one.
+type Node interface {
+	node()
+}
+
+var (
+	_ Node = (*Text)(nil)
+	_ Node = (*Bold)(nil)
+	_ Node = (*Italic)(nil)
+	_ Node = (*Underline)(nil)
+	_ Node = (*Strikethrough)(nil)
+	_ Node = (*Spoiler)(nil)
+	_ Node = (*InlineCode)(nil)
+	_ Node = (*CodeBlock)(nil)
+	_ Node = (*BlockQuote)(nil)
+	_ Node = (*Header)(nil)
+	_ Node = (*Subtext)(nil)
+	_ Node = (*ListItem)(nil)
+	_ Node = (*Link)(nil)
+	_ Node = (*UserMention)(nil)
+	_ Node = (*RoleMention)(nil)
+	_ Node = (*ChannelMention)(nil)
+	_ Node = (*Emoji)(nil)
+	_ Node = (*Timestamp)(nil)
+	_ Node = (*SlashCommandMention)(nil)
+	_ Node = (*EveryoneMention)(nil)
+	_ Node = (*HereMention)(nil)
+)
+
+// Text is unformatted text. Escape sequences are already resolved.
+type Text struct {
+	Content string
+}
+
+// Bold is text wrapped in **
+type Bold struct {
+	Children []Node
+}
+
+// Italic is text wrapped in * or _
+type Italic struct {
+	Children []Node
+}
+
+// Underline is text wrapped in __
+type Underline struct {
+	Children []Node
+}
+
+// Strikethrough is text wrapped in ~~
+type Strikethrough struct {
+	Children []Node
+}
+
+// Spoiler is text wrapped in ||
+type Spoiler struct {
+	Children []Node
+}
+
+// InlineCode is text wrapped in one or two backticks
+type InlineCode struct {
+	Content string
+}
+
+// CodeBlock is text wrapped in ```. The Language is set if the first line only contains a language name.
+type CodeBlock struct {
+	Language string
+	Content  string
+}
+
+// BlockQuote is one or more lines starting with > or everything after >>>
+type BlockQuote struct {
+	Children []Node
+	// Multiline is true for quotes starting with >>> which quote the rest of the message
+	Multiline bool
+}
+
+// Header is a line starting with #, ## or ###
+type Header struct {
+	Level    int
+	Children []Node
+}
+
+// Subtext is a line starting with -#
+type Subtext struct {
+	Children []Node
+}
+
+// ListItem is a line starting with - or * for unordered and a number followed by a dot for ordered lists.
+type ListItem struct {
+	// Indent is the number of spaces before the list marker
+	Indent   int
+	Ordered  bool
+	Number   int
+	Children []Node
+}
+
+// Link is an url. Masked links like [text](https://example.com) have Children.
+type Link struct {
+	URL      string
+	Children []Node
+	// Suppressed is true if the url is wrapped in <> to not show an embed
+	Suppressed bool
+}
+
+// Masked returns whether the Link has a text which is shown instead of the url.
+func (l Link) Masked() bool {
+	return len(l.Children) > 0
+}
+
+// UserMention is a <@id> mention
+type UserMention struct {
+	ID snowflake.ID
+}
+
+// RoleMention is a <@&id> mention
+type RoleMention struct {
+	ID snowflake.ID
+}
+
+// ChannelMention is a <#id> mention
+type ChannelMention struct {
+	ID snowflake.ID
+}
+
+// Emoji is a custom <:name:id> or animated <a:name:id> emoji
+type Emoji struct {
+	ID       snowflake.ID
+	Name     string
+	Animated bool
+}
+
+// Timestamp is a <t:unix:style> timestamp
+type Timestamp struct {
+	Time  time.Time
+	Style discord.TimestampStyle
+}
+
+// SlashCommandMention is a </name:id> mention. The Name includes the subcommand group & subcommand.
+type SlashCommandMention struct {
+	ID   snowflake.ID
+	Name string
+}
+
+// EveryoneMention is a @everyone mention
+type EveryoneMention struct{}
+
+// HereMention is a @here mention
+type HereMention struct{}
+
+func (Text) node()                {}
+func (Bold) node()                {}
+func (Italic) node()              {}
+func (Underline) node()           {}
+func (Strikethrough) node()       {}
+func (Spoiler) node()             {}
+func (InlineCode) node()          {}
+func (CodeBlock) node()           {}
+func (BlockQuote) node()          {}
+func (Header) node()              {}
+func (Subtext) node()             {}
+func (ListItem) node()            {}
+func (Link) node()                {}
+func (UserMention) node()         {}
+func (RoleMention) node()         {}
+func (ChannelMention) node()      {}
+func (Emoji) node()               {}
+func (Timestamp) node()           {}
+func (SlashCommandMention) node() {}
+func (EveryoneMention) node()     {}
+func (HereMention) node()         {}
@@ -0,0 +1,69 @@
+package markdown
+
+import (
+	"strings"
+)
+
+// Escape escapes all markdown formatting & mentions in s, so it is shown as is.
+// Urls are kept as they are, since a backslash would become part of the url.
+func Escape(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	lineStart := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == 'h' && (i == 0 || !isWordChar(s[i-1])) {
+			if url := urlRegex.FindString(s[i:]); url != "" {
+				b.WriteString(url)
+				i += len(url) - 1
+				lineStart = false
+				continue
+			}
+		}
+
+		if lineStart {
+			switch {
+			case c == ' ':
+				b.WriteByte(c)
+				continue
+			case c == '#' || c == '-' || c == '>':
+				b.WriteByte('\\')
+			case c >= '0' && c <= '9':
+				// ordered list items like 1. are escaped by escaping the dot
+				j := i
+				for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+					j++
+				}
+				b.WriteString(s[i:j])
+				if j < len(s) && s[j] == '.' {
+					b.WriteString(`\.`)
+					j++
+				}
+				i = j - 1
+				lineStart = false
+				continue
+			}
+		}
+
+		switch c {
+		case '\\', '*', '_', '~', '`', '|', '[', '<', '@':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+		lineStart = c == '\n'
+	}
+	return b.String()
+}
+
+// EscapeMentions only escapes mentions in s. See Escape to also escape formatting.
+func EscapeMentions(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '<' || s[i] == '@' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package markdown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		nodes   []Node
+	}{
+		{
+			name:    "formatting",
+			content: "**bold *italic*** __under__ ~~strike~~ ||spoiler|| snake_case_name",
+			nodes: []Node{
+				Bold{Children: []Node{Text{Content: "bold "}, Italic{Children: []Node{Text{Content: "italic"}}}}},
+				Text{Content: " "},
+				Underline{Children: []Node{Text{Content: "under"}}},
+				Text{Content: " "},
+				Strikethrough{Children: []Node{Text{Content: "strike"}}},
+				Text{Content: " "},
+				Spoiler{Children: []Node{Text{Content: "spoiler"}}},
+				Text{Content: " snake_case_name"},
+			},
+		},
+		{
+			name:    "code",
+			content: "`a*b*` ```go\nfmt.Println(\"**\")\n```",
+			nodes: []Node{
+				InlineCode{Content: "a*b*"},
+				Text{Content: " "},
+				CodeBlock{Language: "go", Content: "fmt.Println(\"**\")\n"},
+			},
+		},
+		{
+			name:    "blocks",
+			content: "# title\n> quote\n> more\n- item\n1. first\n-# small",
+			nodes: []Node{
+				Header{Level: 1, Children: []Node{Text{Content: "title"}}},
+				Text{Content: "\n"},
+				BlockQuote{Children: []Node{Text{Content: "quote\nmore"}}},
+				Text{Content: "\n"},
+				ListItem{Children: []Node{Text{Content: "item"}}},
+				Text{Content: "\n"},
+				ListItem{Ordered: true, Number: 1, Children: []Node{Text{Content: "first"}}},
+				Text{Content: "\n"},
+				Subtext{Children: []Node{Text{Content: "small"}}},
+			},
+		},
+		{
+			name:    "mentions",
+			content: "<@1> <@&2> <#3> <a:wave:4> <t:0:R> </ban user:5> @everyone \\<@6>",
+			nodes: []Node{
+				UserMention{ID: 1},
+				Text{Content: " "},
+				RoleMention{ID: 2},
+				Text{Content: " "},
+				ChannelMention{ID: 3},
+				Text{Content: " "},
+				Emoji{ID: 4, Name: "wave", Animated: true},
+				Text{Content: " "},
+				Timestamp{Time: time.Unix(0, 0), Style: discord.TimestampStyleRelative},
+				Text{Content: " "},
+				SlashCommandMention{ID: 5, Name: "ban user"},
+				Text{Content: " "},
+				EveryoneMention{},
+				Text{Content: " <@6>"},
+			},
+		},
+		{
+			name:    "mention variants",
+			content: "<@!7> <:smile:8> </tag get:9>",
+			nodes: []Node{
+				UserMention{ID: 7},
+				Text{Content: " "},
+				Emoji{ID: 8, Name: "smile"},
+				Text{Content: " "},
+				SlashCommandMention{ID: 9, Name: "tag get"},
+			},
+		},
+		{
+			name:    "oversized ids",
+			content: "<@99999999999999999999> <@&99999999999999999999> <#99999999999999999999> <:wave:99999999999999999999> </ban:99999999999999999999>",
+			nodes: []Node{
+				Text{Content: "<@99999999999999999999> <@&99999999999999999999> <#99999999999999999999> <:wave:99999999999999999999> </ban:99999999999999999999>"},
+			},
+		},
+		{
+			name:    "links",
+			content: "[docs](<https://example.com/a_b_c>) https://example.com/x_y_z.",
+			nodes: []Node{
+				Link{URL: "https://example.com/a_b_c", Children: []Node{Text{Content: "docs"}}, Suppressed: true},
+				Text{Content: " "},
+				Link{URL: "https://example.com/x_y_z"},
+				Text{Content: "."},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := Parse(tt.content)
+			assert.Equal(t, tt.nodes, nodes)
+			assert.Equal(t, nodes, Parse(Render(nodes)), "rendered markdown must parse to the same nodes")
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	input := "# **not bold** <@1> @everyone\n1. _x_ https://example.com/a_b"
+	escaped := Escape(input)
+	assert.Equal(t, `\# \*\*not bold\*\* \<\@1> \@everyone`+"\n"+`1\. \_x\_ https://example.com/a_b`, escaped)
+	assert.Equal(t, input, PlainText(Parse(escaped)))
+}
+
+func TestPlainText(t *testing.T) {
+	nodes := Parse("**hi** <@1>, ||secret|| [link](https://example.com)")
+	text := PlainText(nodes,
+		WithUserResolver(func(id snowflake.ID) string { return "@user" }),
+		WithSpoilerReplacement("***"),
+	)
+	assert.Equal(t, "hi @user, *** link", text)
+}
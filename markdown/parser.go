@@ -0,0 +1,359 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var (
+	userMentionRegex         = regexp.MustCompile(`^<@!?(\d+)>`)
+	roleMentionRegex         = regexp.MustCompile(`^<@&(\d+)>`)
+	channelMentionRegex      = regexp.MustCompile(`^<#(\d+)>`)
+	emojiRegex               = regexp.MustCompile(`^<(a?):(\w+):(\d+)>`)
+	timestampRegex           = regexp.MustCompile(`^<t:(-?\d{1,17})(?::([tTdDfFR]))?>`)
+	slashCommandMentionRegex = regexp.MustCompile(`^</([-_\p{L}\p{N}]+(?: [-_\p{L}\p{N}]+){0,2}):(\d+)>`)
+	suppressedURLRegex       = regexp.MustCompile(`^<(https?://[^\s>]+)>`)
+	urlRegex                 = regexp.MustCompile(`^https?://[^\s<]+[^<.,:;"')\]\s]`)
+	maskedLinkRegex          = regexp.MustCompile(`^\[([^\[\]]+)\]\((<?)(https?://[^\s()<>]+)(>?)\)`)
+	headerRegex              = regexp.MustCompile(`^(#{1,3}) +(\S.*)`)
+	subtextRegex             = regexp.MustCompile(`^-# +(\S.*)`)
+	listItemRegex            = regexp.MustCompile(`^( *)(?:([-*])|(\d{1,9})\.) +(\S.*)`)
+	codeBlockLanguageRegex   = regexp.MustCompile(`^[\w+\-.#]+$`)
+)
+
+// Parse parses the Discord flavoured markdown of a message content into a list of Node(s).
+func Parse(content string) []Node {
+	return parse(content, true, true)
+}
+
+// ParseInline parses the content like Parse but ignores line based elements like BlockQuote, Header, Subtext & ListItem.
+func ParseInline(content string) []Node {
+	return parse(content, false, false)
+}
+
+type parser struct {
+	src   string
+	pos   int
+	nodes []Node
+	text  strings.Builder
+}
+
+func (p *parser) add(node Node) {
+	p.flush()
+	p.nodes = append(p.nodes, node)
+}
+
+func (p *parser) flush() {
+	if p.text.Len() > 0 {
+		p.nodes = append(p.nodes, Text{Content: p.text.String()})
+		p.text.Reset()
+	}
+}
+
+// parse parses s. blocks enables line based elements & quotes block quotes, which can't be nested.
+func parse(s string, blocks bool, quotes bool) []Node {
+	p := &parser{src: s}
+	for p.pos < len(s) {
+		if blocks && (p.pos == 0 || s[p.pos-1] == '\n') && p.parseBlock(quotes) {
+			continue
+		}
+		if !p.parseInline() {
+			p.text.WriteByte(s[p.pos])
+			p.pos++
+		}
+	}
+	p.flush()
+	return p.nodes
+}
+
+// line returns the rest of the current line without the line break.
+func (p *parser) line() string {
+	rest := p.src[p.pos:]
+	if i := strings.IndexByte(rest, '\n'); i != -1 {
+		return rest[:i]
+	}
+	return rest
+}
+
+func (p *parser) parseBlock(quotes bool) bool {
+	rest := p.src[p.pos:]
+	line := p.line()
+
+	if quotes && strings.HasPrefix(rest, ">>> ") {
+		p.add(BlockQuote{Children: parse(rest[4:], true, false), Multiline: true})
+		p.pos = len(p.src)
+		return true
+	}
+	if quotes && strings.HasPrefix(rest, "> ") {
+		var content strings.Builder
+		for {
+			line = p.line()
+			content.WriteString(line[2:])
+			p.pos += len(line)
+			next := p.src[p.pos:]
+			if !strings.HasPrefix(next, "\n> ") || strings.HasPrefix(next, "\n>>> ") {
+				break
+			}
+			content.WriteByte('\n')
+			p.pos++
+		}
+		p.add(BlockQuote{Children: parse(content.String(), true, false)})
+		return true
+	}
+
+	if match := headerRegex.FindStringSubmatch(line); match != nil {
+		p.add(Header{Level: len(match[1]), Children: ParseInline(match[2])})
+		p.pos += len(line)
+		return true
+	}
+	if match := subtextRegex.FindStringSubmatch(line); match != nil {
+		p.add(Subtext{Children: ParseInline(match[1])})
+		p.pos += len(line)
+		return true
+	}
+	if match := listItemRegex.FindStringSubmatch(line); match != nil {
+		item := ListItem{
+			Indent:   len(match[1]),
+			Ordered:  match[3] != "",
+			Children: ParseInline(match[4]),
+		}
+		if item.Ordered {
+			item.Number, _ = strconv.Atoi(match[3])
+		}
+		p.add(item)
+		p.pos += len(line)
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseInline() bool {
+	s := p.src
+	rest := s[p.pos:]
+	switch s[p.pos] {
+	case '\\':
+		if len(rest) > 1 && isEscapable(rest[1]) {
+			p.text.WriteByte(rest[1])
+			p.pos += 2
+			return true
+		}
+
+	case '`':
+		if strings.HasPrefix(rest, "```") {
+			if end := strings.Index(rest[3:], "```"); end > 0 {
+				p.add(newCodeBlock(rest[3 : 3+end]))
+				p.pos += end + 6
+				return true
+			}
+		}
+		delim := "`"
+		if strings.HasPrefix(rest, "``") {
+			delim = "``"
+		}
+		if end := closing(rest, len(delim)+1, delim, '`'); end != -1 {
+			p.add(InlineCode{Content: rest[len(delim):end]})
+			p.pos += end + len(delim)
+			return true
+		}
+		// skip the whole run of backticks, so it is not parsed as a shorter delimiter
+		p.text.WriteString(delim)
+		p.pos += len(delim)
+		return true
+
+	case '*':
+		if strings.HasPrefix(rest, "**") && p.parseWrapped("**", '*', func(children []Node) Node { return Bold{Children: children} }) {
+			return true
+		}
+		if end := closingItalicStar(rest); end != -1 {
+			p.add(Italic{Children: ParseInline(rest[1:end])})
+			p.pos += end + 1
+			return true
+		}
+
+	case '_':
+		if strings.HasPrefix(rest, "__") && p.parseWrapped("__", '_', func(children []Node) Node { return Underline{Children: children} }) {
+			return true
+		}
+		if p.pos == 0 || !isWordChar(s[p.pos-1]) {
+			if end := closingItalicUnderscore(rest); end != -1 {
+				p.add(Italic{Children: ParseInline(rest[1:end])})
+				p.pos += end + 1
+				return true
+			}
+		}
+
+	case '~':
+		if strings.HasPrefix(rest, "~~") {
+			return p.parseWrapped("~~", 0, func(children []Node) Node { return Strikethrough{Children: children} })
+		}
+
+	case '|':
+		if strings.HasPrefix(rest, "||") {
+			return p.parseWrapped("||", 0, func(children []Node) Node { return Spoiler{Children: children} })
+		}
+
+	case '[':
+		if match := maskedLinkRegex.FindStringSubmatch(rest); match != nil && (match[2] == "") == (match[4] == "") {
+			p.add(Link{URL: match[3], Children: ParseInline(match[1]), Suppressed: match[2] != ""})
+			p.pos += len(match[0])
+			return true
+		}
+
+	case '<':
+		return p.parseAngleBrackets(rest)
+
+	case '@':
+		if strings.HasPrefix(rest, "@everyone") {
+			p.add(EveryoneMention{})
+			p.pos += len("@everyone")
+			return true
+		}
+		if strings.HasPrefix(rest, "@here") {
+			p.add(HereMention{})
+			p.pos += len("@here")
+			return true
+		}
+
+	case 'h':
+		if p.pos == 0 || !isWordChar(s[p.pos-1]) {
+			if url := urlRegex.FindString(rest); url != "" {
+				p.add(Link{URL: url})
+				p.pos += len(url)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseWrapped parses text wrapped in delim, where the closing delim must not be followed by notFollowedBy.
+func (p *parser) parseWrapped(delim string, notFollowedBy byte, newNode func(children []Node) Node) bool {
+	rest := p.src[p.pos:]
+	end := closing(rest, len(delim)+1, delim, notFollowedBy)
+	if end == -1 {
+		return false
+	}
+	p.add(newNode(ParseInline(rest[len(delim):end])))
+	p.pos += end + len(delim)
+	return true
+}
+
+func (p *parser) parseAngleBrackets(rest string) bool {
+	var (
+		node  Node
+		match []string
+		id    snowflake.ID
+		err   error
+	)
+	// IDs & timestamps which don't fit into 64 bits are not mentions, they are kept as plain text
+	if match = userMentionRegex.FindStringSubmatch(rest); match != nil {
+		id, err = snowflake.Parse(match[1])
+		node = UserMention{ID: id}
+	} else if match = roleMentionRegex.FindStringSubmatch(rest); match != nil {
+		id, err = snowflake.Parse(match[1])
+		node = RoleMention{ID: id}
+	} else if match = channelMentionRegex.FindStringSubmatch(rest); match != nil {
+		id, err = snowflake.Parse(match[1])
+		node = ChannelMention{ID: id}
+	} else if match = emojiRegex.FindStringSubmatch(rest); match != nil {
+		id, err = snowflake.Parse(match[3])
+		node = Emoji{ID: id, Name: match[2], Animated: match[1] == "a"}
+	} else if match = timestampRegex.FindStringSubmatch(rest); match != nil {
+		var seconds int64
+		seconds, err = strconv.ParseInt(match[1], 10, 64)
+		node = Timestamp{Time: time.Unix(seconds, 0), Style: discord.TimestampStyle(match[2])}
+	} else if match = slashCommandMentionRegex.FindStringSubmatch(rest); match != nil {
+		id, err = snowflake.Parse(match[2])
+		node = SlashCommandMention{ID: id, Name: match[1]}
+	} else if match = suppressedURLRegex.FindStringSubmatch(rest); match != nil {
+		node = Link{URL: match[1], Suppressed: true}
+	} else {
+		return false
+	}
+	if err != nil {
+		return false
+	}
+	p.add(node)
+	p.pos += len(match[0])
+	return true
+}
+
+func newCodeBlock(content string) CodeBlock {
+	// the first line is the language if it is a single word and followed by more content
+	if i := strings.IndexByte(content, '\n'); i > 0 && codeBlockLanguageRegex.MatchString(content[:i]) && strings.TrimSpace(content[i+1:]) != "" {
+		return CodeBlock{Language: content[:i], Content: content[i+1:]}
+	}
+	return CodeBlock{Content: content}
+}
+
+// closing returns the index of the first delim in s at or after from, which is not followed by notFollowedBy, or -1.
+func closing(s string, from int, delim string, notFollowedBy byte) int {
+	for from <= len(s)-len(delim) {
+		i := strings.Index(s[from:], delim)
+		if i == -1 {
+			return -1
+		}
+		i += from
+		end := i + len(delim)
+		if notFollowedBy == 0 || end >= len(s) || s[end] != notFollowedBy {
+			return i
+		}
+		from = i + 1
+	}
+	return -1
+}
+
+// closingItalicStar returns the index of the * closing the italic text s starts with, or -1.
+// The text must not start or end with whitespace.
+func closingItalicStar(s string) int {
+	if len(s) < 3 || isSpace(s[1]) {
+		return -1
+	}
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case strings.HasPrefix(s[i:], "**"):
+			i++
+		case s[i] == '*' && i > 1 && !isSpace(s[i-1]):
+			return i
+		}
+	}
+	return -1
+}
+
+// closingItalicUnderscore returns the index of the _ closing the italic text s starts with, or -1.
+// The closing _ must not be followed by a word character.
+func closingItalicUnderscore(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case strings.HasPrefix(s[i:], "__"):
+			i++
+		case s[i] == '_' && i > 1 && (i+1 == len(s) || !isWordChar(s[i+1])):
+			return i
+		}
+	}
+	return -1
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isWordChar reports whether c is a letter, digit or underscore. Bytes of multibyte characters count as word characters.
+func isWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// isEscapable reports whether c can be escaped with a backslash.
+func isEscapable(c byte) bool {
+	return c < 0x80 && !isSpace(c) && !isWordChar(c) || c == '_'
+}
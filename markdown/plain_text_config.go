@@ -0,0 +1,108 @@
+package markdown
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// DefaultPlainTextConfig returns a new PlainTextConfig with all default values.
+func DefaultPlainTextConfig() *PlainTextConfig {
+	return &PlainTextConfig{
+		User: func(id snowflake.ID) string {
+			return "@" + id.String()
+		},
+		Role: func(id snowflake.ID) string {
+			return "@" + id.String()
+		},
+		Channel: func(id snowflake.ID) string {
+			return "#" + id.String()
+		},
+		Emoji: func(emoji Emoji) string {
+			return ":" + emoji.Name + ":"
+		},
+		Timestamp: formatTimestamp,
+	}
+}
+
+// PlainTextConfig can be used to configure how PlainText renders mentions.
+type PlainTextConfig struct {
+	User      func(id snowflake.ID) string
+	Role      func(id snowflake.ID) string
+	Channel   func(id snowflake.ID) string
+	Emoji     func(emoji Emoji) string
+	Timestamp func(timestamp Timestamp) string
+	// Spoiler replaces the content of spoilers if set
+	Spoiler string
+}
+
+// PlainTextConfigOpt is a functional option for configuring PlainText.
+type PlainTextConfigOpt func(config *PlainTextConfig)
+
+// Apply applies the given PlainTextConfigOpt(s) to the PlainTextConfig.
+func (c *PlainTextConfig) Apply(opts []PlainTextConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithUserResolver sets the func which returns the text of UserMention(s), for example the name of the user.
+func WithUserResolver(resolver func(id snowflake.ID) string) PlainTextConfigOpt {
+	return func(config *PlainTextConfig) {
+		config.User = resolver
+	}
+}
+
+// WithRoleResolver sets the func which returns the text of RoleMention(s).
+func WithRoleResolver(resolver func(id snowflake.ID) string) PlainTextConfigOpt {
+	return func(config *PlainTextConfig) {
+		config.Role = resolver
+	}
+}
+
+// WithChannelResolver sets the func which returns the text of ChannelMention(s).
+func WithChannelResolver(resolver func(id snowflake.ID) string) PlainTextConfigOpt {
+	return func(config *PlainTextConfig) {
+		config.Channel = resolver
+	}
+}
+
+// WithEmojiFormatter sets the func which returns the text of custom Emoji(s).
+func WithEmojiFormatter(formatter func(emoji Emoji) string) PlainTextConfigOpt {
+	return func(config *PlainTextConfig) {
+		config.Emoji = formatter
+	}
+}
+
+// WithTimestampFormatter sets the func which returns the text of Timestamp(s).
+func WithTimestampFormatter(formatter func(timestamp Timestamp) string) PlainTextConfigOpt {
+	return func(config *PlainTextConfig) {
+		config.Timestamp = formatter
+	}
+}
+
+// WithSpoilerReplacement replaces the content of spoilers with the given text.
+func WithSpoilerReplacement(replacement string) PlainTextConfigOpt {
+	return func(config *PlainTextConfig) {
+		config.Spoiler = replacement
+	}
+}
+
+// formatTimestamp formats the Timestamp in UTC like the Discord client does for its discord.TimestampStyle.
+func formatTimestamp(timestamp Timestamp) string {
+	t := timestamp.Time.UTC()
+	switch timestamp.Style {
+	case discord.TimestampStyleShortTime:
+		return t.Format("15:04")
+	case discord.TimestampStyleLongTime:
+		return t.Format("15:04:05")
+	case discord.TimestampStyleShortDate:
+		return t.Format("02/01/2006")
+	case discord.TimestampStyleLongDate:
+		return t.Format("2 January 2006")
+	case discord.TimestampStyleLongDateTime:
+		return t.Format("Monday, 2 January 2006 15:04")
+	default:
+		return t.Format("2 January 2006 15:04")
+	}
+}
@@ -0,0 +1,180 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// PlainText renders the Node(s) as text without any formatting. Mentions are resolved with the PlainTextConfigOpt(s).
+func PlainText(nodes []Node, opts ...PlainTextConfigOpt) string {
+	cfg := DefaultPlainTextConfig()
+	cfg.Apply(opts)
+
+	var b strings.Builder
+	writePlainText(&b, nodes, cfg)
+	return b.String()
+}
+
+func writePlainText(b *strings.Builder, nodes []Node, cfg *PlainTextConfig) {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case Text:
+			b.WriteString(n.Content)
+		case Bold:
+			writePlainText(b, n.Children, cfg)
+		case Italic:
+			writePlainText(b, n.Children, cfg)
+		case Underline:
+			writePlainText(b, n.Children, cfg)
+		case Strikethrough:
+			writePlainText(b, n.Children, cfg)
+		case Spoiler:
+			if cfg.Spoiler != "" {
+				b.WriteString(cfg.Spoiler)
+				continue
+			}
+			writePlainText(b, n.Children, cfg)
+		case InlineCode:
+			b.WriteString(n.Content)
+		case CodeBlock:
+			b.WriteString(n.Content)
+		case BlockQuote:
+			writePlainText(b, n.Children, cfg)
+		case Header:
+			writePlainText(b, n.Children, cfg)
+		case Subtext:
+			writePlainText(b, n.Children, cfg)
+		case ListItem:
+			b.WriteString(strings.Repeat(" ", n.Indent))
+			if n.Ordered {
+				b.WriteString(strconv.Itoa(n.Number) + ". ")
+			} else {
+				b.WriteString("- ")
+			}
+			writePlainText(b, n.Children, cfg)
+		case Link:
+			if n.Masked() {
+				writePlainText(b, n.Children, cfg)
+				continue
+			}
+			b.WriteString(n.URL)
+		case UserMention:
+			b.WriteString(cfg.User(n.ID))
+		case RoleMention:
+			b.WriteString(cfg.Role(n.ID))
+		case ChannelMention:
+			b.WriteString(cfg.Channel(n.ID))
+		case Emoji:
+			b.WriteString(cfg.Emoji(n))
+		case Timestamp:
+			b.WriteString(cfg.Timestamp(n))
+		case SlashCommandMention:
+			b.WriteString("/" + n.Name)
+		case EveryoneMention:
+			b.WriteString("@everyone")
+		case HereMention:
+			b.WriteString("@here")
+		}
+	}
+}
+
+// Render renders the Node(s) back into markdown. Text is escaped, so the result shows the same as the parsed content,
+// but it is not necessarily byte for byte the same.
+// This can be used to change parts of a message, for example translating Text nodes, while keeping its formatting.
+func Render(nodes []Node) string {
+	var b strings.Builder
+	writeMarkdown(&b, nodes)
+	return b.String()
+}
+
+func writeMarkdown(b *strings.Builder, nodes []Node) {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case Text:
+			b.WriteString(Escape(n.Content))
+		case Bold:
+			writeWrapped(b, "**", n.Children)
+		case Italic:
+			writeWrapped(b, "*", n.Children)
+		case Underline:
+			writeWrapped(b, "__", n.Children)
+		case Strikethrough:
+			writeWrapped(b, "~~", n.Children)
+		case Spoiler:
+			writeWrapped(b, "||", n.Children)
+		case InlineCode:
+			delim := "`"
+			if strings.Contains(n.Content, "`") {
+				delim = "``"
+			}
+			b.WriteString(delim + n.Content + delim)
+		case CodeBlock:
+			b.WriteString("```")
+			if n.Language != "" {
+				b.WriteString(n.Language + "\n")
+			}
+			b.WriteString(n.Content + "```")
+		case BlockQuote:
+			content := Render(n.Children)
+			if n.Multiline {
+				b.WriteString(">>> " + content)
+				continue
+			}
+			b.WriteString("> " + strings.ReplaceAll(content, "\n", "\n> "))
+		case Header:
+			b.WriteString(strings.Repeat("#", n.Level) + " ")
+			writeMarkdown(b, n.Children)
+		case Subtext:
+			b.WriteString("-# ")
+			writeMarkdown(b, n.Children)
+		case ListItem:
+			b.WriteString(strings.Repeat(" ", n.Indent))
+			if n.Ordered {
+				b.WriteString(strconv.Itoa(n.Number) + ". ")
+			} else {
+				b.WriteString("- ")
+			}
+			writeMarkdown(b, n.Children)
+		case Link:
+			url := n.URL
+			if n.Suppressed {
+				url = "<" + url + ">"
+			}
+			if n.Masked() {
+				b.WriteString("[")
+				writeMarkdown(b, n.Children)
+				b.WriteString("](" + url + ")")
+				continue
+			}
+			b.WriteString(url)
+		case UserMention:
+			b.WriteString(discord.UserMention(n.ID))
+		case RoleMention:
+			b.WriteString(discord.RoleMention(n.ID))
+		case ChannelMention:
+			b.WriteString(discord.ChannelMention(n.ID))
+		case Emoji:
+			if n.Animated {
+				b.WriteString(discord.AnimatedEmojiMention(n.ID, n.Name))
+				continue
+			}
+			b.WriteString(discord.EmojiMention(n.ID, n.Name))
+		case Timestamp:
+			b.WriteString(n.Style.Format(n.Time.Unix()))
+		case SlashCommandMention:
+			b.WriteString(discord.SlashCommandMention(n.ID, n.Name))
+		case EveryoneMention:
+			b.WriteString("@everyone")
+		case HereMention:
+			b.WriteString("@here")
+		}
+	}
+}
+
+func writeWrapped(b *strings.Builder, delim string, children []Node) {
+	b.WriteString(delim)
+	writeMarkdown(b, children)
+	b.WriteString(delim)
+}
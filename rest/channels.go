@@ -1,6 +1,11 @@
 package rest
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -36,6 +41,17 @@ type Channels interface {
 	BulkDeleteMessages(channelID snowflake.ID, messageIDs []snowflake.ID, opts ...RequestOpt) error
 	CrosspostMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 
+	// CreateAttachmentUploads requests upload urls for cloud attachments which can be used to upload big files outside the message request
+	CreateAttachmentUploads(channelID snowflake.ID, files []discord.AttachmentUploadCreate, opts ...RequestOpt) ([]discord.AttachmentUpload, error)
+	// UploadAttachment uploads the given file to the upload url of a cloud attachment. The file is streamed from its io.Reader.
+	// Only the context of the RequestOpt(s) is used, headers are not sent to the upload url
+	UploadAttachment(upload discord.AttachmentUpload, file *discord.File, opts ...RequestOpt) error
+	// DeleteAttachmentUpload deletes an uploaded cloud attachment which was not used in a message
+	DeleteAttachmentUpload(uploadFilename string, opts ...RequestOpt) error
+	// UploadAttachments requests upload urls for the given files, uploads them & returns the discord.AttachmentCreate(s) to reference them in a message.
+	// The io.Reader of the files must implement io.Seeker to determine their size
+	UploadAttachments(channelID snowflake.ID, files []*discord.File, opts ...RequestOpt) ([]discord.AttachmentCreate, error)
+
 	GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) ([]discord.User, error)
 	AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveOwnReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
@@ -180,6 +196,118 @@ func (s *channelImpl) CrosspostMessage(channelID snowflake.ID, messageID snowfla
 	return
 }
 
+func (s *channelImpl) CreateAttachmentUploads(channelID snowflake.ID, files []discord.AttachmentUploadCreate, opts ...RequestOpt) (uploads []discord.AttachmentUpload, err error) {
+	var rs struct {
+		Attachments []discord.AttachmentUpload `json:"attachments"`
+	}
+	err = s.client.Do(CreateAttachmentUploads.Compile(nil, channelID), discord.AttachmentUploadsCreate{Files: files}, &rs, opts...)
+	if err == nil {
+		uploads = rs.Attachments
+	}
+	return
+}
+
+func (s *channelImpl) UploadAttachment(upload discord.AttachmentUpload, file *discord.File, opts ...RequestOpt) error {
+	// the caller owns the file, so it must not be closed by the http.Client
+	rq, err := http.NewRequest(http.MethodPut, upload.UploadURL, io.NopCloser(file.Reader))
+	if err != nil {
+		return err
+	}
+	if seeker, ok := file.Reader.(io.Seeker); ok {
+		offset, size, err := seekerSize(seeker)
+		if err != nil {
+			return fmt.Errorf("failed to get size of file %s: %w", file.Name, err)
+		}
+		rq.ContentLength = size
+		rq.GetBody = func() (io.ReadCloser, error) {
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return io.NopCloser(file.Reader), nil
+		}
+	}
+	rq.Header.Set("Content-Type", "application/octet-stream")
+
+	// the upload url is not Discord's api, so only the context is taken from the opts & headers like the bot token are not sent
+	config := DefaultRequestConfig(&http.Request{Header: http.Header{}})
+	config.Apply(opts)
+
+	rs, err := s.client.HTTPClient().Do(rq.WithContext(config.Ctx))
+	if err != nil {
+		return fmt.Errorf("error uploading attachment: %w", err)
+	}
+	defer rs.Body.Close()
+
+	rsBody, _ := io.ReadAll(rs.Body)
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 {
+		return NewError(rq, nil, rs, rsBody)
+	}
+	return nil
+}
+
+func (s *channelImpl) DeleteAttachmentUpload(uploadFilename string, opts ...RequestOpt) error {
+	return s.client.Do(DeleteAttachmentUpload.Compile(nil, url.PathEscape(uploadFilename)), nil, nil, opts...)
+}
+
+func (s *channelImpl) UploadAttachments(channelID snowflake.ID, files []*discord.File, opts ...RequestOpt) ([]discord.AttachmentCreate, error) {
+	uploadCreates := make([]discord.AttachmentUploadCreate, len(files))
+	for i, file := range files {
+		seeker, ok := file.Reader.(io.Seeker)
+		if !ok {
+			return nil, fmt.Errorf("file %s does not implement io.Seeker", file.Name)
+		}
+		_, size, err := seekerSize(seeker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get size of file %s: %w", file.Name, err)
+		}
+		uploadCreates[i] = discord.AttachmentUploadCreate{
+			ID:       i,
+			Filename: fileName(file),
+			FileSize: size,
+		}
+	}
+
+	uploads, err := s.CreateAttachmentUploads(channelID, uploadCreates, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]discord.AttachmentCreate, len(uploads))
+	for i, upload := range uploads {
+		if upload.ID < 0 || upload.ID >= len(files) {
+			return nil, fmt.Errorf("received upload url for unknown file id %d", upload.ID)
+		}
+		file := files[upload.ID]
+		if err = s.UploadAttachment(upload, file, opts...); err != nil {
+			return nil, err
+		}
+		attachments[i] = upload.AttachmentCreate(fileName(file), file.Description)
+	}
+	return attachments, nil
+}
+
+func seekerSize(seeker io.Seeker) (int64, int64, error) {
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err = seeker.Seek(offset, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+	return offset, end - offset, nil
+}
+
+func fileName(file *discord.File) string {
+	if file.Flags.Has(discord.FileFlagSpoiler) {
+		return "SPOILER_" + file.Name
+	}
+	return file.Name
+}
+
 func (s *channelImpl) GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) (users []discord.User, err error) {
 	err = s.client.Do(GetReactions.Compile(nil, channelID, messageID, emoji), nil, &users, opts...)
 	return
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestUploadAttachmentWithoutAuth(t *testing.T) {
+	var (
+		authorization string
+		uploaded      string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		uploaded = string(data)
+	}))
+	defer server.Close()
+
+	channels := NewChannels(NewClient("token"))
+	err := channels.UploadAttachment(
+		discord.AttachmentUpload{UploadURL: server.URL},
+		discord.NewFile("test.txt", "", strings.NewReader("hello world")),
+		WithToken(discord.TokenTypeBot, "token"),
+		WithHeader("X-Audit-Log-Reason", "test"),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, authorization, "the bot token must not be sent to the upload url")
+	assert.Equal(t, "hello world", uploaded)
+}
+
+// closeRecorder is a file like *os.File which records whether it was closed.
+type closeRecorder struct {
+	*strings.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestUploadAttachmentKeepsFileOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	file := &closeRecorder{Reader: strings.NewReader("hello world")}
+	channels := NewChannels(NewClient("token"))
+	require.NoError(t, channels.UploadAttachment(discord.AttachmentUpload{UploadURL: server.URL}, discord.NewFile("test.txt", "", file)))
+	assert.False(t, file.closed, "the file is owned by the caller and must not be closed")
+}
+
+func TestNotReplayableBodyIsNotRetried(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.RetryNonIdempotent = true
+	policy.BaseDelay = 0
+	client := NewClient("token", WithURL(server.URL), WithRetryPolicy(policy))
+
+	body, err := discord.NewMultipartBody(discord.MessageCreate{}, discord.NewFile("test.txt", "", io.MultiReader(strings.NewReader("hello world"))))
+	require.NoError(t, err)
+	err = client.Do(CreateMessage.Compile(nil, 1), body, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), hits.Load(), "a body with a file without io.Seeker can't be sent again")
+
+	body, err = discord.NewMultipartBody(discord.MessageCreate{}, discord.NewFile("test.txt", "", strings.NewReader("hello world")))
+	require.NoError(t, err)
+	hits.Store(0)
+	err = client.Do(CreateMessage.Compile(nil, 1), body, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(DefaultRetryMaxAttempts), hits.Load())
+}
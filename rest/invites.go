@@ -18,6 +18,10 @@ type Invites interface {
 	DeleteInvite(code string, opts ...RequestOpt) (*discord.Invite, error)
 	GetGuildInvites(guildID snowflake.ID, opts ...RequestOpt) ([]discord.Invite, error)
 	GetChannelInvites(channelID snowflake.ID, opts ...RequestOpt) ([]discord.Invite, error)
+	// GetGuildExtendedInvites returns the invites of a guild including their metadata like uses & max age.
+	GetGuildExtendedInvites(guildID snowflake.ID, opts ...RequestOpt) ([]discord.ExtendedInvite, error)
+	// GetChannelExtendedInvites returns the invites of a channel including their metadata like uses & max age.
+	GetChannelExtendedInvites(channelID snowflake.ID, opts ...RequestOpt) ([]discord.ExtendedInvite, error)
 }
 
 type inviteImpl struct {
@@ -48,3 +52,13 @@ func (s *inviteImpl) GetChannelInvites(channelID snowflake.ID, opts ...RequestOp
 	err = s.client.Do(GetChannelInvites.Compile(nil, channelID), nil, &invites, opts...)
 	return
 }
+
+func (s *inviteImpl) GetGuildExtendedInvites(guildID snowflake.ID, opts ...RequestOpt) (invites []discord.ExtendedInvite, err error) {
+	err = s.client.Do(GetGuildInvites.Compile(nil, guildID), nil, &invites, opts...)
+	return
+}
+
+func (s *inviteImpl) GetChannelExtendedInvites(channelID snowflake.ID, opts ...RequestOpt) (invites []discord.ExtendedInvite, err error) {
+	err = s.client.Do(GetChannelInvites.Compile(nil, channelID), nil, &invites, opts...)
+	return
+}
@@ -24,6 +24,9 @@ type RequestConfig struct {
 	Ctx     context.Context
 	Checks  []Check
 	Delay   time.Duration
+
+	RetryPolicy *RetryPolicy
+	Idempotent  bool
 }
 
 // Check is a function which gets executed right before a request is made
@@ -63,6 +66,21 @@ func WithDelay(delay time.Duration) RequestOpt {
 	}
 }
 
+// WithRequestRetryPolicy overrides the RetryPolicy of the rest client for this request
+func WithRequestRetryPolicy(policy RetryPolicy) RequestOpt {
+	return func(config *RequestConfig) {
+		config.RetryPolicy = &policy
+	}
+}
+
+// WithIdempotent marks the request as safe to retry on transient failures even if its http method is not idempotent.
+// This is useful for requests which are deduplicated by Discord, like messages with an enforced nonce
+func WithIdempotent() RequestOpt {
+	return func(config *RequestConfig) {
+		config.Idempotent = true
+	}
+}
+
 // WithHeader adds a custom header to the request
 func WithHeader(key string, value string) RequestOpt {
 	return func(config *RequestConfig) {
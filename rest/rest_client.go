@@ -23,10 +23,12 @@ func NewClient(botToken string, opts ...ConfigOpt) Client {
 
 	config.RateLimiter.Reset()
 
-	return &clientImpl{
+	client := &clientImpl{
 		botToken: botToken,
 		config:   *config,
 	}
+	client.roundTrip = chainInterceptors(config.Interceptors, client.doRequest)
+	return client
 }
 
 // Client allows doing requests to different endpoints
@@ -45,8 +47,9 @@ type Client interface {
 }
 
 type clientImpl struct {
-	botToken string
-	config   Config
+	botToken  string
+	config    Config
+	roundTrip RoundTrip
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
@@ -62,15 +65,23 @@ func (c *clientImpl) RateLimiter() RateLimiter {
 	return c.config.RateLimiter
 }
 
-func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, tries int, opts []RequestOpt) error {
+// retry does the request & retries it on rate limits or transient failures.
+// tries counts the attempts which were rate limited, attempts counts the attempts which failed with a transient error
+func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, tries int, attempts int, opts []RequestOpt) error {
 	var (
-		rawRqBody   []byte
-		err         error
-		contentType string
+		rawRqBody     []byte
+		err           error
+		contentType   string
+		multipartBody *discord.MultipartBody
 	)
 
 	if rqBody != nil {
 		switch v := rqBody.(type) {
+		case *discord.MultipartBody:
+			contentType = v.ContentType
+			rawRqBody = v.Payload()
+			multipartBody = v
+
 		case *discord.MultipartBuffer:
 			contentType = v.ContentType
 			rawRqBody = v.Buffer.Bytes()
@@ -88,7 +99,11 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		c.config.Logger.Debug("new request", slog.String("endpoint", endpoint.URL), slog.String("body", string(rawRqBody)))
 	}
 
-	rq, err := http.NewRequest(endpoint.Endpoint.Method, c.config.URL+endpoint.URL, bytes.NewReader(rawRqBody))
+	var body io.Reader
+	if multipartBody == nil {
+		body = bytes.NewReader(rawRqBody)
+	}
+	rq, err := http.NewRequest(endpoint.Endpoint.Method, c.config.URL+endpoint.URL, body)
 	if err != nil {
 		return err
 	}
@@ -121,7 +136,7 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 	if err != nil {
 		return fmt.Errorf("error locking bucket in rest client: %w", err)
 	}
-	rq = rq.WithContext(config.Ctx)
+	rq = config.Request.WithContext(config.Ctx)
 
 	for _, check := range config.Checks {
 		if !check() {
@@ -130,9 +145,43 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		}
 	}
 
-	rs, err := c.HTTPClient().Do(config.Request)
+	// bodies with files which can't be rewound can't be sent again
+	replayable := true
+	if multipartBody != nil {
+		replayable = multipartBody.Replayable()
+		// the length has to be known before the body is streamed by Reader
+		rq.ContentLength = multipartBody.Len()
+		// the multipart body is streamed, so only open it once we are sure the request is sent
+		rq.Body = multipartBody.Reader()
+		if replayable {
+			rq.GetBody = func() (io.ReadCloser, error) {
+				return multipartBody.Reader(), nil
+			}
+		}
+	}
+
+	call := &Call{
+		Endpoint: endpoint,
+		Request:  rq,
+		Tries:    tries,
+		Attempt:  attempts,
+	}
+	rs, err := c.roundTrip(call)
+	if multipartBody != nil {
+		// an Interceptor may have returned without reading the whole body, closing it stops its writer
+		_ = rq.Body.Close()
+	}
+	// an Interceptor may have replaced the request, so errors should reference the one which was actually sent
+	rq = call.Request
 	if err != nil {
 		_ = c.RateLimiter().UnlockBucket(endpoint, nil)
+		if replayable && c.shouldRetry(config, endpoint, attempts, nil, err) {
+			c.config.Logger.Debug("retrying request after transient error", slog.String("endpoint", endpoint.URL), slog.Int("attempt", attempts), slog.String("err", err.Error()))
+			if err = c.waitRetry(config, attempts, nil); err != nil {
+				return err
+			}
+			return c.retry(endpoint, rqBody, rsBody, tries, attempts+1, opts)
+		}
 		return fmt.Errorf("error doing request in rest client: %w", err)
 	}
 
@@ -159,16 +208,76 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		return nil
 
 	case http.StatusTooManyRequests:
-		if tries >= c.RateLimiter().MaxRetries() {
+		if tries >= c.RateLimiter().MaxRetries() || !replayable {
 			return NewError(rq, rawRqBody, rs, rawRsBody)
 		}
-		return c.retry(endpoint, rqBody, rsBody, tries+1, opts)
+		return c.retry(endpoint, rqBody, rsBody, tries+1, attempts, opts)
 
 	default:
+		if replayable && c.shouldRetry(config, endpoint, attempts, rs, nil) {
+			c.config.Logger.Debug("retrying request after transient error", slog.String("endpoint", endpoint.URL), slog.Int("attempt", attempts), slog.String("code", rs.Status))
+			if err = c.waitRetry(config, attempts, rs); err != nil {
+				return err
+			}
+			return c.retry(endpoint, rqBody, rsBody, tries, attempts+1, opts)
+		}
 		return NewError(rq, rawRqBody, rs, rawRsBody)
 	}
 }
 
+func (c *clientImpl) doRequest(call *Call) (*http.Response, error) {
+	start := time.Now()
+	rs, err := c.HTTPClient().Do(call.Request)
+	setCallResult(call, start, rs)
+	return rs, err
+}
+
+func (c *clientImpl) shouldRetry(config *RequestConfig, endpoint *CompiledEndpoint, attempts int, rs *http.Response, err error) bool {
+	// the caller gave up on the request, don't retry it
+	if config.Ctx.Err() != nil {
+		return false
+	}
+	policy := c.config.RetryPolicy
+	if config.RetryPolicy != nil {
+		policy = *config.RetryPolicy
+	}
+	return policy.ShouldRetry(endpoint.Endpoint.Method, config.Idempotent, attempts, rs, err)
+}
+
+func (c *clientImpl) waitRetry(config *RequestConfig, attempts int, rs *http.Response) error {
+	policy := c.config.RetryPolicy
+	if config.RetryPolicy != nil {
+		policy = *config.RetryPolicy
+	}
+	delay := policy.Delay(attempts, rs)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-config.Ctx.Done():
+		return config.Ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func (c *clientImpl) Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
-	return c.retry(endpoint, rqBody, rsBody, 1, opts)
+	if c.config.ValidatePayloads {
+		if err := validatePayload(rqBody); err != nil {
+			return err
+		}
+	}
+	return c.retry(endpoint, rqBody, rsBody, 1, 1, opts)
+}
+
+func validatePayload(rqBody any) error {
+	if multipartBody, ok := rqBody.(*discord.MultipartBody); ok {
+		rqBody = multipartBody.Value()
+	}
+	if validator, ok := rqBody.(discord.Validator); ok {
+		return validator.Validate()
+	}
+	return nil
 }
@@ -10,9 +10,10 @@ import (
 // DefaultConfig is the configuration which is used by default
 func DefaultConfig() *Config {
 	return &Config{
-		Logger:     slog.Default(),
-		HTTPClient: &http.Client{Timeout: 20 * time.Second},
-		URL:        fmt.Sprintf("%sv%d", API, Version),
+		Logger:      slog.Default(),
+		HTTPClient:  &http.Client{Timeout: 20 * time.Second},
+		URL:         fmt.Sprintf("%sv%d", API, Version),
+		RetryPolicy: DefaultRetryPolicy(),
 	}
 }
 
@@ -24,6 +25,9 @@ type Config struct {
 	RateLimiterConfigOpts []RateLimiterConfigOpt
 	URL                   string
 	UserAgent             string
+	RetryPolicy           RetryPolicy
+	Interceptors          []Interceptor
+	ValidatePayloads      bool
 }
 
 // ConfigOpt can be used to supply optional parameters to NewClient
@@ -80,3 +84,25 @@ func WithUserAgent(userAgent string) ConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithRetryPolicy sets the RetryPolicy used for transient failures of all requests. It can be overridden per request with WithRequestRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) ConfigOpt {
+	return func(config *Config) {
+		config.RetryPolicy = policy
+	}
+}
+
+// WithInterceptors adds Interceptor(s) which are called around every http request. The first Interceptor is the outermost one
+func WithInterceptors(interceptors ...Interceptor) ConfigOpt {
+	return func(config *Config) {
+		config.Interceptors = append(config.Interceptors, interceptors...)
+	}
+}
+
+// WithPayloadValidation validates all request bodies implementing discord.Validator before sending them.
+// Invalid payloads return discord.ValidationErrors instead of being rejected by Discord.
+func WithPayloadValidation() ConfigOpt {
+	return func(config *Config) {
+		config.ValidatePayloads = true
+	}
+}
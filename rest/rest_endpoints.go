@@ -203,6 +203,9 @@ var (
 
 	CrosspostMessage = NewEndpoint(http.MethodPost, "/channels/{channel.id}/messages/{message.id}/crosspost")
 
+	CreateAttachmentUploads = NewEndpoint(http.MethodPost, "/channels/{channel.id}/attachments")
+	DeleteAttachmentUpload  = NewEndpoint(http.MethodDelete, "/attachments/{upload.filename}")
+
 	GetReactions               = NewEndpoint(http.MethodGet, "/channels/{channel.id}/messages/{message.id}/reactions/{emoji}")
 	AddReaction                = NewEndpoint(http.MethodPut, "/channels/{channel.id}/messages/{message.id}/reactions/{emoji}/@me")
 	RemoveOwnReaction          = NewEndpoint(http.MethodDelete, "/channels/{channel.id}/messages/{message.id}/reactions/{emoji}/@me")
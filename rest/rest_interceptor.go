@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+)
+
+// Call holds the information about a single http request done by the Client which is passed through the Interceptor chain.
+type Call struct {
+	// Endpoint is the CompiledEndpoint the request is done to
+	Endpoint *CompiledEndpoint
+	// Request is the http.Request which is sent. Interceptors can modify it or replace it with a new one
+	Request *http.Request
+	// Tries is the number of times this request was sent because of rate limits, starting at 1
+	Tries int
+	// Attempt is the number of times this request was sent because of transient failures, starting at 1
+	Attempt int
+	// Duration is the time the http request took. It is set once the next RoundTrip returns.
+	// If an Interceptor returns its own http.Response, it is the time that Interceptor took
+	Duration time.Duration
+	// Bucket is the rate limit bucket of the returned http.Response. It is set once the next RoundTrip returns
+	Bucket string
+}
+
+// RoundTrip sends the Call to the next Interceptor or Discord and returns the http.Response.
+type RoundTrip func(call *Call) (*http.Response, error)
+
+// Interceptor is a middleware around every http request the Client does.
+// It is called after the rate limit bucket has been acquired and can inspect or modify the Call before calling next, inspect the http.Response after it,
+// or return its own http.Response without calling next at all.
+type Interceptor func(call *Call, next RoundTrip) (*http.Response, error)
+
+// chainInterceptors wraps the given RoundTrip with the Interceptor(s). The first Interceptor is the outermost one.
+func chainInterceptors(interceptors []Interceptor, roundTrip RoundTrip) RoundTrip {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := roundTrip
+		roundTrip = func(call *Call) (*http.Response, error) {
+			start := time.Now()
+			rs, err := interceptor(call, next)
+			// the Interceptor may have returned its own http.Response without calling next
+			setCallResult(call, start, rs)
+			return rs, err
+		}
+	}
+	return roundTrip
+}
+
+// setCallResult sets the Duration & Bucket of the Call if no inner RoundTrip did already.
+func setCallResult(call *Call, start time.Time, rs *http.Response) {
+	if call.Duration == 0 {
+		call.Duration = time.Since(start)
+	}
+	if call.Bucket == "" && rs != nil && rs.Header != nil {
+		call.Bucket = rs.Header.Get("X-RateLimit-Bucket")
+	}
+}
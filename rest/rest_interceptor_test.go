@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func newInterceptorTestServer(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Header.Get("X-Test") == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":50035,"message":"Invalid Form Body"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","username":"real"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestInterceptorChainOrder(t *testing.T) {
+	var hits atomic.Int32
+	server := newInterceptorTestServer(t, &hits)
+
+	var order []string
+	trace := func(name string) Interceptor {
+		return func(call *Call, next RoundTrip) (*http.Response, error) {
+			order = append(order, name+" before")
+			rs, err := next(call)
+			order = append(order, name+" after")
+			return rs, err
+		}
+	}
+	client := NewClient("token", WithURL(server.URL), WithInterceptors(trace("first"), trace("second")))
+
+	var user struct {
+		Username string `json:"username"`
+	}
+	require.NoError(t, client.Do(GetCurrentUser.Compile(nil), nil, &user))
+	assert.Equal(t, "real", user.Username)
+	assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, order)
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	var hits atomic.Int32
+	server := newInterceptorTestServer(t, &hits)
+
+	var outerCall *Call
+	outer := func(call *Call, next RoundTrip) (*http.Response, error) {
+		rs, err := next(call)
+		outerCall = call
+		return rs, err
+	}
+	canned := func(call *Call, next RoundTrip) (*http.Response, error) {
+		time.Sleep(time.Millisecond)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Ratelimit-Bucket": []string{"canned"}, "X-Ratelimit-Reset-After": []string{"1"}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"1","username":"canned"}`))),
+		}, nil
+	}
+	client := NewClient("token", WithURL(server.URL), WithInterceptors(outer, canned))
+
+	var user struct {
+		Username string `json:"username"`
+	}
+	require.NoError(t, client.Do(GetCurrentUser.Compile(nil), nil, &user))
+	assert.Equal(t, "canned", user.Username)
+	assert.Zero(t, hits.Load(), "the request should not reach the server")
+
+	require.NotNil(t, outerCall)
+	assert.Positive(t, outerCall.Duration)
+	assert.Equal(t, "canned", outerCall.Bucket)
+}
+
+func TestInterceptorShortCircuitMultipartBody(t *testing.T) {
+	var hits atomic.Int32
+	server := newInterceptorTestServer(t, &hits)
+
+	canned := func(call *Call, next RoundTrip) (*http.Response, error) {
+		// only read the start of the body, like an Interceptor which only inspects the payload
+		_, _ = call.Request.Body.Read(make([]byte, 10))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Ratelimit-Bucket": []string{"canned"}, "X-Ratelimit-Reset-After": []string{"1"}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"1"}`))),
+		}, nil
+	}
+	client := NewClient("token", WithURL(server.URL), WithInterceptors(canned))
+
+	body, err := discord.NewMultipartBody(discord.MessageCreate{}, discord.NewFile("test.txt", "", bytes.NewReader(bytes.Repeat([]byte("a"), 1<<20))))
+	require.NoError(t, err)
+	require.NoError(t, client.Do(CreateMessage.Compile(nil, 1), body, nil))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := body.WriteTo(io.Discard)
+		done <- err
+	}()
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the body is still locked by the request which was short-circuited")
+	}
+}
+
+func TestInterceptorReplacedRequest(t *testing.T) {
+	var hits atomic.Int32
+	server := newInterceptorTestServer(t, &hits)
+
+	var replaced *http.Request
+	replace := func(call *Call, next RoundTrip) (*http.Response, error) {
+		replaced = call.Request.Clone(call.Request.Context())
+		replaced.Header.Set("X-Test", "fail")
+		call.Request = replaced
+		return next(call)
+	}
+	client := NewClient("token", WithURL(server.URL), WithInterceptors(replace))
+
+	err := client.Do(GetCurrentUser.Compile(nil), nil, nil)
+	var restErr Error
+	require.True(t, errors.As(err, &restErr))
+	assert.Equal(t, JSONErrorCode(50035), restErr.Code)
+	assert.Same(t, replaced, restErr.Request, "the error should reference the request which was sent")
+	assert.Equal(t, int32(1), hits.Load())
+}
@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterFractionalResetAfter(t *testing.T) {
+	l := NewRateLimiter().(*rateLimiterImpl)
+	endpoint := GetGateway.Compile(nil)
+
+	require.NoError(t, l.WaitBucket(context.Background(), endpoint))
+	start := time.Now()
+	require.NoError(t, l.UnlockBucket(endpoint, &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ratelimit-Bucket":      []string{"bucket"},
+			"X-Ratelimit-Limit":       []string{"5"},
+			"X-Ratelimit-Remaining":   []string{"0"},
+			"X-Ratelimit-Reset-After": []string{"0.5"},
+		},
+	}))
+
+	b := l.getBucket(endpoint, false)
+	require.NotNil(t, b)
+	assert.Equal(t, 0, b.Remaining)
+	assert.WithinDuration(t, start.Add(500*time.Millisecond), b.Reset, 100*time.Millisecond, "the fractional reset after should not be truncated to whole seconds")
+}
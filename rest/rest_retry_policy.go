@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultRetryBaseDelay is the delay before the first retry of a transient failure
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay is the upper bound of the delay between two retries of a transient failure
+	DefaultRetryMaxDelay = 10 * time.Second
+	// DefaultRetryMaxAttempts is the maximum number of attempts done for a request which fails with a transient error
+	DefaultRetryMaxAttempts = 3
+)
+
+// DefaultRetryPolicy returns the RetryPolicy which is used by default.
+// It retries idempotent requests on 500, 502, 503 & 504 responses, connection errors & timeouts up to 3 attempts.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		StatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+		BaseDelay:          DefaultRetryBaseDelay,
+		MaxDelay:           DefaultRetryMaxDelay,
+	}
+}
+
+// NoRetryPolicy returns a RetryPolicy which never retries transient failures.
+// Rate limited requests (429) are still retried according to RateLimiter.MaxRetries.
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// RetryPolicy describes which transient failures of a request should be retried and how long to wait between the attempts.
+// Rate limited requests (429) are not covered by the RetryPolicy, they are handled by the RateLimiter.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts (including the first one) for a request failing with a transient error
+	MaxAttempts int
+	// StatusCodes are the http status codes which are considered transient
+	StatusCodes []int
+	// RetryNetworkErrors retries requests which failed before a response was received, like connection resets or timeouts
+	RetryNetworkErrors bool
+	// RetryNonIdempotent also retries non-idempotent requests like POST or PATCH. This can lead to duplicated actions like messages being sent twice
+	RetryNonIdempotent bool
+	// BaseDelay is the delay before the first retry. Every following retry doubles the delay
+	BaseDelay time.Duration
+	// MaxDelay is the upper bound of the delay between two retries
+	MaxDelay time.Duration
+}
+
+// ShouldRetry returns whether a request which was attempted the given number of times should be retried.
+// Either rs or err is set depending on whether a response was received.
+func (p RetryPolicy) ShouldRetry(method string, idempotent bool, attempt int, rs *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if !p.RetryNonIdempotent && !idempotent && !isIdempotentMethod(method) {
+		return false
+	}
+	if err != nil {
+		return p.RetryNetworkErrors && isTransientError(err)
+	}
+	return rs != nil && slices.Contains(p.StatusCodes, rs.StatusCode)
+}
+
+// Delay returns the time to wait before doing the next attempt after the given number of attempts.
+// The delay grows exponentially with a random jitter & respects the Retry-After header of the response if present.
+// Both are capped at MaxDelay, so a server can't stall a request for an arbitrary amount of time.
+func (p RetryPolicy) Delay(attempt int, rs *http.Response) time.Duration {
+	if rs != nil {
+		if retryAfter, err := strconv.Atoi(rs.Header.Get("Retry-After")); err == nil && retryAfter > 0 {
+			delay := time.Duration(retryAfter) * time.Second
+			if p.MaxDelay > 0 && delay > p.MaxDelay {
+				delay = p.MaxDelay
+			}
+			return delay
+		}
+	}
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			delay = p.MaxDelay
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	// equal jitter: wait at least half of the delay and a random amount of the other half
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
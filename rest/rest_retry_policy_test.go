@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	nonIdempotentPolicy := DefaultRetryPolicy()
+	nonIdempotentPolicy.RetryNonIdempotent = true
+
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		method     string
+		idempotent bool
+		attempt    int
+		status     int
+		err        error
+		want       bool
+	}{
+		{name: "get 500", policy: policy, method: http.MethodGet, attempt: 1, status: http.StatusInternalServerError, want: true},
+		{name: "get 502", policy: policy, method: http.MethodGet, attempt: 1, status: http.StatusBadGateway, want: true},
+		{name: "get 404", policy: policy, method: http.MethodGet, attempt: 1, status: http.StatusNotFound, want: false},
+		{name: "get 200", policy: policy, method: http.MethodGet, attempt: 1, status: http.StatusOK, want: false},
+		{name: "max attempts reached", policy: policy, method: http.MethodGet, attempt: DefaultRetryMaxAttempts, status: http.StatusInternalServerError, want: false},
+		{name: "put is idempotent", policy: policy, method: http.MethodPut, attempt: 1, status: http.StatusServiceUnavailable, want: true},
+		{name: "delete is idempotent", policy: policy, method: http.MethodDelete, attempt: 1, status: http.StatusGatewayTimeout, want: true},
+		{name: "post is not idempotent", policy: policy, method: http.MethodPost, attempt: 1, status: http.StatusInternalServerError, want: false},
+		{name: "patch is not idempotent", policy: policy, method: http.MethodPatch, attempt: 1, status: http.StatusInternalServerError, want: false},
+		{name: "post marked idempotent", policy: policy, method: http.MethodPost, idempotent: true, attempt: 1, status: http.StatusInternalServerError, want: true},
+		{name: "post with RetryNonIdempotent", policy: nonIdempotentPolicy, method: http.MethodPost, attempt: 1, status: http.StatusInternalServerError, want: true},
+		{name: "connection reset", policy: policy, method: http.MethodGet, attempt: 1, err: &net.OpError{Op: "read", Err: errors.New("connection reset")}, want: true},
+		{name: "unexpected eof", policy: policy, method: http.MethodGet, attempt: 1, err: io.ErrUnexpectedEOF, want: true},
+		{name: "canceled", policy: policy, method: http.MethodGet, attempt: 1, err: context.Canceled, want: false},
+		{name: "network errors disabled", policy: RetryPolicy{MaxAttempts: 3}, method: http.MethodGet, attempt: 1, err: io.ErrUnexpectedEOF, want: false},
+		{name: "no retry policy", policy: NoRetryPolicy(), method: http.MethodGet, attempt: 1, status: http.StatusInternalServerError, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rs *http.Response
+			if tt.err == nil {
+				rs = &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			}
+			assert.Equal(t, tt.want, tt.policy.ShouldRetry(tt.method, tt.idempotent, tt.attempt, rs, tt.err))
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter int
+		min        time.Duration
+		max        time.Duration
+	}{
+		{name: "first attempt", attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "second attempt doubles", attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{name: "third attempt doubles again", attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "capped at max delay", attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+		{name: "retry after", attempt: 1, retryAfter: 1, min: time.Second, max: time.Second},
+		{name: "retry after capped at max delay", attempt: 1, retryAfter: 3600, min: time.Second, max: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+			if tt.retryAfter > 0 {
+				rs.Header.Set("Retry-After", strconv.Itoa(tt.retryAfter))
+			}
+			for i := 0; i < 20; i++ {
+				delay := policy.Delay(tt.attempt, rs)
+				assert.GreaterOrEqual(t, delay, tt.min)
+				assert.LessOrEqual(t, delay, tt.max)
+			}
+		})
+	}
+
+	assert.Zero(t, RetryPolicy{}.Delay(1, nil), "no base delay should not wait")
+}
+
+func TestWithIdempotent(t *testing.T) {
+	config := DefaultRequestConfig(nil)
+	assert.False(t, config.Idempotent)
+
+	config.Apply([]RequestOpt{WithIdempotent()})
+	assert.True(t, config.Idempotent)
+	assert.True(t, DefaultRetryPolicy().ShouldRetry(http.MethodPost, config.Idempotent, 1, &http.Response{StatusCode: http.StatusBadGateway}, nil))
+}
@@ -0,0 +1,231 @@
+package resttest
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// channelGuildID returns the guild of the channel or 0 for unknown and private channels.
+func (s *Server) channelGuildID(channelID snowflake.ID) snowflake.ID {
+	if channel, ok := s.state.channels[channelID].(discord.GuildChannel); ok {
+		return channel.GuildID()
+	}
+	return 0
+}
+
+func (s *Server) getGuildChannels(r *request) response {
+	guildID := r.id("guild.id")
+	if _, found := s.state.guilds[guildID]; !found {
+		return notFound(codeUnknownGuild, "Unknown Guild")
+	}
+	channels := []discord.Channel{}
+	for channelID, channel := range s.state.channels {
+		if s.channelGuildID(channelID) == guildID {
+			channels = append(channels, channel)
+		}
+	}
+	slices.SortFunc(channels, func(a, b discord.Channel) int {
+		return compareIDs(a.ID(), b.ID())
+	})
+	return ok(channels)
+}
+
+func (s *Server) createGuildChannel(r *request) response {
+	guildID := r.id("guild.id")
+	if _, found := s.state.guilds[guildID]; !found {
+		return notFound(codeUnknownGuild, "Unknown Guild")
+	}
+	data, err := json.Merge(r.body, []byte(`{"id":"`+s.newID().String()+`","guild_id":"`+guildID.String()+`"}`))
+	if err != nil {
+		return invalidFormBody()
+	}
+	channel, err := decodeChannel(data)
+	if err != nil {
+		return invalidFormBody()
+	}
+	s.state.channels[channel.ID()] = channel
+	return ok(channel)
+}
+
+func (s *Server) getChannel(r *request) response {
+	channel, found := s.state.channels[r.id("channel.id")]
+	if !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+	return ok(channel)
+}
+
+func (s *Server) updateChannel(r *request) response {
+	channel, found := s.state.channels[r.id("channel.id")]
+	if !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+	channel, err := patch(channel, r.body, decodeChannel)
+	if err != nil {
+		return invalidFormBody()
+	}
+	s.state.channels[channel.ID()] = channel
+	return ok(channel)
+}
+
+func (s *Server) deleteChannel(r *request) response {
+	channelID := r.id("channel.id")
+	channel, found := s.state.channels[channelID]
+	if !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+	delete(s.state.channels, channelID)
+	delete(s.state.messages, channelID)
+	return ok(channel)
+}
+
+// getMessages returns the messages newest first like Discord. It supports the limit, before, after & around query parameters.
+func (s *Server) getMessages(r *request) response {
+	channelID := r.id("channel.id")
+	if _, found := s.state.channels[channelID]; !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+
+	limit := 50
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		var err error
+		if limit, err = strconv.Atoi(rawLimit); err != nil || limit < 1 || limit > 100 {
+			return invalidFormBody()
+		}
+	}
+
+	messages := s.state.messages[channelID]
+	query := r.URL.Query()
+	switch {
+	case query.Has("before"):
+		before, _ := snowflake.Parse(query.Get("before"))
+		end, _ := slices.BinarySearchFunc(messages, before, compareMessageID)
+		messages = messages[max(0, end-limit):end]
+	case query.Has("after"):
+		after, _ := snowflake.Parse(query.Get("after"))
+		start, found := slices.BinarySearchFunc(messages, after, compareMessageID)
+		if found {
+			start++
+		}
+		messages = messages[start:min(len(messages), start+limit)]
+	case query.Has("around"):
+		around, _ := snowflake.Parse(query.Get("around"))
+		center, _ := slices.BinarySearchFunc(messages, around, compareMessageID)
+		start := max(0, center-limit/2)
+		messages = messages[start:min(len(messages), start+limit)]
+	default:
+		messages = messages[max(0, len(messages)-limit):]
+	}
+
+	newestFirst := slices.Clone(messages)
+	slices.Reverse(newestFirst)
+	return ok(newestFirst)
+}
+
+func (s *Server) getMessage(r *request) response {
+	channelID := r.id("channel.id")
+	index, found := s.state.message(channelID, r.id("message.id"))
+	if !found {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+	return ok(s.state.messages[channelID][index])
+}
+
+func (s *Server) createMessage(r *request) response {
+	channelID := r.id("channel.id")
+	if _, found := s.state.channels[channelID]; !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+	fields, attachments, err := s.payload(r)
+	if err != nil {
+		return invalidFormBody()
+	}
+	if isEmptyMessage(fields, attachments) {
+		return emptyMessage()
+	}
+	message, rs := s.newMessage(fields, attachments, channelID, s.config.User, 0)
+	if rs != nil {
+		return *rs
+	}
+	s.state.messages[channelID] = append(s.state.messages[channelID], message)
+	return ok(message)
+}
+
+func (s *Server) updateMessage(r *request) response {
+	return s.editMessage(r, r.id("channel.id"), r.id("message.id"), 0)
+}
+
+// editMessage updates the message in the channel. If webhookID is not 0, the message must be from this webhook.
+func (s *Server) editMessage(r *request, channelID snowflake.ID, messageID snowflake.ID, webhookID snowflake.ID) response {
+	index, found := s.state.message(channelID, messageID)
+	if !found {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+	message := s.state.messages[channelID][index]
+	if webhookID != 0 && (message.WebhookID == nil || *message.WebhookID != webhookID) {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+
+	fields, attachments, err := s.payload(r)
+	if err != nil {
+		return invalidFormBody()
+	}
+	message, rs := s.updateMessageFields(fields, attachments, message)
+	if rs != nil {
+		return *rs
+	}
+	s.state.messages[channelID][index] = message
+	return ok(message)
+}
+
+func (s *Server) deleteMessage(r *request) response {
+	return s.removeMessage(r.id("channel.id"), r.id("message.id"), 0)
+}
+
+// removeMessage deletes the message in the channel. If webhookID is not 0, the message must be from this webhook.
+func (s *Server) removeMessage(channelID snowflake.ID, messageID snowflake.ID, webhookID snowflake.ID) response {
+	index, found := s.state.message(channelID, messageID)
+	if !found {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+	message := s.state.messages[channelID][index]
+	if webhookID != 0 && (message.WebhookID == nil || *message.WebhookID != webhookID) {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+	s.state.messages[channelID] = slices.Delete(s.state.messages[channelID], index, index+1)
+	return noContent()
+}
+
+func (s *Server) bulkDeleteMessages(r *request) response {
+	channelID := r.id("channel.id")
+	if _, found := s.state.channels[channelID]; !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+	bulkDelete, err := decodeJSON[discord.MessageBulkDelete](r.body)
+	if err != nil || len(bulkDelete.Messages) < 2 || len(bulkDelete.Messages) > 100 {
+		return invalidFormBody()
+	}
+	s.state.messages[channelID] = slices.DeleteFunc(s.state.messages[channelID], func(message discord.Message) bool {
+		return slices.Contains(bulkDelete.Messages, message.ID)
+	})
+	return noContent()
+}
+
+func compareMessageID(message discord.Message, id snowflake.ID) int {
+	return compareIDs(message.ID, id)
+}
+
+func compareIDs(a snowflake.ID, b snowflake.ID) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,293 @@
+package resttest
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func (s *Server) guild(r *request) (*guildState, *response) {
+	g, found := s.state.guilds[r.id("guild.id")]
+	if !found {
+		rs := notFound(codeUnknownGuild, "Unknown Guild")
+		return nil, &rs
+	}
+	return g, nil
+}
+
+func (s *Server) getGuild(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	return ok(discord.RestGuild{
+		Guild:    g.guild,
+		Stickers: []discord.Sticker{},
+		Roles:    s.roles(g.guild.ID),
+		Emojis:   []discord.Emoji{},
+	})
+}
+
+func (s *Server) getMember(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	member, found := g.members[r.id("user.id")]
+	if !found {
+		return notFound(codeUnknownMember, "Unknown Member")
+	}
+	return ok(member)
+}
+
+// getMembers returns the members sorted by their user id. It supports the limit & after query parameters.
+func (s *Server) getMembers(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+
+	query := r.URL.Query()
+	limit := 1
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		var err error
+		if limit, err = strconv.Atoi(rawLimit); err != nil || limit < 1 || limit > 1000 {
+			return invalidFormBody()
+		}
+	}
+	after, _ := snowflake.Parse(query.Get("after"))
+
+	members := []discord.Member{}
+	for userID, member := range g.members {
+		if userID > after {
+			members = append(members, member)
+		}
+	}
+	slices.SortFunc(members, func(a, b discord.Member) int {
+		return compareIDs(a.User.ID, b.User.ID)
+	})
+	return ok(members[:min(len(members), limit)])
+}
+
+func (s *Server) updateMember(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	member, found := g.members[r.id("user.id")]
+	if !found {
+		return notFound(codeUnknownMember, "Unknown Member")
+	}
+	member, err := patch(member, r.body, decodeJSON[discord.Member])
+	if err != nil {
+		return invalidFormBody()
+	}
+	g.members[member.User.ID] = member
+	return ok(member)
+}
+
+func (s *Server) removeMember(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	userID := r.id("user.id")
+	if _, found := g.members[userID]; !found {
+		return notFound(codeUnknownMember, "Unknown Member")
+	}
+	delete(g.members, userID)
+	return noContent()
+}
+
+func (s *Server) addMemberRole(r *request) response {
+	return s.updateMemberRoles(r, func(roleIDs []snowflake.ID, roleID snowflake.ID) []snowflake.ID {
+		if slices.Contains(roleIDs, roleID) {
+			return roleIDs
+		}
+		return append(roleIDs, roleID)
+	})
+}
+
+func (s *Server) removeMemberRole(r *request) response {
+	return s.updateMemberRoles(r, func(roleIDs []snowflake.ID, roleID snowflake.ID) []snowflake.ID {
+		return slices.DeleteFunc(roleIDs, func(id snowflake.ID) bool {
+			return id == roleID
+		})
+	})
+}
+
+func (s *Server) updateMemberRoles(r *request, update func(roleIDs []snowflake.ID, roleID snowflake.ID) []snowflake.ID) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	member, found := g.members[r.id("user.id")]
+	if !found {
+		return notFound(codeUnknownMember, "Unknown Member")
+	}
+	roleID := r.id("role.id")
+	if _, found = g.roles[roleID]; !found {
+		return notFound(codeUnknownRole, "Unknown Role")
+	}
+	member.RoleIDs = update(slices.Clone(member.RoleIDs), roleID)
+	g.members[member.User.ID] = member
+	return noContent()
+}
+
+// getBans returns the bans sorted by their user id.
+func (s *Server) getBans(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	bans := make([]discord.Ban, 0, len(g.bans))
+	for _, ban := range g.bans {
+		bans = append(bans, ban)
+	}
+	slices.SortFunc(bans, func(a, b discord.Ban) int {
+		return compareIDs(a.User.ID, b.User.ID)
+	})
+	return ok(bans)
+}
+
+func (s *Server) getBan(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	ban, found := g.bans[r.id("user.id")]
+	if !found {
+		return notFound(codeUnknownBan, "Unknown Ban")
+	}
+	return ok(ban)
+}
+
+// addBan bans the user and removes its member. The reason is taken from the X-Audit-Log-Reason header.
+func (s *Server) addBan(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	userID := r.id("user.id")
+	user, found := s.state.users[userID]
+	if !found {
+		return notFound(codeUnknownUser, "Unknown User")
+	}
+
+	ban := discord.Ban{User: user}
+	if reason, err := url.QueryUnescape(r.Header.Get("X-Audit-Log-Reason")); err == nil && reason != "" {
+		ban.Reason = &reason
+	}
+	g.bans[userID] = ban
+	delete(g.members, userID)
+	return noContent()
+}
+
+func (s *Server) deleteBan(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	userID := r.id("user.id")
+	if _, found := g.bans[userID]; !found {
+		return notFound(codeUnknownBan, "Unknown Ban")
+	}
+	delete(g.bans, userID)
+	return noContent()
+}
+
+func (s *Server) getRoles(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	return ok(s.roles(g.guild.ID))
+}
+
+func (s *Server) getRole(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	role, found := g.roles[r.id("role.id")]
+	if !found {
+		return notFound(codeUnknownRole, "Unknown Role")
+	}
+	return ok(role)
+}
+
+// createRole creates the role at position 1 like Discord, right above @everyone.
+func (s *Server) createRole(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	roleCreate, err := decodeJSON[discord.RoleCreate](r.body)
+	if err != nil && len(r.body) > 0 {
+		return invalidFormBody()
+	}
+
+	role := discord.Role{
+		ID:          s.newID(),
+		GuildID:     g.guild.ID,
+		Name:        roleCreate.Name,
+		Color:       roleCreate.Color,
+		Hoist:       roleCreate.Hoist,
+		Position:    1,
+		Mentionable: roleCreate.Mentionable,
+	}
+	if role.Name == "" {
+		role.Name = "new role"
+	}
+	if roleCreate.Permissions != nil {
+		role.Permissions = *roleCreate.Permissions
+	}
+	if roleCreate.Emoji != "" {
+		role.Emoji = &roleCreate.Emoji
+	}
+	g.roles[role.ID] = role
+	return ok(role)
+}
+
+func (s *Server) updateRole(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	role, found := g.roles[r.id("role.id")]
+	if !found {
+		return notFound(codeUnknownRole, "Unknown Role")
+	}
+	role, err := patch(role, r.body, decodeJSON[discord.Role])
+	if err != nil {
+		return invalidFormBody()
+	}
+	g.roles[role.ID] = role
+	return ok(role)
+}
+
+// deleteRole deletes the role and removes it from all members.
+func (s *Server) deleteRole(r *request) response {
+	g, rs := s.guild(r)
+	if rs != nil {
+		return *rs
+	}
+	roleID := r.id("role.id")
+	if _, found := g.roles[roleID]; !found {
+		return notFound(codeUnknownRole, "Unknown Role")
+	}
+	delete(g.roles, roleID)
+	for userID, member := range g.members {
+		if slices.Contains(member.RoleIDs, roleID) {
+			member.RoleIDs = slices.DeleteFunc(slices.Clone(member.RoleIDs), func(id snowflake.ID) bool {
+				return id == roleID
+			})
+			g.members[userID] = member
+		}
+	}
+	return noContent()
+}
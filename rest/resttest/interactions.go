@@ -0,0 +1,111 @@
+package resttest
+
+import (
+	"net/http"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// createInteractionResponse records the callback of an interaction.
+// discord.InteractionResponseTypeCreateMessage creates the original message and discord.InteractionResponseTypeDeferredCreateMessage
+// creates a loading original message, which is replaced by the first update. All other types are only recorded.
+func (s *Server) createInteractionResponse(r *request) response {
+	i, found := s.state.interactions[r.params["interaction.token"]]
+	if !found || i.ID != r.id("interaction.id") {
+		return notFound(codeUnknownInteraction, "Unknown interaction")
+	}
+	if i.response != nil {
+		return badRequest(codeAlreadyAcknowledged, "Interaction has already been acknowledged.")
+	}
+
+	fields, attachments, err := s.payload(r)
+	if err != nil {
+		return invalidFormBody()
+	}
+	responseType, _ := fields["type"].(float64)
+	data, _ := fields["data"].(map[string]any)
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, 0, err.Error())
+	}
+
+	interactionResponse := &InteractionResponse{
+		Type: discord.InteractionResponseType(responseType),
+		Data: rawData,
+	}
+	switch interactionResponse.Type {
+	case discord.InteractionResponseTypeCreateMessage:
+		if data == nil || isEmptyMessage(data, attachments) {
+			return emptyMessage()
+		}
+		message, rs := s.newMessage(data, attachments, i.ChannelID, s.config.User, i.ApplicationID)
+		if rs != nil {
+			return *rs
+		}
+		s.state.messages[i.ChannelID] = append(s.state.messages[i.ChannelID], message)
+		interactionResponse.OriginalMessageID = message.ID
+
+	case discord.InteractionResponseTypeDeferredCreateMessage:
+		flags := discord.MessageFlagLoading
+		if rawFlags, ok := data["flags"].(float64); ok {
+			flags = flags.Add(discord.MessageFlags(rawFlags))
+		}
+		message, rs := s.newMessage(map[string]any{"flags": flags}, nil, i.ChannelID, s.config.User, i.ApplicationID)
+		if rs != nil {
+			return *rs
+		}
+		s.state.messages[i.ChannelID] = append(s.state.messages[i.ChannelID], message)
+		interactionResponse.OriginalMessageID = message.ID
+	}
+
+	i.response = interactionResponse
+	return noContent()
+}
+
+// originalResponse returns the interaction of the request if it has an original message.
+func (s *Server) originalResponse(r *request) (*interaction, *response) {
+	i, found := s.state.interactions[r.params["interaction.token"]]
+	if !found || i.ApplicationID != r.id("application.id") {
+		rs := notFound(codeUnknownWebhook, "Unknown Webhook")
+		return nil, &rs
+	}
+	if i.response == nil || i.response.OriginalMessageID == 0 {
+		rs := notFound(codeUnknownMessage, "Unknown Message")
+		return nil, &rs
+	}
+	return i, nil
+}
+
+func (s *Server) getInteractionResponse(r *request) response {
+	i, rs := s.originalResponse(r)
+	if rs != nil {
+		return *rs
+	}
+	index, found := s.state.message(i.ChannelID, i.response.OriginalMessageID)
+	if !found {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+	return ok(s.state.messages[i.ChannelID][index])
+}
+
+func (s *Server) updateInteractionResponse(r *request) response {
+	i, rs := s.originalResponse(r)
+	if rs != nil {
+		return *rs
+	}
+	return s.editMessage(r, i.ChannelID, i.response.OriginalMessageID, i.ApplicationID)
+}
+
+func (s *Server) deleteInteractionResponse(r *request) response {
+	i, rs := s.originalResponse(r)
+	if rs != nil {
+		return *rs
+	}
+	deleted := s.removeMessage(i.ChannelID, i.response.OriginalMessageID, i.ApplicationID)
+	if deleted.status == http.StatusNoContent {
+		i.response.OriginalMessageID = 0
+	}
+	return deleted
+}
@@ -0,0 +1,177 @@
+package resttest
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// messageFields are the fields of a message create or update payload which are copied to the discord.Message.
+var messageFields = []string{"content", "tts", "embeds", "components", "flags", "poll"}
+
+// payload decodes the json body or the payload_json of a multipart body into fields.
+// The files of multipart bodies are returned as discord.Attachment(s).
+func (s *Server) payload(r *request) (map[string]any, []discord.Attachment, error) {
+	fields := map[string]any{}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if len(r.body) == 0 {
+			return fields, nil, nil
+		}
+		if err := json.Unmarshal(r.body, &fields); err != nil {
+			return nil, nil, err
+		}
+		return fields, nil, nil
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, nil, err
+	}
+	if payloadJSON := r.FormValue("payload_json"); payloadJSON != "" {
+		if err := json.Unmarshal([]byte(payloadJSON), &fields); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var attachments []discord.Attachment
+	for name, files := range r.MultipartForm.File {
+		if !strings.HasPrefix(name, "files[") {
+			continue
+		}
+		for _, file := range files {
+			id := s.newID()
+			attachment := discord.Attachment{
+				ID:       id,
+				Filename: file.Filename,
+				Size:     int(file.Size),
+				URL:      fmt.Sprintf("%s/attachments/%d/%s", s.server.URL, id, file.Filename),
+			}
+			if contentType := file.Header.Get("Content-Type"); contentType != "" {
+				attachment.ContentType = &contentType
+			}
+			attachments = append(attachments, attachment)
+		}
+	}
+	return fields, attachments, nil
+}
+
+// newMessage creates a discord.Message from the fields & attachments of a message create payload.
+func (s *Server) newMessage(fields map[string]any, attachments []discord.Attachment, channelID snowflake.ID, author discord.User, webhookID snowflake.ID) (discord.Message, *response) {
+	message := map[string]any{
+		"id":          s.newID(),
+		"channel_id":  channelID,
+		"author":      author,
+		"timestamp":   time.Now().UTC(),
+		"type":        discord.MessageTypeDefault,
+		"attachments": attachments,
+	}
+	if guildID := s.channelGuildID(channelID); guildID != 0 {
+		message["guild_id"] = guildID
+	}
+	if webhookID != 0 {
+		message["webhook_id"] = webhookID
+	}
+	for _, field := range messageFields {
+		if value, ok := fields[field]; ok {
+			message[field] = value
+		}
+	}
+	return s.decodeMessage(message)
+}
+
+// updateMessageFields applies the fields & attachments of a message update payload to the discord.Message.
+func (s *Server) updateMessageFields(fields map[string]any, attachments []discord.Attachment, message discord.Message) (discord.Message, *response) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		rs := errorResponse(http.StatusInternalServerError, 0, err.Error())
+		return discord.Message{}, &rs
+	}
+	updated := map[string]any{}
+	if err = json.Unmarshal(data, &updated); err != nil {
+		rs := errorResponse(http.StatusInternalServerError, 0, err.Error())
+		return discord.Message{}, &rs
+	}
+	for _, field := range messageFields {
+		if value, ok := fields[field]; ok {
+			updated[field] = value
+		}
+	}
+	if len(attachments) > 0 {
+		updated["attachments"] = append(message.Attachments, attachments...)
+	}
+	updated["edited_timestamp"] = time.Now().UTC()
+
+	updatedMessage, rs := s.decodeMessage(updated)
+	if rs != nil {
+		return discord.Message{}, rs
+	}
+	// editing a deferred response removes its loading state
+	updatedMessage.Flags = updatedMessage.Flags.Remove(discord.MessageFlagLoading)
+	return updatedMessage, nil
+}
+
+func (s *Server) decodeMessage(fields map[string]any) (discord.Message, *response) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		rs := errorResponse(http.StatusInternalServerError, 0, err.Error())
+		return discord.Message{}, &rs
+	}
+	var message discord.Message
+	if err = json.Unmarshal(data, &message); err != nil {
+		rs := invalidFormBody()
+		return discord.Message{}, &rs
+	}
+	return message, nil
+}
+
+// patch applies the json fields of the body to v like a PATCH request does.
+func patch[T any](v T, body []byte, decode func(data []byte) (T, error)) (T, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v, err
+	}
+	if len(body) > 0 {
+		if data, err = json.Merge(data, body); err != nil {
+			return v, err
+		}
+	}
+	return decode(data)
+}
+
+func decodeJSON[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func decodeChannel(data []byte) (discord.Channel, error) {
+	var v discord.UnmarshalChannel
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v.Channel, nil
+}
+
+// isEmptyMessage reports whether the message create payload has nothing to show.
+func isEmptyMessage(fields map[string]any, attachments []discord.Attachment) bool {
+	return isEmpty(fields["content"]) && isEmpty(fields["embeds"]) && isEmpty(fields["components"]) && isEmpty(fields["poll"]) && len(attachments) == 0
+}
+
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
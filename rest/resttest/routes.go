@@ -0,0 +1,126 @@
+package resttest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+type route struct {
+	endpoint *rest.Endpoint
+	segments []string
+	handle   func(s *Server, r *request) response
+}
+
+func newRoute(endpoint *rest.Endpoint, handle func(s *Server, r *request) response) *route {
+	return &route{
+		endpoint: endpoint,
+		segments: strings.Split(strings.Trim(endpoint.Route, "/"), "/"),
+		handle:   handle,
+	}
+}
+
+// getWebhookMessage is missing in rest as the rest.Webhooks do not support fetching messages.
+var getWebhookMessage = rest.NewNoBotAuthEndpoint(http.MethodGet, "/webhooks/{webhook.id}/{webhook.token}/messages/{message.id}")
+
+// routes are all routes the Server implements.
+// Follow-up messages share their routes with webhook messages, so the webhook handlers also handle interactions.
+var routes = []*route{
+	newRoute(rest.GetCurrentUser, (*Server).getCurrentUser),
+	newRoute(rest.GetUser, (*Server).getUser),
+
+	newRoute(rest.GetGuild, (*Server).getGuild),
+	newRoute(rest.GetGuildChannels, (*Server).getGuildChannels),
+	newRoute(rest.CreateGuildChannel, (*Server).createGuildChannel),
+
+	newRoute(rest.GetChannel, (*Server).getChannel),
+	newRoute(rest.UpdateChannel, (*Server).updateChannel),
+	newRoute(rest.DeleteChannel, (*Server).deleteChannel),
+
+	newRoute(rest.GetMessages, (*Server).getMessages),
+	newRoute(rest.GetMessage, (*Server).getMessage),
+	newRoute(rest.CreateMessage, (*Server).createMessage),
+	newRoute(rest.UpdateMessage, (*Server).updateMessage),
+	newRoute(rest.DeleteMessage, (*Server).deleteMessage),
+	newRoute(rest.BulkDeleteMessages, (*Server).bulkDeleteMessages),
+
+	newRoute(rest.GetMember, (*Server).getMember),
+	newRoute(rest.GetMembers, (*Server).getMembers),
+	newRoute(rest.UpdateMember, (*Server).updateMember),
+	newRoute(rest.RemoveMember, (*Server).removeMember),
+	newRoute(rest.AddMemberRole, (*Server).addMemberRole),
+	newRoute(rest.RemoveMemberRole, (*Server).removeMemberRole),
+
+	newRoute(rest.GetBans, (*Server).getBans),
+	newRoute(rest.GetBan, (*Server).getBan),
+	newRoute(rest.AddBan, (*Server).addBan),
+	newRoute(rest.DeleteBan, (*Server).deleteBan),
+
+	newRoute(rest.GetRoles, (*Server).getRoles),
+	newRoute(rest.GetRole, (*Server).getRole),
+	newRoute(rest.CreateRole, (*Server).createRole),
+	newRoute(rest.UpdateRole, (*Server).updateRole),
+	newRoute(rest.DeleteRole, (*Server).deleteRole),
+
+	newRoute(rest.CreateInteractionResponse, (*Server).createInteractionResponse),
+	newRoute(rest.GetInteractionResponse, (*Server).getInteractionResponse),
+	newRoute(rest.UpdateInteractionResponse, (*Server).updateInteractionResponse),
+	newRoute(rest.DeleteInteractionResponse, (*Server).deleteInteractionResponse),
+
+	newRoute(rest.GetChannelWebhooks, (*Server).getChannelWebhooks),
+	newRoute(rest.CreateWebhook, (*Server).createWebhook),
+	newRoute(rest.GetWebhook, (*Server).getWebhook),
+	newRoute(rest.DeleteWebhook, (*Server).deleteWebhook),
+	newRoute(rest.GetWebhookWithToken, (*Server).getWebhookWithToken),
+	newRoute(rest.CreateWebhookMessage, (*Server).createWebhookMessage),
+	newRoute(getWebhookMessage, (*Server).getWebhookMessage),
+	newRoute(rest.UpdateWebhookMessage, (*Server).updateWebhookMessage),
+	newRoute(rest.DeleteWebhookMessage, (*Server).deleteWebhookMessage),
+}
+
+// matchRoute returns the route of the path and its url parameters.
+// If multiple routes match, the one with the most literal segments wins, for example /members/search over /members/{user.id}.
+func matchRoute(method string, path string) (*route, map[string]string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	var (
+		best       *route
+		bestParams map[string]string
+		bestScore  = -1
+	)
+	for _, rt := range routes {
+		if rt.endpoint.Method != method || len(rt.segments) != len(parts) {
+			continue
+		}
+		params := map[string]string{}
+		score := 0
+		for i, segment := range rt.segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				params[segment[1:len(segment)-1]] = parts[i]
+				continue
+			}
+			if segment != parts[i] {
+				score = -1
+				break
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestParams, bestScore = rt, params, score
+		}
+	}
+	return best, bestParams
+}
+
+// routeKey returns the method and route of the rest.Endpoint without parameter names,
+// so endpoints which share a route like follow-up and webhook messages have the same key.
+func routeKey(endpoint *rest.Endpoint) string {
+	segments := strings.Split(endpoint.Route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") {
+			segments[i] = "{}"
+		}
+	}
+	return endpoint.Method + " " + strings.Join(segments, "/")
+}
@@ -0,0 +1,350 @@
+// Package resttest provides an in-process fake of the Discord REST API with in-memory state for integration tests.
+//
+// Point a rest.Client at the Server with rest.WithURL(server.URL), seed the state with Server.AddGuild, Server.AddChannel, ...
+// and assert on the resulting state or the recorded Server.Requests.
+package resttest
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// Request is a request the Server received.
+type Request struct {
+	Method string
+	// Path is the path without the api prefix, for example /channels/123/messages
+	Path  string
+	Query url.Values
+	// Endpoint is the matched rest.Endpoint or nil if the route is unknown.
+	Endpoint *rest.Endpoint
+	Body     []byte
+	Status   int
+	Time     time.Time
+}
+
+// Fault is an error the Server returns instead of handling the request.
+type Fault struct {
+	// Endpoint is the rest.Endpoint the Fault applies to. Nil applies to all endpoints.
+	Endpoint *rest.Endpoint
+	// Times is how many requests fail. 0 fails all requests until ClearFaults is called.
+	Times int
+	// Status is the http status code, for example http.StatusInternalServerError.
+	Status  int
+	Code    rest.JSONErrorCode
+	Message string
+	// RetryAfter is the Retry-After for http.StatusTooManyRequests.
+	RetryAfter time.Duration
+	// Global marks a http.StatusTooManyRequests as global rate limit.
+	Global bool
+}
+
+// NewServer starts a new Server listening on a local port. Close it after the test.
+func NewServer(opts ...ConfigOpt) *Server {
+	config := DefaultConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "resttest"))
+
+	s := &Server{
+		config:  *config,
+		buckets: map[string]*bucket{},
+		state:   newState(),
+	}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL + apiPrefix
+	return s
+}
+
+const apiPrefix = "/api/v10"
+
+// Server is a fake Discord REST API. It implements the main channel, message, member, role, interaction & webhook routes.
+// Requests to other routes return http.StatusNotFound.
+//
+// Every response has rate limit headers like Discord's. A bucket is shared by all requests to the same route with the same major parameters.
+type Server struct {
+	// URL is the api url of the Server, for example http://127.0.0.1:1234/api/v10
+	URL string
+
+	config Config
+	server *httptest.Server
+
+	mu       sync.Mutex
+	lastID   snowflake.ID
+	requests []Request
+	faults   []*Fault
+	buckets  map[string]*bucket
+	state    *state
+}
+
+type bucket struct {
+	remaining int
+	reset     time.Time
+}
+
+// Close stops the Server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// InjectFault makes the Server return the Fault for matching requests. Faults are checked in the order they were added.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all Fault(s).
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// NewID returns a new unique snowflake.ID for the current time.
+func (s *Server) NewID() snowflake.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newID()
+}
+
+func (s *Server) newID() snowflake.ID {
+	id := snowflake.New(time.Now())
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return id
+}
+
+// request is a request matched to a route.
+type request struct {
+	*http.Request
+	endpoint *rest.Endpoint
+	params   map[string]string
+	body     []byte
+}
+
+// id returns the url parameter as snowflake.ID. Invalid ids are returned as 0 which never exists.
+func (r *request) id(param string) snowflake.ID {
+	id, _ := snowflake.Parse(r.params[param])
+	return id
+}
+
+// response is the status and json body a route returns. A nil body is sent as http.StatusNoContent.
+type response struct {
+	status int
+	body   any
+}
+
+type apiError struct {
+	Code    rest.JSONErrorCode `json:"code"`
+	Message string             `json:"message"`
+}
+
+func ok(body any) response {
+	return response{status: http.StatusOK, body: body}
+}
+
+func noContent() response {
+	return response{status: http.StatusNoContent}
+}
+
+func errorResponse(status int, code rest.JSONErrorCode, message string) response {
+	return response{status: status, body: apiError{Code: code, Message: message}}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, found := strings.CutPrefix(r.URL.Path, apiPrefix)
+	rq := Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.Query(),
+		Time:   time.Now(),
+	}
+	rq.Body, _ = io.ReadAll(r.Body)
+	// the Via header tells clients rate limits are from Discord and not from cloudflare
+	w.Header().Set("Via", "1.1 google")
+
+	rs := s.serve(w, r, path, found, &rq)
+	rq.Status = rs.status
+
+	s.mu.Lock()
+	s.requests = append(s.requests, rq)
+	s.mu.Unlock()
+
+	if rs.body == nil {
+		if rs.status == http.StatusOK {
+			rs.status = http.StatusNoContent
+		}
+		w.WriteHeader(rs.status)
+		return
+	}
+	data, err := json.Marshal(rs.body)
+	if err != nil {
+		s.config.Logger.Error("failed to marshal response", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(rs.status)
+	_, _ = w.Write(data)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, path string, found bool, rq *Request) response {
+	var (
+		rt     *route
+		params map[string]string
+	)
+	if found {
+		rt, params = matchRoute(r.Method, path)
+	}
+	if rt == nil {
+		s.config.Logger.Debug("unknown route", slog.String("method", r.Method), slog.String("path", r.URL.Path))
+		return errorResponse(http.StatusNotFound, 0, "404: Not Found")
+	}
+	rq.Endpoint = rt.endpoint
+
+	if s.config.Token != "" && rt.endpoint.BotAuth && r.Header.Get("Authorization") != discord.TokenTypeBot.Apply(s.config.Token) {
+		return errorResponse(http.StatusUnauthorized, 0, "401: Unauthorized")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault := s.takeFault(rt.endpoint); fault != nil {
+		return s.faultResponse(w, fault)
+	}
+	if rs, limited := s.rateLimit(w, rt.endpoint, params); limited {
+		return rs
+	}
+
+	// the body was already read for the Request, so multipart bodies need to be readable again
+	r.Body = io.NopCloser(bytes.NewReader(rq.Body))
+	return rt.handle(s, &request{
+		Request:  r,
+		endpoint: rt.endpoint,
+		params:   params,
+		body:     rq.Body,
+	})
+}
+
+// takeFault returns the first Fault for the endpoint. s.mu must be held.
+func (s *Server) takeFault(endpoint *rest.Endpoint) *Fault {
+	for i, fault := range s.faults {
+		if fault.Endpoint != nil && routeKey(fault.Endpoint) != routeKey(endpoint) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) faultResponse(w http.ResponseWriter, fault *Fault) response {
+	if fault.Status != http.StatusTooManyRequests {
+		return errorResponse(fault.Status, fault.Code, fault.Message)
+	}
+	message := fault.Message
+	if message == "" {
+		message = "You are being rate limited."
+	}
+	return s.tooManyRequests(w, fault.RetryAfter, fault.Global, message)
+}
+
+// rateLimit takes a request from the bucket of the route and sets the rate limit headers. s.mu must be held.
+func (s *Server) rateLimit(w http.ResponseWriter, endpoint *rest.Endpoint, params map[string]string) (response, bool) {
+	if s.config.RateLimit <= 0 {
+		return response{}, false
+	}
+
+	bucketID := bucketHash(endpoint)
+	key := bucketID
+	for name, value := range params {
+		if strings.Contains(rest.MajorParameters, name) {
+			key += ":" + name + "=" + value
+		}
+	}
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok || !now.Before(b.reset) {
+		b = &bucket{
+			remaining: s.config.RateLimit,
+			reset:     now.Add(s.config.RateLimitWindow),
+		}
+		s.buckets[key] = b
+	}
+
+	resetAfter := b.reset.Sub(now)
+	header := w.Header()
+	header.Set("X-RateLimit-Bucket", bucketID)
+	header.Set("X-RateLimit-Limit", strconv.Itoa(s.config.RateLimit))
+	header.Set("X-RateLimit-Reset", strconv.FormatFloat(float64(b.reset.UnixMilli())/1000, 'f', 3, 64))
+	header.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter.Seconds(), 'f', 3, 64))
+
+	if b.remaining == 0 {
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Scope", "user")
+		return s.tooManyRequests(w, resetAfter, false, "You are being rate limited."), true
+	}
+	b.remaining--
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return response{}, false
+}
+
+func (s *Server) tooManyRequests(w http.ResponseWriter, retryAfter time.Duration, global bool, message string) response {
+	header := w.Header()
+	header.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	if global {
+		header.Set("X-RateLimit-Global", "true")
+		header.Set("X-RateLimit-Scope", "global")
+	}
+	return response{
+		status: http.StatusTooManyRequests,
+		body: struct {
+			Message    string  `json:"message"`
+			RetryAfter float64 `json:"retry_after"`
+			Global     bool    `json:"global"`
+			Code       int     `json:"code"`
+		}{
+			Message:    message,
+			RetryAfter: retryAfter.Seconds(),
+			Global:     global,
+		},
+	}
+}
+
+func bucketHash(endpoint *rest.Endpoint) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(routeKey(endpoint)))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
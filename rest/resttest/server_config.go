@@ -0,0 +1,75 @@
+package resttest
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger: slog.Default(),
+		User: discord.User{
+			ID:       1,
+			Username: "test",
+			Bot:      true,
+		},
+		RateLimit:       50,
+		RateLimitWindow: time.Second,
+	}
+}
+
+// Config lets you configure your Server instance.
+type Config struct {
+	// Logger is the Logger of the Server. Defaults to slog.Default().
+	Logger *slog.Logger
+	// Token is the bot token requests must authorize with. Defaults to accepting any token.
+	Token string
+	// User is the bot user. It is the author of all messages the bot creates and its ID is used as application id.
+	User discord.User
+	// RateLimit is how many requests each rate limit bucket allows per RateLimitWindow. 0 disables rate limits. Defaults to 50.
+	RateLimit int
+	// RateLimitWindow is the time after which a rate limit bucket resets. Defaults to 1 second.
+	RateLimitWindow time.Duration
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger for the Server.
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithToken sets the bot token requests must authorize with. Other tokens are rejected with http.StatusUnauthorized.
+func WithToken(token string) ConfigOpt {
+	return func(config *Config) {
+		config.Token = token
+	}
+}
+
+// WithUser sets the bot user of the Server.
+func WithUser(user discord.User) ConfigOpt {
+	return func(config *Config) {
+		config.User = user
+	}
+}
+
+// WithRateLimit sets how many requests each rate limit bucket allows per window. A limit of 0 disables rate limits.
+func WithRateLimit(limit int, window time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.RateLimit = limit
+		config.RateLimitWindow = window
+	}
+}
@@ -0,0 +1,140 @@
+package resttest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func newTestServer(t *testing.T, opts ...ConfigOpt) (*Server, rest.Rest, snowflake.ID) {
+	t.Helper()
+	s := NewServer(append([]ConfigOpt{WithToken("token")}, opts...)...)
+	t.Cleanup(s.Close)
+
+	guildID := s.NewID()
+	channelID := s.NewID()
+	s.AddGuild(discord.Guild{ID: guildID, Name: "guild"})
+
+	var channel discord.UnmarshalChannel
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"`+channelID.String()+`","guild_id":"`+guildID.String()+`","type":0,"name":"general"}`), &channel))
+	s.AddChannel(channel.Channel)
+
+	client := rest.New(rest.NewClient("token",
+		rest.WithURL(s.URL),
+		rest.WithRetryPolicy(rest.RetryPolicy{
+			MaxAttempts: 2,
+			StatusCodes: []int{http.StatusInternalServerError},
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    time.Millisecond,
+		}),
+	))
+	return s, client, channelID
+}
+
+func TestServerMessages(t *testing.T) {
+	s, client, channelID := newTestServer(t)
+
+	message, err := client.CreateMessage(channelID, discord.MessageCreate{Content: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", message.Content)
+	require.NotNil(t, message.GuildID)
+
+	message, err = client.UpdateMessage(channelID, message.ID, discord.NewMessageUpdateBuilder().SetContent("edited").Build())
+	require.NoError(t, err)
+	assert.Equal(t, "edited", message.Content)
+	assert.NotNil(t, message.EditedTimestamp)
+
+	stored, ok := s.Message(channelID, message.ID)
+	require.True(t, ok)
+	assert.Equal(t, "edited", stored.Content)
+
+	_, err = client.CreateMessage(channelID, discord.MessageCreate{})
+	var restErr rest.Error
+	require.ErrorAs(t, err, &restErr)
+	assert.Equal(t, codeEmptyMessage, restErr.Code)
+
+	require.NoError(t, client.DeleteMessage(channelID, message.ID))
+	assert.Empty(t, s.Messages(channelID))
+}
+
+func TestServerInteraction(t *testing.T) {
+	s, client, channelID := newTestServer(t)
+
+	interaction := Interaction{
+		ID:        s.NewID(),
+		Token:     "interaction-token",
+		ChannelID: channelID,
+	}
+	s.AddInteraction(interaction)
+	applicationID := s.config.User.ID
+
+	err := client.CreateInteractionResponse(interaction.ID, interaction.Token, discord.InteractionResponse{
+		Type: discord.InteractionResponseTypeDeferredCreateMessage,
+	})
+	require.NoError(t, err)
+
+	err = client.CreateInteractionResponse(interaction.ID, interaction.Token, discord.InteractionResponse{
+		Type: discord.InteractionResponseTypeDeferredCreateMessage,
+	})
+	var restErr rest.Error
+	require.ErrorAs(t, err, &restErr)
+	assert.Equal(t, codeAlreadyAcknowledged, restErr.Code)
+
+	original, err := client.UpdateInteractionResponse(applicationID, interaction.Token, discord.NewMessageUpdateBuilder().SetContent("done").Build())
+	require.NoError(t, err)
+	assert.Equal(t, "done", original.Content)
+	assert.False(t, original.Flags.Has(discord.MessageFlagLoading))
+
+	followup, err := client.CreateFollowupMessage(applicationID, interaction.Token, discord.MessageCreate{Content: "followup"})
+	require.NoError(t, err)
+	require.NotNil(t, followup.WebhookID)
+	assert.Equal(t, applicationID, *followup.WebhookID)
+
+	response, ok := s.InteractionResponse(interaction.Token)
+	require.True(t, ok)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredCreateMessage, response.Type)
+	assert.Equal(t, original.ID, response.OriginalMessageID)
+	assert.Len(t, s.Messages(channelID), 2)
+}
+
+func TestServerFaults(t *testing.T) {
+	s, client, channelID := newTestServer(t)
+
+	s.InjectFault(Fault{Endpoint: rest.GetChannel, Times: 1, Status: http.StatusInternalServerError})
+	_, err := client.GetChannel(channelID)
+	require.NoError(t, err)
+
+	s.InjectFault(Fault{Endpoint: rest.GetChannel, Status: http.StatusNotFound, Code: codeUnknownChannel, Message: "Unknown Channel"})
+	_, err = client.GetChannel(channelID)
+	var restErr rest.Error
+	require.ErrorAs(t, err, &restErr)
+	assert.Equal(t, codeUnknownChannel, restErr.Code)
+
+	var statuses []int
+	for _, rq := range s.Requests() {
+		statuses = append(statuses, rq.Status)
+	}
+	assert.Equal(t, []int{http.StatusInternalServerError, http.StatusOK, http.StatusNotFound}, statuses)
+}
+
+func TestServerRateLimit(t *testing.T) {
+	s, client, channelID := newTestServer(t, WithRateLimit(2, 300*time.Millisecond))
+
+	for i := 0; i < 5; i++ {
+		_, err := client.CreateMessage(channelID, discord.MessageCreate{Content: "spam"})
+		require.NoError(t, err)
+	}
+
+	for _, rq := range s.Requests() {
+		assert.NotEqual(t, http.StatusTooManyRequests, rq.Status, "the client should wait for the bucket to reset")
+	}
+	assert.Len(t, s.Messages(channelID), 5)
+}
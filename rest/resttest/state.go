@@ -0,0 +1,283 @@
+package resttest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const (
+	codeUnknownChannel      rest.JSONErrorCode = 10003
+	codeUnknownGuild        rest.JSONErrorCode = 10004
+	codeUnknownMember       rest.JSONErrorCode = 10007
+	codeUnknownMessage      rest.JSONErrorCode = 10008
+	codeUnknownRole         rest.JSONErrorCode = 10011
+	codeUnknownUser         rest.JSONErrorCode = 10013
+	codeUnknownWebhook      rest.JSONErrorCode = 10015
+	codeUnknownBan          rest.JSONErrorCode = 10026
+	codeUnknownInteraction  rest.JSONErrorCode = 10062
+	codeAlreadyAcknowledged rest.JSONErrorCode = 40060
+	codeEmptyMessage        rest.JSONErrorCode = 50006
+	codeInvalidWebhookToken rest.JSONErrorCode = 50027
+	codeInvalidFormBody     rest.JSONErrorCode = 50035
+)
+
+func notFound(code rest.JSONErrorCode, message string) response {
+	return errorResponse(http.StatusNotFound, code, message)
+}
+
+func badRequest(code rest.JSONErrorCode, message string) response {
+	return errorResponse(http.StatusBadRequest, code, message)
+}
+
+func emptyMessage() response {
+	return badRequest(codeEmptyMessage, "Cannot send an empty message")
+}
+
+func invalidFormBody() response {
+	return badRequest(codeInvalidFormBody, "Invalid Form Body")
+}
+
+// Interaction is an interaction the bot can respond to. Interactions come from the gateway, so they have to be added with Server.AddInteraction.
+type Interaction struct {
+	ID            snowflake.ID
+	ApplicationID snowflake.ID
+	Token         string
+	ChannelID     snowflake.ID
+	GuildID       snowflake.ID
+}
+
+// InteractionResponse is the response the bot sent to an Interaction.
+type InteractionResponse struct {
+	Type discord.InteractionResponseType
+	// Data is the raw discord.InteractionResponseData which depends on the Type.
+	Data json.RawMessage
+	// OriginalMessageID is the id of the message created by discord.InteractionResponseTypeCreateMessage or
+	// discord.InteractionResponseTypeDeferredCreateMessage.
+	OriginalMessageID snowflake.ID
+}
+
+type state struct {
+	users        map[snowflake.ID]discord.User
+	guilds       map[snowflake.ID]*guildState
+	channels     map[snowflake.ID]discord.Channel
+	messages     map[snowflake.ID][]discord.Message
+	webhooks     map[snowflake.ID]*webhook
+	interactions map[string]*interaction
+}
+
+type guildState struct {
+	guild   discord.Guild
+	roles   map[snowflake.ID]discord.Role
+	members map[snowflake.ID]discord.Member
+	bans    map[snowflake.ID]discord.Ban
+}
+
+type webhook struct {
+	ID        snowflake.ID  `json:"id"`
+	Type      int           `json:"type"`
+	Name      string        `json:"name"`
+	Avatar    *string       `json:"avatar"`
+	ChannelID snowflake.ID  `json:"channel_id"`
+	GuildID   snowflake.ID  `json:"guild_id"`
+	Token     string        `json:"token"`
+	User      *discord.User `json:"user,omitempty"`
+}
+
+type interaction struct {
+	Interaction
+	response *InteractionResponse
+}
+
+func newState() *state {
+	return &state{
+		users:        map[snowflake.ID]discord.User{},
+		guilds:       map[snowflake.ID]*guildState{},
+		channels:     map[snowflake.ID]discord.Channel{},
+		messages:     map[snowflake.ID][]discord.Message{},
+		webhooks:     map[snowflake.ID]*webhook{},
+		interactions: map[string]*interaction{},
+	}
+}
+
+func (s *state) message(channelID snowflake.ID, messageID snowflake.ID) (int, bool) {
+	index := slices.IndexFunc(s.messages[channelID], func(message discord.Message) bool {
+		return message.ID == messageID
+	})
+	return index, index != -1
+}
+
+// AddUser adds the discord.User, so it can be fetched.
+func (s *Server) AddUser(user discord.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.users[user.ID] = user
+}
+
+// AddGuild adds or replaces the discord.Guild. Its roles, members and bans are kept.
+func (s *Server) AddGuild(guild discord.Guild) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g, ok := s.state.guilds[guild.ID]; ok {
+		g.guild = guild
+		return
+	}
+	s.state.guilds[guild.ID] = &guildState{
+		guild:   guild,
+		roles:   map[snowflake.ID]discord.Role{},
+		members: map[snowflake.ID]discord.Member{},
+		bans:    map[snowflake.ID]discord.Ban{},
+	}
+}
+
+// AddRole adds or replaces the discord.Role in the guild of its GuildID. The guild must be added first.
+func (s *Server) AddRole(role discord.Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g, ok := s.state.guilds[role.GuildID]; ok {
+		g.roles[role.ID] = role
+	}
+}
+
+// AddMember adds or replaces the discord.Member in the guild of its GuildID. The guild must be added first.
+func (s *Server) AddMember(member discord.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g, ok := s.state.guilds[member.GuildID]; ok {
+		g.members[member.User.ID] = member
+		s.state.users[member.User.ID] = member.User
+	}
+}
+
+// AddChannel adds or replaces the discord.Channel.
+func (s *Server) AddChannel(channel discord.Channel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.channels[channel.ID()] = channel
+}
+
+// AddMessage adds the discord.Message to the channel of its ChannelID. Messages must be added in the order of their ids.
+func (s *Server) AddMessage(message discord.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.messages[message.ChannelID] = append(s.state.messages[message.ChannelID], message)
+}
+
+// AddInteraction adds an Interaction the bot can respond to.
+func (s *Server) AddInteraction(i Interaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i.ApplicationID == 0 {
+		i.ApplicationID = s.config.User.ID
+	}
+	s.state.interactions[i.Token] = &interaction{Interaction: i}
+}
+
+// Guild returns the discord.Guild.
+func (s *Server) Guild(guildID snowflake.ID) (discord.Guild, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.state.guilds[guildID]
+	if !ok {
+		return discord.Guild{}, false
+	}
+	return g.guild, true
+}
+
+// Role returns the discord.Role of the guild.
+func (s *Server) Role(guildID snowflake.ID, roleID snowflake.ID) (discord.Role, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.state.guilds[guildID]
+	if !ok {
+		return discord.Role{}, false
+	}
+	role, ok := g.roles[roleID]
+	return role, ok
+}
+
+// Roles returns all discord.Role(s) of the guild sorted by their position.
+func (s *Server) Roles(guildID snowflake.ID) []discord.Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.roles(guildID)
+}
+
+func (s *Server) roles(guildID snowflake.ID) []discord.Role {
+	g, ok := s.state.guilds[guildID]
+	if !ok {
+		return nil
+	}
+	roles := make([]discord.Role, 0, len(g.roles))
+	for _, role := range g.roles {
+		roles = append(roles, role)
+	}
+	slices.SortFunc(roles, discord.CompareRoles)
+	return roles
+}
+
+// Member returns the discord.Member of the guild.
+func (s *Server) Member(guildID snowflake.ID, userID snowflake.ID) (discord.Member, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.state.guilds[guildID]
+	if !ok {
+		return discord.Member{}, false
+	}
+	member, ok := g.members[userID]
+	return member, ok
+}
+
+// Ban returns the discord.Ban of the user in the guild.
+func (s *Server) Ban(guildID snowflake.ID, userID snowflake.ID) (discord.Ban, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.state.guilds[guildID]
+	if !ok {
+		return discord.Ban{}, false
+	}
+	ban, ok := g.bans[userID]
+	return ban, ok
+}
+
+// Channel returns the discord.Channel.
+func (s *Server) Channel(channelID snowflake.ID) (discord.Channel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, ok := s.state.channels[channelID]
+	return channel, ok
+}
+
+// Message returns the discord.Message of the channel.
+func (s *Server) Message(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index, ok := s.state.message(channelID, messageID)
+	if !ok {
+		return discord.Message{}, false
+	}
+	return s.state.messages[channelID][index], true
+}
+
+// Messages returns all discord.Message(s) of the channel, oldest first.
+func (s *Server) Messages(channelID snowflake.ID) []discord.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.state.messages[channelID])
+}
+
+// InteractionResponse returns the InteractionResponse to the Interaction with the token.
+func (s *Server) InteractionResponse(interactionToken string) (InteractionResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.state.interactions[interactionToken]
+	if !ok || i.response == nil {
+		return InteractionResponse{}, false
+	}
+	return *i.response, true
+}
@@ -0,0 +1,17 @@
+package resttest
+
+func (s *Server) getCurrentUser(_ *request) response {
+	return ok(s.config.User)
+}
+
+func (s *Server) getUser(r *request) response {
+	userID := r.id("user.id")
+	if userID == s.config.User.ID {
+		return ok(s.config.User)
+	}
+	user, found := s.state.users[userID]
+	if !found {
+		return notFound(codeUnknownUser, "Unknown User")
+	}
+	return ok(user)
+}
@@ -0,0 +1,204 @@
+package resttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"slices"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func (s *Server) getChannelWebhooks(r *request) response {
+	channelID := r.id("channel.id")
+	if _, found := s.state.channels[channelID]; !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+	webhooks := []webhook{}
+	for _, w := range s.state.webhooks {
+		if w.ChannelID == channelID {
+			webhooks = append(webhooks, *w)
+		}
+	}
+	slices.SortFunc(webhooks, func(a, b webhook) int {
+		return compareIDs(a.ID, b.ID)
+	})
+	return ok(webhooks)
+}
+
+func (s *Server) createWebhook(r *request) response {
+	channelID := r.id("channel.id")
+	if _, found := s.state.channels[channelID]; !found {
+		return notFound(codeUnknownChannel, "Unknown Channel")
+	}
+	webhookCreate, err := decodeJSON[discord.WebhookCreate](r.body)
+	if err != nil || webhookCreate.Name == "" {
+		return invalidFormBody()
+	}
+
+	token := make([]byte, 32)
+	if _, err = rand.Read(token); err != nil {
+		return errorResponse(http.StatusInternalServerError, 0, err.Error())
+	}
+	user := s.config.User
+	w := &webhook{
+		ID:        s.newID(),
+		Type:      int(discord.WebhookTypeIncoming),
+		Name:      webhookCreate.Name,
+		ChannelID: channelID,
+		GuildID:   s.channelGuildID(channelID),
+		Token:     hex.EncodeToString(token),
+		User:      &user,
+	}
+	s.state.webhooks[w.ID] = w
+	return ok(w)
+}
+
+func (s *Server) getWebhook(r *request) response {
+	w, found := s.state.webhooks[r.id("webhook.id")]
+	if !found {
+		return notFound(codeUnknownWebhook, "Unknown Webhook")
+	}
+	return ok(w)
+}
+
+func (s *Server) deleteWebhook(r *request) response {
+	webhookID := r.id("webhook.id")
+	if _, found := s.state.webhooks[webhookID]; !found {
+		return notFound(codeUnknownWebhook, "Unknown Webhook")
+	}
+	delete(s.state.webhooks, webhookID)
+	return noContent()
+}
+
+// getWebhookWithToken returns the webhook without its user like Discord does for token authenticated requests.
+func (s *Server) getWebhookWithToken(r *request) response {
+	w, rs := s.webhookWithToken(r)
+	if rs != nil {
+		return *rs
+	}
+	withoutUser := *w
+	withoutUser.User = nil
+	return ok(withoutUser)
+}
+
+func (s *Server) webhookWithToken(r *request) (*webhook, *response) {
+	w, found := s.state.webhooks[r.id("webhook.id")]
+	if !found {
+		rs := notFound(codeUnknownWebhook, "Unknown Webhook")
+		return nil, &rs
+	}
+	if w.Token != r.params["webhook.token"] {
+		rs := errorResponse(http.StatusUnauthorized, codeInvalidWebhookToken, "Invalid Webhook Token")
+		return nil, &rs
+	}
+	return w, nil
+}
+
+// webhookTarget is where a webhook message request goes to, either a webhook or the follow-ups of an interaction.
+type webhookTarget struct {
+	channelID   snowflake.ID
+	webhookID   snowflake.ID
+	author      discord.User
+	interaction bool
+}
+
+// resolveWebhook resolves the webhook or the interaction of a webhook message request.
+// Follow-ups are only possible after the interaction was responded to.
+func (s *Server) resolveWebhook(r *request) (webhookTarget, *response) {
+	if i, found := s.state.interactions[r.params["webhook.token"]]; found && i.ApplicationID == r.id("webhook.id") {
+		if i.response == nil {
+			rs := notFound(codeUnknownWebhook, "Unknown Webhook")
+			return webhookTarget{}, &rs
+		}
+		return webhookTarget{
+			channelID:   i.ChannelID,
+			webhookID:   i.ApplicationID,
+			author:      s.config.User,
+			interaction: true,
+		}, nil
+	}
+
+	w, rs := s.webhookWithToken(r)
+	if rs != nil {
+		return webhookTarget{}, rs
+	}
+	target := webhookTarget{
+		channelID: w.ChannelID,
+		webhookID: w.ID,
+		author: discord.User{
+			ID:       w.ID,
+			Username: w.Name,
+			Avatar:   w.Avatar,
+			Bot:      true,
+		},
+	}
+	if threadID, err := snowflake.Parse(r.URL.Query().Get("thread_id")); err == nil && threadID != 0 {
+		if _, found := s.state.channels[threadID]; !found {
+			rs := notFound(codeUnknownChannel, "Unknown Channel")
+			return webhookTarget{}, &rs
+		}
+		target.channelID = threadID
+	}
+	return target, nil
+}
+
+// createWebhookMessage executes the webhook or creates a follow-up message.
+// Webhooks only return the message with the wait query parameter.
+func (s *Server) createWebhookMessage(r *request) response {
+	target, rs := s.resolveWebhook(r)
+	if rs != nil {
+		return *rs
+	}
+	fields, attachments, err := s.payload(r)
+	if err != nil {
+		return invalidFormBody()
+	}
+	if isEmptyMessage(fields, attachments) {
+		return emptyMessage()
+	}
+	message, rs := s.newMessage(fields, attachments, target.channelID, target.author, target.webhookID)
+	if rs != nil {
+		return *rs
+	}
+	s.state.messages[target.channelID] = append(s.state.messages[target.channelID], message)
+
+	if !target.interaction && r.URL.Query().Get("wait") != "true" {
+		return noContent()
+	}
+	return ok(message)
+}
+
+func (s *Server) getWebhookMessage(r *request) response {
+	target, rs := s.resolveWebhook(r)
+	if rs != nil {
+		return *rs
+	}
+	index, found := s.state.message(target.channelID, r.id("message.id"))
+	if !found {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+	message := s.state.messages[target.channelID][index]
+	if message.WebhookID == nil || *message.WebhookID != target.webhookID {
+		return notFound(codeUnknownMessage, "Unknown Message")
+	}
+	return ok(message)
+}
+
+func (s *Server) updateWebhookMessage(r *request) response {
+	target, rs := s.resolveWebhook(r)
+	if rs != nil {
+		return *rs
+	}
+	return s.editMessage(r, target.channelID, r.id("message.id"), target.webhookID)
+}
+
+func (s *Server) deleteWebhookMessage(r *request) response {
+	target, rs := s.resolveWebhook(r)
+	if rs != nil {
+		return *rs
+	}
+	return s.removeMessage(target.channelID, r.id("message.id"), target.webhookID)
+}
@@ -5,10 +5,15 @@ import (
 	"errors"
 	"log/slog"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/disgoorg/snowflake/v2"
 )
 
+// maxReceiveErrors is the number of consecutive failed reads after which the AudioReceiver asks the Conn to reconnect.
+const maxReceiveErrors = 5
+
 type (
 	// AudioReceiverCreateFunc is used to create a new AudioReceiver reading audio from the given Conn.
 	AudioReceiverCreateFunc func(logger *slog.Logger, receiver OpusFrameReceiver, connection Conn) AudioReceiver
@@ -19,6 +24,7 @@ type (
 	// AudioReceiver is used to receive audio from a voice connection and pass it to an OpusFrameReceiver.
 	AudioReceiver interface {
 		// Open starts receiving audio from the voice connection.
+		// It is called again by the Conn after it reconnected.
 		Open()
 
 		// CleanupUser cleans up any audio resources for the given user.
@@ -43,8 +49,11 @@ type (
 
 // NewAudioReceiver creates a new AudioReceiver reading audio to the given OpusFrameReceiver from the given Conn.
 func NewAudioReceiver(logger *slog.Logger, opusReceiver OpusFrameReceiver, conn Conn) AudioReceiver {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &defaultAudioReceiver{
 		logger:       logger,
+		ctx:          ctx,
+		cancelFunc:   cancel,
 		opusReceiver: opusReceiver,
 		conn:         conn,
 	}
@@ -52,51 +61,100 @@ func NewAudioReceiver(logger *slog.Logger, opusReceiver OpusFrameReceiver, conn
 
 type defaultAudioReceiver struct {
 	logger       *slog.Logger
+	ctx          context.Context
 	cancelFunc   context.CancelFunc
 	opusReceiver OpusFrameReceiver
 	conn         Conn
+
+	mu sync.Mutex
+	// running is true while the receive goroutine runs
+	running bool
+	// reopen is set when Open is called while the receive goroutine runs, so it does not exit when the old UDPConn is closed
+	reopen bool
+	// receiveErrors is the number of consecutive failed reads
+	receiveErrors int
 }
 
+// Open starts the receive goroutine. It stops when the UDPConn is closed and is started again by the Conn once it reconnected.
 func (s *defaultAudioReceiver) Open() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return
+	}
+	if s.running {
+		s.reopen = true
+		return
+	}
+	s.running = true
 	go s.open()
 }
 
 func (s *defaultAudioReceiver) open() {
 	defer s.logger.Debug("closing audio receiver")
-	ctx, cancel := context.WithCancel(context.Background())
-	s.cancelFunc = cancel
-	defer cancel()
-loop:
-	for {
-		select {
-		case <-ctx.Done():
-			break loop
-		default:
-			s.receive()
+	for s.ctx.Err() == nil {
+		if err := s.receive(); errors.Is(err, net.ErrClosed) {
+			s.mu.Lock()
+			if s.reopen {
+				s.reopen = false
+				s.mu.Unlock()
+				continue
+			}
+			s.running = false
+			s.mu.Unlock()
+			return
 		}
 	}
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
 }
 
 func (s *defaultAudioReceiver) CleanupUser(userID snowflake.ID) {
 	s.opusReceiver.CleanupUser(userID)
 }
 
-func (s *defaultAudioReceiver) receive() {
+func (s *defaultAudioReceiver) receive() error {
 	packet, err := s.conn.UDP().ReadPacket()
 	if errors.Is(err, net.ErrClosed) {
-		s.Close()
-		return
+		return err
+	}
+	if errors.Is(err, ErrNoSecretKey) {
+		// the Conn is reconnecting, wait for the new session
+		select {
+		case <-s.ctx.Done():
+		case <-time.After(time.Duration(OpusFrameSizeMs) * time.Millisecond):
+		}
+		return nil
 	}
 	if err != nil {
-		s.logger.Error("error while reading packet", slog.String("err", err.Error()))
-		return
+		s.handleErr(err)
+		return nil
 	}
+	s.receiveErrors = 0
 	if s.opusReceiver != nil {
 		if err = s.opusReceiver.ReceiveOpusFrame(s.conn.UserIDBySSRC(packet.SSRC), packet); err != nil {
 			s.logger.Error("error while receiving opus frame", slog.String("err", err.Error()))
 		}
 	}
+	return nil
+}
 
+func (s *defaultAudioReceiver) handleErr(err error) {
+	s.logger.Error("error while reading packet", slog.String("err", err.Error()))
+
+	s.receiveErrors++
+	if s.receiveErrors < maxReceiveErrors {
+		return
+	}
+	s.receiveErrors = 0
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.conn.Reconnect(ctx); err != nil {
+			s.logger.Error("failed to reconnect voice conn", slog.String("err", err.Error()))
+		}
+	}()
 }
 
 func (s *defaultAudioReceiver) Close() {
@@ -21,6 +21,12 @@ const (
 
 	// OpusFrameSizeBytes is the size of an opus frame in bytes.
 	OpusFrameSizeBytes = OpusFrameSize * 2 * 2
+
+	// silenceFrames is the number of SilenceAudioFrame(s) sent when the audio stops, before the speaking state is cleared.
+	silenceFrames = 5
+
+	// maxSendErrors is the number of consecutive failed writes after which the AudioSender asks the Conn to reconnect (100ms of audio).
+	maxSendErrors = 5
 )
 
 type (
@@ -49,7 +55,7 @@ func NewAudioSender(logger *slog.Logger, opusProvider OpusFrameProvider, conn Co
 		logger:       logger,
 		opusProvider: opusProvider,
 		conn:         conn,
-		silentFrames: 5,
+		silentFrames: silenceFrames,
 	}
 }
 
@@ -62,6 +68,9 @@ type defaultAudioSender struct {
 	silentFrames      int
 	sentSpeakingStop  bool
 	sentSpeakingStart bool
+	// speakingSSRC is the SSRC the speaking state was sent for, it changes when the Conn reconnects to a new session
+	speakingSSRC uint32
+	sendErrors   int
 }
 
 func (s *defaultAudioSender) Open() {
@@ -106,44 +115,67 @@ func (s *defaultAudioSender) send() {
 	}
 	if len(opus) == 0 {
 		if s.silentFrames > 0 {
-			if _, err = s.conn.UDP().Write(SilenceAudioFrame); err != nil {
-				s.handleErr(err)
-			}
+			s.write(SilenceAudioFrame)
 			s.silentFrames--
 		} else if !s.sentSpeakingStop {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-			if err = s.conn.SetSpeaking(ctx, SpeakingFlagNone); err != nil {
-				s.handleErr(err)
+			if s.setSpeaking(SpeakingFlagNone) {
+				s.sentSpeakingStop = true
+				s.sentSpeakingStart = false
 			}
-			s.sentSpeakingStop = true
-			s.sentSpeakingStart = false
 		}
 		return
 	}
 
-	if !s.sentSpeakingStart {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		if err = s.conn.SetSpeaking(ctx, SpeakingFlagMicrophone); err != nil {
-			s.handleErr(err)
+	// resend the speaking state after the Conn reconnected to a new session
+	if !s.sentSpeakingStart || s.speakingSSRC != s.conn.Gateway().SSRC() {
+		if s.setSpeaking(SpeakingFlagMicrophone) {
+			s.sentSpeakingStart = true
+			s.sentSpeakingStop = false
+			s.silentFrames = silenceFrames
 		}
-		s.sentSpeakingStart = true
-		s.sentSpeakingStop = false
-		s.silentFrames = 5
 	}
 
-	if _, err = s.conn.UDP().Write(opus); err != nil {
+	s.write(opus)
+}
+
+func (s *defaultAudioSender) setSpeaking(flags SpeakingFlags) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.conn.SetSpeaking(ctx, flags); err != nil {
 		s.handleErr(err)
+		return false
 	}
+	s.speakingSSRC = s.conn.Gateway().SSRC()
+	return true
+}
+
+func (s *defaultAudioSender) write(opus []byte) {
+	if _, err := s.conn.UDP().Write(opus); err != nil {
+		s.handleErr(err)
+		return
+	}
+	s.sendErrors = 0
 }
 
 func (s *defaultAudioSender) handleErr(err error) {
-	if errors.Is(err, net.ErrClosed) || errors.Is(err, ErrGatewayNotConnected) {
-		s.Close()
+	// the Conn is reconnecting, drop the frame & keep going until it is ready again
+	if errors.Is(err, net.ErrClosed) || errors.Is(err, ErrGatewayNotConnected) || errors.Is(err, ErrNoSecretKey) {
 		return
 	}
 	s.logger.Error("failed to send audio", slog.String("err", err.Error()))
+
+	s.sendErrors++
+	if s.sendErrors < maxSendErrors {
+		return
+	}
+	s.sendErrors = 0
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.conn.Reconnect(ctx); err != nil {
+			s.logger.Error("failed to reconnect voice conn", slog.String("err", err.Error()))
+		}
+	}()
 }
 
 func (s *defaultAudioSender) Close() {
@@ -2,11 +2,14 @@ package voice
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
 
 	botgateway "github.com/disgoorg/disgo/gateway"
 )
@@ -45,8 +48,13 @@ type (
 		SetEventHandlerFunc(eventHandlerFunc EventHandlerFunc)
 
 		// Open opens the voice conn. It will connect to the voice gateway and start the Conn conn after it receives the Gateway events.
+		// It returns an error if the voice session could not be established, for example if no supported EncryptionMode is offered.
 		Open(ctx context.Context, channelID snowflake.ID, selfMute bool, selfDeaf bool) error
 
+		// Reconnect closes the voice Gateway & UDPConn and identifies a new session with the current voice server.
+		// The OpusFrameProvider & OpusFrameReceiver stay attached.
+		Reconnect(ctx context.Context) error
+
 		// Close closes the voice conn. It will close the Conn conn and disconnect from the voice gateway.
 		Close(ctx context.Context)
 
@@ -58,6 +66,62 @@ type (
 	}
 )
 
+// ConnLifecycleEventType is the type of ConnLifecycleEvent.
+type ConnLifecycleEventType int
+
+// All ConnLifecycleEventType(s).
+const (
+	// ConnLifecycleEventReady is emitted when a voice session is established & audio can be sent.
+	ConnLifecycleEventReady ConnLifecycleEventType = iota
+	// ConnLifecycleEventResumed is emitted when the voice Gateway resumed the session.
+	ConnLifecycleEventResumed
+	// ConnLifecycleEventReconnecting is emitted when the Conn identifies a new session, for example after the UDPConn failed.
+	ConnLifecycleEventReconnecting
+	// ConnLifecycleEventServerChanged is emitted when discord moved the Conn to another voice server.
+	ConnLifecycleEventServerChanged
+	// ConnLifecycleEventChannelMoved is emitted when the bot was moved to another voice channel.
+	ConnLifecycleEventChannelMoved
+	// ConnLifecycleEventDisconnected is emitted when the voice Gateway closed and won't reconnect by itself.
+	ConnLifecycleEventDisconnected
+	// ConnLifecycleEventClosed is emitted when the bot left the voice channel.
+	ConnLifecycleEventClosed
+)
+
+func (t ConnLifecycleEventType) String() string {
+	switch t {
+	case ConnLifecycleEventReady:
+		return "ready"
+	case ConnLifecycleEventResumed:
+		return "resumed"
+	case ConnLifecycleEventReconnecting:
+		return "reconnecting"
+	case ConnLifecycleEventServerChanged:
+		return "server_changed"
+	case ConnLifecycleEventChannelMoved:
+		return "channel_moved"
+	case ConnLifecycleEventDisconnected:
+		return "disconnected"
+	case ConnLifecycleEventClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ConnLifecycleEvent is emitted by a Conn when its connection state changes.
+type ConnLifecycleEvent struct {
+	Type ConnLifecycleEventType
+	// ChannelID is the voice channel the Conn is connected to.
+	ChannelID *snowflake.ID
+	// Endpoint is the voice server the Conn is connected to.
+	Endpoint string
+	// Err is the error which caused the event, if any.
+	Err error
+}
+
+// ConnLifecycleHandlerFunc is used to receive the ConnLifecycleEvent(s) of a Conn.
+type ConnLifecycleHandlerFunc func(conn Conn, event ConnLifecycleEvent)
+
 // NewConn returns a new default voice conn.
 func NewConn(guildID snowflake.ID, userID snowflake.ID, voiceStateUpdateFunc StateUpdateFunc, removeConnFunc func(), opts ...ConnConfigOpt) Conn {
 	config := DefaultConnConfig()
@@ -72,7 +136,7 @@ func NewConn(guildID snowflake.ID, userID snowflake.ID, voiceStateUpdateFunc Sta
 			GuildID: guildID,
 			UserID:  userID,
 		},
-		openedChan: make(chan struct{}, 1),
+		openedChan: make(chan error, 1),
 		closedChan: make(chan struct{}, 1),
 		ssrcs:      map[uint32]snowflake.ID{},
 	}
@@ -96,15 +160,23 @@ type connImpl struct {
 
 	audioSender   AudioSender
 	audioReceiver AudioReceiver
+	audioMu       sync.Mutex
 
-	openedChan chan struct{}
+	// openedChan receives the result of opening the voice session
+	openedChan chan error
 	closedChan chan struct{}
 
+	// serverUpdates is incremented for every voice server update, it is used to detect if a new voice server was assigned after a disconnect
+	serverUpdates int
+	reconnecting  atomic.Bool
+
 	ssrcs   map[uint32]snowflake.ID
 	ssrcsMu sync.Mutex
 }
 
 func (c *connImpl) ChannelID() *snowflake.ID {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
 	return c.state.ChannelID
 }
 
@@ -134,6 +206,8 @@ func (c *connImpl) UDP() UDPConn {
 }
 
 func (c *connImpl) SetOpusFrameProvider(provider OpusFrameProvider) {
+	c.audioMu.Lock()
+	defer c.audioMu.Unlock()
 	if c.audioSender != nil {
 		c.audioSender.Close()
 	}
@@ -142,6 +216,8 @@ func (c *connImpl) SetOpusFrameProvider(provider OpusFrameProvider) {
 }
 
 func (c *connImpl) SetOpusFrameReceiver(handler OpusFrameReceiver) {
+	c.audioMu.Lock()
+	defer c.audioMu.Unlock()
 	if c.audioReceiver != nil {
 		c.audioReceiver.Close()
 	}
@@ -149,76 +225,173 @@ func (c *connImpl) SetOpusFrameReceiver(handler OpusFrameReceiver) {
 	c.audioReceiver.Open()
 }
 
+func (c *connImpl) closeAudio() {
+	c.audioMu.Lock()
+	defer c.audioMu.Unlock()
+	if c.audioSender != nil {
+		c.audioSender.Close()
+		c.audioSender = nil
+	}
+	if c.audioReceiver != nil {
+		c.audioReceiver.Close()
+		c.audioReceiver = nil
+	}
+}
+
 func (c *connImpl) SetEventHandlerFunc(eventHandlerFunc EventHandlerFunc) {
 	c.config.EventHandlerFunc = eventHandlerFunc
 }
 
+func (c *connImpl) emit(eventType ConnLifecycleEventType, err error) {
+	if c.config.LifecycleHandlerFunc == nil {
+		return
+	}
+	c.stateMu.Lock()
+	event := ConnLifecycleEvent{
+		Type:      eventType,
+		ChannelID: c.state.ChannelID,
+		Endpoint:  c.state.Endpoint,
+		Err:       err,
+	}
+	c.stateMu.Unlock()
+	c.config.LifecycleHandlerFunc(c, event)
+}
+
 func (c *connImpl) HandleVoiceStateUpdate(update botgateway.EventVoiceStateUpdate) {
+	c.stateMu.Lock()
 	if update.GuildID != c.state.GuildID || update.UserID != c.state.UserID {
+		c.stateMu.Unlock()
 		return
 	}
 
+	oldChannelID := c.state.ChannelID
+	c.state.ChannelID = update.ChannelID
+	c.state.SessionID = update.SessionID
+	c.stateMu.Unlock()
+
 	if update.ChannelID == nil {
-		c.state.ChannelID = nil
-		if c.audioSender != nil {
-			c.audioSender.Close()
-			c.audioSender = nil
-		}
-		if c.audioReceiver != nil {
-			c.audioReceiver.Close()
-			c.audioReceiver = nil
-		}
+		c.closeAudio()
 		_ = c.udp.Close()
 		c.gateway.Close()
-		c.closedChan <- struct{}{}
-	} else {
-		c.state.ChannelID = update.ChannelID
+		select {
+		case c.closedChan <- struct{}{}:
+		default:
+		}
+		c.emit(ConnLifecycleEventClosed, nil)
+		return
+	}
+
+	// the voice session survives channel moves on the same voice server, a new server is announced by a voice server update
+	if oldChannelID != nil && *oldChannelID != *update.ChannelID {
+		c.emit(ConnLifecycleEventChannelMoved, nil)
 	}
-	c.state.SessionID = update.SessionID
 }
 
 func (c *connImpl) HandleVoiceServerUpdate(update botgateway.EventVoiceServerUpdate) {
 	c.stateMu.Lock()
-	defer c.stateMu.Unlock()
 	if update.GuildID != c.state.GuildID || update.Endpoint == nil {
+		c.stateMu.Unlock()
 		return
 	}
 
+	// a voice server update without a new endpoint only refreshes the token, the session has to be identified again anyway
+	reopen := c.state.Endpoint != ""
+	changed := reopen && c.state.Endpoint != *update.Endpoint
 	c.state.Token = update.Token
 	c.state.Endpoint = *update.Endpoint
+	c.serverUpdates++
+	state := c.state
+	c.stateMu.Unlock()
+
 	go func() {
+		if changed {
+			c.emit(ConnLifecycleEventServerChanged, nil)
+		}
+		if reopen {
+			// the old session is bound to the previous voice server & token, close it gracefully so the Gateway identifies a new one
+			c.gateway.Close()
+			_ = c.udp.Close()
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		if err := c.gateway.Open(ctx, c.state); err != nil {
-			c.config.Logger.Error("error opening voice gateway. error: ", err)
+		if err := c.gateway.Open(ctx, state); err != nil {
+			c.config.Logger.Error("error opening voice gateway", slog.String("err", err.Error()))
 		}
 	}()
 }
 
+func (c *connImpl) Reconnect(ctx context.Context) error {
+	if !c.reconnecting.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer c.reconnecting.Store(false)
+
+	c.stateMu.Lock()
+	state := c.state
+	c.stateMu.Unlock()
+	if state.Endpoint == "" || state.ChannelID == nil {
+		return ErrGatewayNotConnected
+	}
+
+	c.config.Logger.Debug("reconnecting voice conn")
+	c.emit(ConnLifecycleEventReconnecting, nil)
+	c.gateway.Close()
+	_ = c.udp.Close()
+	return c.gateway.Open(ctx, state)
+}
+
 func (c *connImpl) handleMessage(op Opcode, data GatewayMessageData) {
 	switch d := data.(type) {
 	case GatewayMessageDataReady:
+		// SSRCs are only valid for the session they were received in
+		c.ssrcsMu.Lock()
+		clear(c.ssrcs)
+		c.ssrcsMu.Unlock()
+
+		mode, err := ChooseEncryptionMode(c.config.EncryptionModes, d.Modes)
+		if err != nil {
+			c.config.Logger.Error("voice: failed to choose encryption mode", slog.String("err", err.Error()))
+			c.fail(err)
+			break
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		ourAddress, ourPort, err := c.udp.Open(ctx, d.IP, d.Port, d.SSRC)
 		if err != nil {
-			c.config.Logger.Error("voice: failed to open voiceudp conn. error: ", err)
+			c.config.Logger.Error("voice: failed to open voiceudp conn", slog.String("err", err.Error()))
+			c.fail(err)
 			break
 		}
+		c.config.Logger.Debug("voice: selecting encryption mode", slog.String("mode", string(mode)))
 		if err = c.Gateway().Send(ctx, OpcodeSelectProtocol, GatewayMessageDataSelectProtocol{
 			Protocol: ProtocolUDP,
 			Data: GatewayMessageDataSelectProtocolData{
 				Address: ourAddress,
 				Port:    ourPort,
-				Mode:    EncryptionModeNormal,
+				Mode:    mode,
 			},
 		}); err != nil {
-			c.config.Logger.Error("voice: failed to send select protocol. error: ", err)
+			c.config.Logger.Error("voice: failed to send select protocol", slog.String("err", err.Error()))
 		}
 
 	case GatewayMessageDataSessionDescription:
-		c.udp.SetSecretKey(d.SecretKey)
-		c.openedChan <- struct{}{}
+		if err := c.udp.SetSecretKey(d.Mode, d.SecretKey); err != nil {
+			c.config.Logger.Error("voice: failed to set secret key", slog.String("err", err.Error()))
+			c.fail(err)
+			break
+		}
+		select {
+		case c.openedChan <- nil:
+		default:
+		}
+		// the AudioReceiver stops when the UDPConn is closed, start it again for the new session
+		c.audioMu.Lock()
+		if c.audioReceiver != nil {
+			c.audioReceiver.Open()
+		}
+		c.audioMu.Unlock()
+		c.emit(ConnLifecycleEventReady, nil)
 
 	case GatewayMessageDataSpeaking:
 		c.ssrcsMu.Lock()
@@ -234,16 +407,61 @@ func (c *connImpl) handleMessage(op Opcode, data GatewayMessageData) {
 				break
 			}
 		}
+		c.audioMu.Lock()
 		if c.audioReceiver != nil {
 			c.audioReceiver.CleanupUser(d.UserID)
 		}
+		c.audioMu.Unlock()
+	}
+	if op == OpcodeResumed {
+		c.emit(ConnLifecycleEventResumed, nil)
 	}
+	c.dispatchEvent(op, data)
+}
+
+// dispatchEvent passes the event to the EventHandlerFunc of the Conn.
+func (c *connImpl) dispatchEvent(op Opcode, data GatewayMessageData) {
 	if c.config.EventHandlerFunc != nil {
 		c.config.EventHandlerFunc(op, data)
 	}
 }
 
-func (c *connImpl) handleGatewayClose(_ Gateway, _ error) {
+// fail closes the voice Gateway & UDPConn after the voice session could not be established.
+// A pending Open returns the given error.
+func (c *connImpl) fail(err error) {
+	select {
+	case c.openedChan <- err:
+	default:
+	}
+	c.gateway.Close()
+	_ = c.udp.Close()
+	c.emit(ConnLifecycleEventDisconnected, err)
+}
+
+func (c *connImpl) handleGatewayClose(_ Gateway, err error) {
+	c.emit(ConnLifecycleEventDisconnected, err)
+
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) && closeErr.Code == GatewayCloseEventCodeDisconnected.Code {
+		// we were moved to another voice server or kicked, wait for the voice server or voice state update telling us which one it was
+		c.stateMu.Lock()
+		serverUpdates := c.serverUpdates
+		c.stateMu.Unlock()
+
+		time.AfterFunc(c.config.MoveTimeout, func() {
+			c.stateMu.Lock()
+			moved := c.serverUpdates != serverUpdates
+			c.stateMu.Unlock()
+			if moved {
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			c.Close(ctx)
+		})
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	c.Close(ctx)
@@ -257,8 +475,8 @@ func (c *connImpl) Open(ctx context.Context, channelID snowflake.ID, selfMute bo
 	}
 
 	select {
-	case <-c.openedChan:
-		return nil
+	case err := <-c.openedChan:
+		return err
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -268,12 +486,10 @@ func (c *connImpl) Close(ctx context.Context) {
 	_ = c.voiceStateUpdateFunc(ctx, c.state.GuildID, nil, false, false)
 	defer c.gateway.Close()
 	defer c.udp.Close()
+	defer c.closeAudio()
 
 	select {
-	case _, ok := <-c.closedChan:
-		if ok {
-			close(c.closedChan)
-		}
+	case <-c.closedChan:
 	case <-ctx.Done():
 	}
 	c.removeConnFunc()
@@ -2,6 +2,7 @@ package voice
 
 import (
 	"log/slog"
+	"time"
 )
 
 // DefaultConnConfig returns a ConnConfig with sensible defaults.
@@ -12,6 +13,8 @@ func DefaultConnConfig() *ConnConfig {
 		UDPConnCreateFunc:       NewUDPConn,
 		AudioSenderCreateFunc:   NewAudioSender,
 		AudioReceiverCreateFunc: NewAudioReceiver,
+		EncryptionModes:         DefaultEncryptionModes,
+		MoveTimeout:             5 * time.Second,
 	}
 }
 
@@ -28,7 +31,14 @@ type ConnConfig struct {
 	AudioSenderCreateFunc   AudioSenderCreateFunc
 	AudioReceiverCreateFunc AudioReceiverCreateFunc
 
-	EventHandlerFunc EventHandlerFunc
+	EventHandlerFunc     EventHandlerFunc
+	LifecycleHandlerFunc ConnLifecycleHandlerFunc
+
+	// EncryptionModes are the EncryptionMode(s) the Conn negotiates with the voice server ordered by preference.
+	EncryptionModes []EncryptionMode
+
+	// MoveTimeout is how long the Conn waits for a new voice server after the voice Gateway closed with GatewayCloseEventCodeDisconnected before it closes itself.
+	MoveTimeout time.Duration
 }
 
 // ConnConfigOpt is used to functionally configure a ConnConfig.
@@ -96,3 +106,24 @@ func WithConnEventHandlerFunc(eventHandlerFunc EventHandlerFunc) ConnConfigOpt {
 		config.EventHandlerFunc = eventHandlerFunc
 	}
 }
+
+// WithConnEncryptionModes sets the EncryptionMode(s) the Conn(s) negotiate with the voice server ordered by preference.
+func WithConnEncryptionModes(modes ...EncryptionMode) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.EncryptionModes = modes
+	}
+}
+
+// WithConnLifecycleHandlerFunc sets the Conn(s) used ConnLifecycleHandlerFunc.
+func WithConnLifecycleHandlerFunc(lifecycleHandlerFunc ConnLifecycleHandlerFunc) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.LifecycleHandlerFunc = lifecycleHandlerFunc
+	}
+}
+
+// WithConnMoveTimeout sets how long the Conn(s) wait for a new voice server after being disconnected by discord.
+func WithConnMoveTimeout(timeout time.Duration) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.MoveTimeout = timeout
+	}
+}
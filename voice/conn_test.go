@@ -0,0 +1,290 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	botgateway "github.com/disgoorg/disgo/gateway"
+)
+
+// fakeGateway is a Gateway which answers Open with the configured ready message instead of connecting to discord.
+type fakeGateway struct {
+	eventHandlerFunc EventHandlerFunc
+	closeHandlerFunc CloseHandlerFunc
+	ready            GatewayMessageDataReady
+
+	mu     sync.Mutex
+	opened []State
+	closed int
+}
+
+func (g *fakeGateway) SSRC() uint32           { return g.ready.SSRC }
+func (g *fakeGateway) Latency() time.Duration { return 0 }
+
+func (g *fakeGateway) Open(_ context.Context, state State) error {
+	g.mu.Lock()
+	g.opened = append(g.opened, state)
+	g.mu.Unlock()
+	go g.eventHandlerFunc(OpcodeReady, g.ready)
+	return nil
+}
+
+func (g *fakeGateway) Close() {
+	g.CloseWithCode(0, "")
+}
+
+func (g *fakeGateway) CloseWithCode(int, string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closed++
+}
+
+func (g *fakeGateway) Send(_ context.Context, op Opcode, data GatewayMessageData) error {
+	if op == OpcodeSelectProtocol {
+		go g.eventHandlerFunc(OpcodeSessionDescription, GatewayMessageDataSessionDescription{
+			Mode: data.(GatewayMessageDataSelectProtocol).Data.Mode,
+		})
+	}
+	return nil
+}
+
+func (g *fakeGateway) openedStates() []State {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]State(nil), g.opened...)
+}
+
+// fakeUDPConn is a UDPConn which does not send or receive anything.
+type fakeUDPConn struct {
+	mu     sync.Mutex
+	closed int
+	// readErr is returned by ReadPacket, net.ErrClosed if nil
+	readErr error
+}
+
+func (u *fakeUDPConn) LocalAddr() net.Addr                         { return nil }
+func (u *fakeUDPConn) RemoteAddr() net.Addr                        { return nil }
+func (u *fakeUDPConn) SetSecretKey(EncryptionMode, [32]byte) error { return nil }
+func (u *fakeUDPConn) SetDeadline(time.Time) error                 { return nil }
+func (u *fakeUDPConn) SetReadDeadline(time.Time) error             { return nil }
+func (u *fakeUDPConn) SetWriteDeadline(time.Time) error            { return nil }
+func (u *fakeUDPConn) Read([]byte) (int, error)                    { return 0, net.ErrClosed }
+func (u *fakeUDPConn) Write(p []byte) (int, error)                 { return len(p), nil }
+func (u *fakeUDPConn) Open(context.Context, string, int, uint32) (string, int, error) {
+	return "127.0.0.1", 50000, nil
+}
+
+func (u *fakeUDPConn) ReadPacket() (*Packet, error) {
+	time.Sleep(time.Millisecond)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.readErr != nil {
+		return nil, u.readErr
+	}
+	return nil, net.ErrClosed
+}
+
+func (u *fakeUDPConn) closedCount() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.closed
+}
+
+func (u *fakeUDPConn) Close() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.closed++
+	return nil
+}
+
+type testConn struct {
+	*connImpl
+	gateway *fakeGateway
+	udp     *fakeUDPConn
+}
+
+// newTestConn creates a Conn which joins the voice channel on the given endpoint when it is opened.
+func newTestConn(t *testing.T, endpoint string, ready GatewayMessageDataReady, opts ...ConnConfigOpt) *testConn {
+	t.Helper()
+	const (
+		guildID = snowflake.ID(1)
+		userID  = snowflake.ID(2)
+	)
+	tc := &testConn{udp: &fakeUDPConn{}}
+
+	var conn Conn
+	stateUpdate := func(_ context.Context, _ snowflake.ID, channelID *snowflake.ID, _ bool, _ bool) error {
+		go func() {
+			conn.HandleVoiceStateUpdate(botgateway.EventVoiceStateUpdate{VoiceState: discord.VoiceState{GuildID: guildID, ChannelID: channelID, UserID: userID, SessionID: "session"}})
+			if channelID != nil {
+				conn.HandleVoiceServerUpdate(botgateway.EventVoiceServerUpdate{Token: "token", GuildID: guildID, Endpoint: &endpoint})
+			}
+		}()
+		return nil
+	}
+
+	conn = NewConn(guildID, userID, stateUpdate, func() {}, append([]ConnConfigOpt{
+		WithConnGatewayCreateFunc(func(eventHandlerFunc EventHandlerFunc, closeHandlerFunc CloseHandlerFunc, _ ...GatewayConfigOpt) Gateway {
+			tc.gateway = &fakeGateway{eventHandlerFunc: eventHandlerFunc, closeHandlerFunc: closeHandlerFunc, ready: ready}
+			return tc.gateway
+		}),
+		WithUDPConnCreateFunc(func(...UDPConnConfigOpt) UDPConn {
+			return tc.udp
+		}),
+	}, opts...)...)
+	tc.connImpl = conn.(*connImpl)
+	return tc
+}
+
+func TestConnOpenUnsupportedEncryptionMode(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []ConnLifecycleEvent
+	)
+	conn := newTestConn(t, "voice.discord.media", GatewayMessageDataReady{SSRC: 1, Modes: []string{"unknown_mode"}},
+		WithConnLifecycleHandlerFunc(func(_ Conn, event ConnLifecycleEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := conn.Open(ctx, 3, false, false)
+	assert.ErrorIs(t, err, ErrUnsupportedEncryptionMode, "Open should fail right away instead of waiting for its timeout")
+	require.NoError(t, ctx.Err())
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) > 0
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, ConnLifecycleEventDisconnected, events[0].Type)
+	assert.ErrorIs(t, events[0].Err, ErrUnsupportedEncryptionMode)
+	assert.Positive(t, conn.udp.closedCount())
+}
+
+type lifecycleRecorder struct {
+	mu     sync.Mutex
+	events []ConnLifecycleEventType
+}
+
+func (r *lifecycleRecorder) handle(_ Conn, event ConnLifecycleEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event.Type)
+}
+
+func (r *lifecycleRecorder) types() []ConnLifecycleEventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ConnLifecycleEventType(nil), r.events...)
+}
+
+func openTestConn(t *testing.T, conn *testConn) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, conn.Open(ctx, 3, false, false))
+}
+
+var supportedReady = GatewayMessageDataReady{SSRC: 1, Modes: []string{string(EncryptionModeAEADXChaCha20Poly1305RTPSize)}}
+
+func TestConnServerUpdate(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	conn := newTestConn(t, "a.discord.media", supportedReady, WithConnLifecycleHandlerFunc(recorder.handle))
+	openTestConn(t, conn)
+	assert.Equal(t, []ConnLifecycleEventType{ConnLifecycleEventReady}, recorder.types())
+
+	// discord moved us to another voice server
+	endpoint := "b.discord.media"
+	conn.HandleVoiceServerUpdate(botgateway.EventVoiceServerUpdate{Token: "new token", GuildID: 1, Endpoint: &endpoint})
+	require.Eventually(t, func() bool {
+		return len(recorder.types()) == 3
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []ConnLifecycleEventType{ConnLifecycleEventReady, ConnLifecycleEventServerChanged, ConnLifecycleEventReady}, recorder.types())
+
+	// a new token for the same voice server identifies a new session without a server change
+	conn.HandleVoiceServerUpdate(botgateway.EventVoiceServerUpdate{Token: "newer token", GuildID: 1, Endpoint: &endpoint})
+	require.Eventually(t, func() bool {
+		return len(recorder.types()) == 4
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, ConnLifecycleEventReady, recorder.types()[3])
+
+	states := conn.gateway.openedStates()
+	require.Len(t, states, 3)
+	assert.Equal(t, "a.discord.media", states[0].Endpoint)
+	assert.Equal(t, "b.discord.media", states[1].Endpoint)
+	assert.Equal(t, "new token", states[1].Token)
+	assert.Equal(t, "newer token", states[2].Token)
+}
+
+type nopOpusFrameReceiver struct{}
+
+func (nopOpusFrameReceiver) ReceiveOpusFrame(snowflake.ID, *Packet) error { return nil }
+func (nopOpusFrameReceiver) CleanupUser(snowflake.ID)                     {}
+func (nopOpusFrameReceiver) Close()                                       {}
+
+func TestConnReconnectOnReceiveErrors(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	conn := newTestConn(t, "a.discord.media", supportedReady, WithConnLifecycleHandlerFunc(recorder.handle))
+	openTestConn(t, conn)
+
+	conn.udp.mu.Lock()
+	conn.udp.readErr = errors.New("connection refused")
+	conn.udp.mu.Unlock()
+	conn.SetOpusFrameReceiver(nopOpusFrameReceiver{})
+	defer conn.closeAudio()
+
+	require.Eventually(t, func() bool {
+		return slices.Contains(recorder.types(), ConnLifecycleEventReconnecting)
+	}, time.Second, time.Millisecond)
+
+	conn.udp.mu.Lock()
+	conn.udp.readErr = nil
+	conn.udp.mu.Unlock()
+	require.Eventually(t, func() bool {
+		return len(conn.gateway.openedStates()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Positive(t, conn.udp.closedCount())
+}
+
+func TestAudioReceiverStopsWhenClosed(t *testing.T) {
+	conn := newTestConn(t, "a.discord.media", supportedReady)
+	receiver := NewAudioReceiver(conn.config.Logger, nopOpusFrameReceiver{}, conn).(*defaultAudioReceiver)
+
+	running := func() bool {
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		return receiver.running
+	}
+
+	// the UDPConn is closed, the goroutine should exit instead of polling it
+	receiver.Open()
+	require.Eventually(t, func() bool { return !running() }, time.Second, time.Millisecond)
+
+	// the Conn starts it again after it reconnected
+	conn.udp.mu.Lock()
+	conn.udp.readErr = ErrNoSecretKey
+	conn.udp.mu.Unlock()
+	receiver.Open()
+	assert.True(t, running())
+
+	receiver.Close()
+	require.Eventually(t, func() bool { return !running() }, time.Second, time.Millisecond)
+	receiver.Open()
+	assert.False(t, running(), "a closed AudioReceiver should not start again")
+}
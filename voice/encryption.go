@@ -0,0 +1,154 @@
+package voice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// ErrUnsupportedEncryptionMode is returned when no supported EncryptionMode is offered by the voice server or an unknown EncryptionMode is used.
+var ErrUnsupportedEncryptionMode = errors.New("unsupported encryption mode")
+
+// EncryptionMode is the encryption mode used for voice data.
+type EncryptionMode string
+
+// All possible EncryptionMode(s) https://discord.com/developers/docs/topics/voice-connections#transport-encryption-modes.
+const (
+	// EncryptionModeAEADAES256GCMRTPSize encrypts packets with AES-256-GCM. This is the preferred mode.
+	EncryptionModeAEADAES256GCMRTPSize EncryptionMode = "aead_aes256_gcm_rtpsize"
+	// EncryptionModeAEADXChaCha20Poly1305RTPSize encrypts packets with XChaCha20-Poly1305. This mode is always available.
+	EncryptionModeAEADXChaCha20Poly1305RTPSize EncryptionMode = "aead_xchacha20_poly1305_rtpsize"
+
+	// Deprecated: Discord is removing this mode, use EncryptionModeAEADAES256GCMRTPSize or EncryptionModeAEADXChaCha20Poly1305RTPSize instead.
+	EncryptionModeNormal EncryptionMode = "xsalsa20_poly1305"
+	// Deprecated: Discord is removing this mode, use EncryptionModeAEADAES256GCMRTPSize or EncryptionModeAEADXChaCha20Poly1305RTPSize instead.
+	EncryptionModeSuffix EncryptionMode = "xsalsa20_poly1305_suffix"
+	// Deprecated: Discord is removing this mode, use EncryptionModeAEADAES256GCMRTPSize or EncryptionModeAEADXChaCha20Poly1305RTPSize instead.
+	EncryptionModeLite EncryptionMode = "xsalsa20_poly1305_lite"
+)
+
+// DefaultEncryptionModes are the EncryptionMode(s) supported by the UDPConn ordered by preference.
+var DefaultEncryptionModes = []EncryptionMode{
+	EncryptionModeAEADAES256GCMRTPSize,
+	EncryptionModeAEADXChaCha20Poly1305RTPSize,
+	EncryptionModeNormal,
+}
+
+// ChooseEncryptionMode returns the first EncryptionMode of the preferred EncryptionMode(s) which is offered by the voice server.
+func ChooseEncryptionMode(preferred []EncryptionMode, offered []string) (EncryptionMode, error) {
+	for _, mode := range preferred {
+		for _, offeredMode := range offered {
+			if string(mode) == offeredMode {
+				return mode, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: offered modes %v", ErrUnsupportedEncryptionMode, offered)
+}
+
+// encrypter seals & opens RTP packets with the negotiated EncryptionMode.
+type encrypter interface {
+	// Seal encrypts the payload & appends the RTP header, the encrypted payload & the nonce to dst.
+	Seal(dst []byte, header []byte, payload []byte) []byte
+
+	// Open decrypts the given RTP packet. headerSize is the size of the unencrypted part of the packet.
+	// It returns the decrypted part of the packet.
+	Open(dst []byte, packet []byte, headerSize int) ([]byte, error)
+
+	// RTPSize returns whether the RTP extension header is sent unencrypted.
+	RTPSize() bool
+}
+
+func newEncrypter(mode EncryptionMode, secretKey [32]byte) (encrypter, error) {
+	switch mode {
+	case EncryptionModeAEADAES256GCMRTPSize:
+		block, err := aes.NewCipher(secretKey[:])
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		return &aeadEncrypter{aead: aead}, nil
+
+	case EncryptionModeAEADXChaCha20Poly1305RTPSize:
+		aead, err := chacha20poly1305.NewX(secretKey[:])
+		if err != nil {
+			return nil, err
+		}
+		return &aeadEncrypter{aead: aead}, nil
+
+	case EncryptionModeNormal:
+		return &xsalsa20Encrypter{secretKey: secretKey}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncryptionMode, mode)
+	}
+}
+
+// aeadEncrypter implements the *_rtpsize AEAD modes.
+// The RTP header including the extension header is used as additional data, the 32-bit nonce is appended to the packet.
+type aeadEncrypter struct {
+	aead  cipher.AEAD
+	nonce uint32
+}
+
+func (e *aeadEncrypter) Seal(dst []byte, header []byte, payload []byte) []byte {
+	nonce := make([]byte, e.aead.NonceSize())
+	binary.BigEndian.PutUint32(nonce, e.nonce)
+	e.nonce++
+
+	dst = append(dst, header...)
+	dst = e.aead.Seal(dst, nonce, payload, header)
+	return append(dst, nonce[:4]...)
+}
+
+func (e *aeadEncrypter) Open(dst []byte, packet []byte, headerSize int) ([]byte, error) {
+	if len(packet) < headerSize+e.aead.Overhead()+4 {
+		return nil, ErrDecryptionFailed
+	}
+	nonce := make([]byte, e.aead.NonceSize())
+	copy(nonce, packet[len(packet)-4:])
+
+	opus, err := e.aead.Open(dst, nonce, packet[headerSize:len(packet)-4], packet[:headerSize])
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return opus, nil
+}
+
+func (e *aeadEncrypter) RTPSize() bool {
+	return true
+}
+
+// xsalsa20Encrypter implements the deprecated xsalsa20_poly1305 mode which uses the RTP header as nonce.
+type xsalsa20Encrypter struct {
+	secretKey [32]byte
+}
+
+func (e *xsalsa20Encrypter) Seal(dst []byte, header []byte, payload []byte) []byte {
+	var nonce [24]byte
+	copy(nonce[:], header)
+	dst = append(dst, header...)
+	return secretbox.Seal(dst, payload, &nonce, &e.secretKey)
+}
+
+func (e *xsalsa20Encrypter) Open(dst []byte, packet []byte, headerSize int) ([]byte, error) {
+	var nonce [24]byte
+	copy(nonce[:], packet[:headerSize])
+	opus, ok := secretbox.Open(dst, packet[headerSize:], &nonce, &e.secretKey)
+	if !ok {
+		return nil, ErrDecryptionFailed
+	}
+	return opus, nil
+}
+
+func (e *xsalsa20Encrypter) RTPSize() bool {
+	return false
+}
@@ -0,0 +1,58 @@
+package voice
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypter_SealOpen(t *testing.T) {
+	var secretKey [32]byte
+	copy(secretKey[:], "01234567890123456789012345678901")
+
+	header := []byte{0x80, 0x78, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3}
+	payload := []byte("opus frame")
+
+	for _, mode := range DefaultEncryptionModes {
+		t.Run(string(mode), func(t *testing.T) {
+			sender, err := newEncrypter(mode, secretKey)
+			assert.NoError(t, err)
+			receiver, err := newEncrypter(mode, secretKey)
+			assert.NoError(t, err)
+
+			packet := sender.Seal(nil, header, payload)
+			assert.Equal(t, header, packet[:len(header)])
+
+			opus, err := receiver.Open(nil, packet, len(header))
+			assert.NoError(t, err)
+			assert.Equal(t, payload, opus)
+
+			packet[len(packet)-5] ^= 0xFF
+			_, err = receiver.Open(nil, packet, len(header))
+			assert.ErrorIs(t, err, ErrDecryptionFailed)
+		})
+	}
+}
+
+func TestEncrypter_RTPSizeNonce(t *testing.T) {
+	var secretKey [32]byte
+	sender, err := newEncrypter(EncryptionModeAEADXChaCha20Poly1305RTPSize, secretKey)
+	assert.NoError(t, err)
+
+	header := make([]byte, OpusPacketHeaderSize)
+	first := sender.Seal(nil, header, []byte{1})
+	second := sender.Seal(nil, header, []byte{1})
+
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(first[len(first)-4:]))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(second[len(second)-4:]))
+}
+
+func TestChooseEncryptionMode(t *testing.T) {
+	mode, err := ChooseEncryptionMode(DefaultEncryptionModes, []string{"xsalsa20_poly1305", "aead_xchacha20_poly1305_rtpsize"})
+	assert.NoError(t, err)
+	assert.Equal(t, EncryptionModeAEADXChaCha20Poly1305RTPSize, mode)
+
+	_, err = ChooseEncryptionMode(DefaultEncryptionModes, []string{"xsalsa20_poly1305_lite"})
+	assert.ErrorIs(t, err, ErrUnsupportedEncryptionMode)
+}
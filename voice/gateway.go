@@ -8,14 +8,13 @@ import (
 	"io"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/disgoorg/json"
 	"github.com/disgoorg/snowflake/v2"
 	"github.com/gorilla/websocket"
-
-	"github.com/disgoorg/disgo/discord"
 )
 
 var (
@@ -27,7 +26,7 @@ var (
 )
 
 // GatewayVersion is the version of the voice gateway we are using.
-const GatewayVersion = 4
+const GatewayVersion = 8
 
 // Status returns the current status of the gateway.
 type Status int
@@ -95,11 +94,13 @@ func NewGateway(eventHandlerFunc EventHandlerFunc, closeHandlerFunc CloseHandler
 	config.Apply(opts)
 	config.Logger = config.Logger.With(slog.String("name", "voice_conn_gateway"))
 
-	return &gatewayImpl{
+	gateway := &gatewayImpl{
 		config:           *config,
 		eventHandlerFunc: eventHandlerFunc,
 		closeHandlerFunc: closeHandlerFunc,
 	}
+	gateway.lastSeq.Store(-1)
+	return gateway
 }
 
 type gatewayImpl struct {
@@ -119,6 +120,9 @@ type gatewayImpl struct {
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
 	lastNonce             int64
+	// lastSeq is the sequence of the last message received, it is acknowledged in heartbeats & resumes.
+	// It is written by the listen goroutine and read by the heartbeat goroutine.
+	lastSeq atomic.Int64
 }
 
 func (g *gatewayImpl) SSRC() uint32 {
@@ -181,10 +185,18 @@ func (g *gatewayImpl) CloseWithCode(code int, message string) {
 		// clear resume data as we closed gracefully
 		if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
 			g.ssrc = 0
+			g.lastSeq.Store(-1)
 		}
 	}
 }
 
+func (g *gatewayImpl) clearResumeData() {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	g.ssrc = 0
+	g.lastSeq.Store(-1)
+}
+
 func (g *gatewayImpl) heartbeat() {
 	g.heartbeatTicker = time.NewTicker(g.heartbeatInterval)
 	defer g.heartbeatTicker.Stop()
@@ -200,7 +212,10 @@ func (g *gatewayImpl) sendHeartbeat() {
 	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
 	defer cancel()
 
-	if err := g.Send(ctx, OpcodeHeartbeat, GatewayMessageDataHeartbeat(g.lastNonce)); err != nil {
+	if err := g.Send(ctx, OpcodeHeartbeat, GatewayMessageDataHeartbeat{
+		T:      g.lastNonce,
+		SeqAck: int(g.lastSeq.Load()),
+	}); err != nil {
 		if !errors.Is(err, ErrGatewayNotConnected) || errors.Is(err, syscall.EPIPE) {
 			return
 		}
@@ -228,12 +243,21 @@ loop:
 			}
 
 			reconnect := true
+			resume := true
 			var closeError *websocket.CloseError
 			if errors.As(err, &closeError) {
 				closeCode := GatewayCloseEventCodeByCode(closeError.Code)
 				reconnect = closeCode.Reconnect
+				// the session can't be resumed anymore, but we can identify a new one
+				if closeCode.Code == GatewayCloseEventCodeSessionNoLongerValid.Code || closeCode.Code == GatewayCloseEventCodeSessionTimeout.Code {
+					reconnect = true
+					resume = false
+				}
 			}
 			g.CloseWithCode(websocket.CloseServiceRestart, "listen error")
+			if !resume {
+				g.clearResumeData()
+			}
 			if g.config.AutoReconnect && reconnect {
 				go g.reconnect()
 			} else if g.closeHandlerFunc != nil {
@@ -247,6 +271,9 @@ loop:
 			g.config.Logger.Error("error while parsing voice gateway event", slog.String("err", err.Error()))
 			continue
 		}
+		if message.Seq != nil {
+			g.lastSeq.Store(int64(*message.Seq))
+		}
 
 		switch d := message.D.(type) {
 		case GatewayMessageDataHello:
@@ -257,6 +284,7 @@ loop:
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			if g.ssrc == 0 {
+				g.lastSeq.Store(-1)
 				g.status = StatusIdentifying
 				err = g.Send(ctx, OpcodeIdentify, GatewayMessageDataIdentify{
 					GuildID:   g.state.GuildID,
@@ -270,6 +298,7 @@ loop:
 					GuildID:   g.state.GuildID,
 					SessionID: g.state.SessionID,
 					Token:     g.state.Token,
+					SeqAck:    int(g.lastSeq.Load()),
 				})
 			}
 			cancel()
@@ -284,8 +313,9 @@ loop:
 			g.ssrc = d.SSRC
 
 		case GatewayMessageDataHeartbeatACK:
-			if int64(d) != g.lastNonce {
-				g.config.Logger.Error("received heartbeat ack with nonce", slog.Int64("nonce", int64(d)), slog.Int64("last_nonce", g.lastNonce))
+			if d.T != g.lastNonce {
+				g.config.Logger.Error("received heartbeat ack with nonce", slog.Int64("nonce", d.T), slog.Int64("last_nonce", g.lastNonce))
+				g.CloseWithCode(websocket.CloseServiceRestart, "heartbeat ack nonce mismatch")
 				go g.reconnect()
 				break loop
 			}
@@ -347,7 +377,7 @@ func (g *gatewayImpl) reconnectTry(ctx context.Context, try int) error {
 
 	g.config.Logger.Debug("reconnecting voice gateway")
 	if err := g.Open(ctx, g.state); err != nil {
-		if errors.Is(err, discord.ErrGatewayAlreadyConnected) {
+		if errors.Is(err, ErrGatewayAlreadyConnected) {
 			return err
 		}
 		g.config.Logger.Error("failed to reconnect voice gateway", slog.String("err", err.Error()))
@@ -7,15 +7,17 @@ import (
 
 // GatewayMessage represents a voice gateway message
 type GatewayMessage struct {
-	Op Opcode             `json:"op"`
-	D  GatewayMessageData `json:"d,omitempty"`
+	Op  Opcode             `json:"op"`
+	D   GatewayMessageData `json:"d,omitempty"`
+	Seq *int               `json:"seq,omitempty"`
 }
 
 // UnmarshalJSON unmarshalls the GatewayMessage from json
 func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
 	var v struct {
-		Op Opcode          `json:"op"`
-		D  json.RawMessage `json:"d"`
+		Op  Opcode          `json:"op"`
+		D   json.RawMessage `json:"d"`
+		Seq *int            `json:"seq"`
 	}
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
@@ -75,6 +77,16 @@ func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
 	case OpcodeResumed:
 		// no data
 
+	case OpcodeClientsConnect:
+		var d GatewayMessageDataClientsConnect
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeClientConnect:
+		var d GatewayMessageDataClientConnect
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
 	case OpcodeClientDisconnect:
 		var d GatewayMessageDataClientDisconnect
 		err = json.Unmarshal(v.D, &d)
@@ -93,6 +105,7 @@ func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
 	}
 	m.Op = v.Op
 	m.D = messageData
+	m.Seq = v.Seq
 	return nil
 }
 
@@ -125,13 +138,17 @@ type GatewayMessageDataHello struct {
 
 func (GatewayMessageDataHello) voiceGatewayMessageData() {}
 
-type GatewayMessageDataHeartbeat int64
+type GatewayMessageDataHeartbeat struct {
+	T int64 `json:"t"`
+	// SeqAck is the sequence of the last message received. It is used by the voice gateway to buffer messages for resuming
+	SeqAck int `json:"seq_ack"`
+}
 
 func (GatewayMessageDataHeartbeat) voiceGatewayMessageData() {}
 
 type GatewayMessageDataSessionDescription struct {
-	Mode      string   `json:"mode"`
-	SecretKey [32]byte `json:"secret_key"`
+	Mode      EncryptionMode `json:"mode"`
+	SecretKey [32]byte       `json:"secret_key"`
 }
 
 func (GatewayMessageDataSessionDescription) voiceGatewayMessageData() {}
@@ -155,16 +172,6 @@ type GatewayMessageDataSelectProtocolData struct {
 	Mode    EncryptionMode `json:"mode"`
 }
 
-// EncryptionMode is the encryption mode used for voice data.
-type EncryptionMode string
-
-// All possible EncryptionMode(s) https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-udp-connection-encryption-modes.
-const (
-	EncryptionModeNormal EncryptionMode = "xsalsa20_poly1305"
-	EncryptionModeSuffix EncryptionMode = "xsalsa20_poly1305_suffix"
-	EncryptionModeLite   EncryptionMode = "xsalsa20_poly1305_lite"
-)
-
 type GatewayMessageDataSpeaking struct {
 	Speaking SpeakingFlags `json:"speaking"`
 	Delay    int           `json:"delay"`
@@ -187,11 +194,15 @@ type GatewayMessageDataResume struct {
 	GuildID   snowflake.ID `json:"server_id"` // wtf is this?
 	SessionID string       `json:"session_id"`
 	Token     string       `json:"token"`
+	// SeqAck is the sequence of the last message received. Messages after it are replayed by the voice gateway
+	SeqAck int `json:"seq_ack"`
 }
 
 func (GatewayMessageDataResume) voiceGatewayMessageData() {}
 
-type GatewayMessageDataHeartbeatACK int64
+type GatewayMessageDataHeartbeatACK struct {
+	T int64 `json:"t"`
+}
 
 func (GatewayMessageDataHeartbeatACK) voiceGatewayMessageData() {}
 
@@ -203,6 +214,12 @@ type GatewayMessageDataClientConnect struct {
 
 func (GatewayMessageDataClientConnect) voiceGatewayMessageData() {}
 
+type GatewayMessageDataClientsConnect struct {
+	UserIDs []snowflake.ID `json:"user_ids"`
+}
+
+func (GatewayMessageDataClientsConnect) voiceGatewayMessageData() {}
+
 type GatewayMessageDataClientDisconnect struct {
 	UserID snowflake.ID `json:"user_id"`
 }
@@ -14,8 +14,8 @@ const (
 	OpcodeHello
 	OpcodeResumed
 	_
-	_
-	_
+	OpcodeClientsConnect
+	OpcodeClientConnect
 	OpcodeClientDisconnect
 	OpcodeGuildSync
 )
@@ -0,0 +1,310 @@
+package voice
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var _ OpusFrameReceiver = (*JitterBuffer)(nil)
+
+// LostOpusFrameReceiver can be implemented by an OpusFrameReceiver to get notified about lost packets by the JitterBuffer.
+// This can be used to run packet loss concealment or forward error correction.
+type LostOpusFrameReceiver interface {
+	// ReceiveLostOpusFrame is called for every lost packet in place of ReceiveOpusFrame.
+	// The Packet holds the expected sequence, timestamp & SSRC but no opus data.
+	ReceiveLostOpusFrame(userID snowflake.ID, packet *Packet) error
+}
+
+// JitterBufferStats holds the stats of a single SSRC of a JitterBuffer.
+type JitterBufferStats struct {
+	// Received is the number of packets received.
+	Received int
+	// Lost is the number of packets which never arrived.
+	Lost int
+	// Late is the number of packets which arrived after they were considered lost.
+	Late int
+	// Duplicates is the number of packets which were received more than once.
+	Duplicates int
+	// Reordered is the number of packets which arrived out of order but in time.
+	Reordered int
+}
+
+// NewJitterBuffer returns a new JitterBuffer passing the reordered packets to the given OpusFrameReceiver.
+func NewJitterBuffer(receiver OpusFrameReceiver, opts ...JitterBufferConfigOpt) *JitterBuffer {
+	config := DefaultJitterBufferConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "voice_jitter_buffer"))
+
+	b := &JitterBuffer{
+		config:   *config,
+		receiver: receiver,
+		streams:  map[uint32]*jitterStream{},
+		closeCh:  make(chan struct{}),
+	}
+	go b.flushIdle()
+	return b
+}
+
+// JitterBuffer is an OpusFrameReceiver which buffers the packets of every SSRC, reorders them by their RTP sequence & timestamp,
+// drops duplicates & reports lost packets before passing them to the wrapped OpusFrameReceiver.
+// Buffered packets of speakers who stopped talking are flushed after JitterBufferConfig.IdleTimeout.
+type JitterBuffer struct {
+	config   JitterBufferConfig
+	receiver OpusFrameReceiver
+
+	streams map[uint32]*jitterStream
+	mu      sync.Mutex
+
+	// dispatchMu keeps the order of the frames passed to the wrapped OpusFrameReceiver.
+	// It is locked before mu is released, so the wrapped OpusFrameReceiver is never called while holding mu.
+	dispatchMu sync.Mutex
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+type jitterStream struct {
+	userID snowflake.ID
+	ssrc   uint32
+
+	started bool
+	// buffering is true until the stream buffered JitterBufferConfig.Depth packets after it started.
+	// Packets which arrive before the first packet in this time are not late.
+	buffering     bool
+	nextSequence  uint16
+	lastTimestamp uint32
+	packets       map[uint16]*Packet
+	lastActivity  time.Time
+
+	stats JitterBufferStats
+}
+
+// jitterFrame is a packet which is passed to the wrapped OpusFrameReceiver after mu was released.
+type jitterFrame struct {
+	userID snowflake.ID
+	packet *Packet
+	lost   bool
+}
+
+// ReceiveOpusFrame buffers the Packet & passes all packets which are in order to the wrapped OpusFrameReceiver.
+func (b *JitterBuffer) ReceiveOpusFrame(userID snowflake.ID, packet *Packet) error {
+	b.mu.Lock()
+	frames := b.receive(userID, packet)
+	return b.dispatch(frames)
+}
+
+// receive buffers the Packet & returns the frames which are in order. b.mu must be held.
+func (b *JitterBuffer) receive(userID snowflake.ID, packet *Packet) []jitterFrame {
+	stream, ok := b.streams[packet.SSRC]
+	if !ok {
+		stream = &jitterStream{
+			ssrc:    packet.SSRC,
+			packets: map[uint16]*Packet{},
+		}
+		b.streams[packet.SSRC] = stream
+	}
+	stream.userID = userID
+	stream.lastActivity = time.Now()
+	stream.stats.Received++
+
+	if !stream.started {
+		b.start(stream, packet)
+	}
+
+	// int16 & int32 arithmetic handles the wraparound of the sequence & timestamp
+	diff := int16(packet.Sequence - stream.nextSequence)
+	timestampDiff := int32(packet.Timestamp - (stream.lastTimestamp + uint32(OpusFrameSize)))
+	var frames []jitterFrame
+	switch {
+	case diff < 0 && stream.buffering && int(-diff) < b.depth() && timestampDiff < 0:
+		// the packet was sent before the first packet of the stream, nothing was passed on yet
+		stream.nextSequence = packet.Sequence
+		stream.lastTimestamp = packet.Timestamp - uint32(OpusFrameSize)
+		stream.stats.Reordered++
+	case diff < 0 && timestampDiff < 0:
+		stream.stats.Late++
+		return nil
+	case int(diff) >= b.depth()*4 || (diff < 0) != (timestampDiff < 0):
+		// the sender jumped ahead or restarted its sequence, flush what we have and start over from this packet
+		b.config.Logger.Debug("sequence jump in voice stream", slog.Uint64("ssrc", uint64(packet.SSRC)), slog.Int("diff", int(diff)), slog.Int("timestamp_diff", int(timestampDiff)))
+		frames = b.flush(stream)
+		b.start(stream, packet)
+	case diff > 0:
+		stream.stats.Reordered++
+	}
+
+	if _, ok = stream.packets[packet.Sequence]; ok {
+		stream.stats.Duplicates++
+		return frames
+	}
+	stream.packets[packet.Sequence] = packet
+
+	return b.drain(frames, stream, false)
+}
+
+func (b *JitterBuffer) start(stream *jitterStream, packet *Packet) {
+	stream.started = true
+	stream.buffering = true
+	stream.nextSequence = packet.Sequence
+	stream.lastTimestamp = packet.Timestamp - uint32(OpusFrameSize)
+}
+
+// dispatch passes the frames to the wrapped OpusFrameReceiver. b.mu must be held and is released.
+func (b *JitterBuffer) dispatch(frames []jitterFrame) error {
+	if len(frames) == 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	b.dispatchMu.Lock()
+	b.mu.Unlock()
+	defer b.dispatchMu.Unlock()
+
+	for _, frame := range frames {
+		var err error
+		if frame.lost {
+			err = b.receiveLost(frame.userID, frame.packet)
+		} else {
+			err = b.receiver.ReceiveOpusFrame(frame.userID, frame.packet)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drain appends all packets which are in order to frames.
+// Missing packets are reported as lost once more than JitterBufferConfig.Depth packets are buffered or force is true.
+func (b *JitterBuffer) drain(frames []jitterFrame, stream *jitterStream, force bool) []jitterFrame {
+	if stream.buffering {
+		if !force && len(stream.packets) < b.depth() {
+			return frames
+		}
+		stream.buffering = false
+	}
+	for len(stream.packets) > 0 {
+		packet, ok := stream.packets[stream.nextSequence]
+		if !ok {
+			if !force && len(stream.packets) <= b.depth() {
+				return frames
+			}
+			frames = append(frames, b.lost(stream))
+			continue
+		}
+		delete(stream.packets, stream.nextSequence)
+		stream.nextSequence++
+		stream.lastTimestamp = packet.Timestamp
+		frames = append(frames, jitterFrame{userID: stream.userID, packet: packet})
+	}
+	return frames
+}
+
+func (b *JitterBuffer) lost(stream *jitterStream) jitterFrame {
+	stream.stats.Lost++
+	stream.lastTimestamp += uint32(OpusFrameSize)
+	packet := &Packet{
+		Sequence:  stream.nextSequence,
+		Timestamp: stream.lastTimestamp,
+		SSRC:      stream.ssrc,
+	}
+	stream.nextSequence++
+	return jitterFrame{userID: stream.userID, packet: packet, lost: true}
+}
+
+func (b *JitterBuffer) receiveLost(userID snowflake.ID, packet *Packet) error {
+	if lostReceiver, ok := b.receiver.(LostOpusFrameReceiver); ok {
+		return lostReceiver.ReceiveLostOpusFrame(userID, packet)
+	}
+	if b.config.InsertSilence {
+		packet.Opus = SilenceAudioFrame
+		return b.receiver.ReceiveOpusFrame(userID, packet)
+	}
+	return nil
+}
+
+// flush returns all buffered packets of the stream & resets it, so the silence until the next packet is not reported as loss.
+func (b *JitterBuffer) flush(stream *jitterStream) []jitterFrame {
+	frames := b.drain(nil, stream, true)
+	stream.started = false
+	return frames
+}
+
+func (b *JitterBuffer) depth() int {
+	if b.config.Depth < 1 {
+		return 1
+	}
+	return b.config.Depth
+}
+
+func (b *JitterBuffer) flushIdle() {
+	interval := b.config.IdleTimeout / 2
+	if interval <= 0 {
+		interval = time.Duration(OpusFrameSizeMs) * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.closeCh:
+			return
+		case now := <-ticker.C:
+			b.mu.Lock()
+			var frames []jitterFrame
+			for _, stream := range b.streams {
+				if !stream.started || now.Sub(stream.lastActivity) < b.config.IdleTimeout {
+					continue
+				}
+				frames = append(frames, b.flush(stream)...)
+			}
+			if err := b.dispatch(frames); err != nil {
+				b.config.Logger.Error("error while flushing idle voice streams", slog.String("err", err.Error()))
+			}
+		}
+	}
+}
+
+// Stats returns the JitterBufferStats of the given SSRC.
+func (b *JitterBuffer) Stats(ssrc uint32) JitterBufferStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stream, ok := b.streams[ssrc]; ok {
+		return stream.stats
+	}
+	return JitterBufferStats{}
+}
+
+// CleanupUser flushes & removes the buffered packets of the user & passes the call to the wrapped OpusFrameReceiver.
+func (b *JitterBuffer) CleanupUser(userID snowflake.ID) {
+	b.mu.Lock()
+	var frames []jitterFrame
+	for ssrc, stream := range b.streams {
+		if stream.userID != userID {
+			continue
+		}
+		frames = append(frames, b.flush(stream)...)
+		delete(b.streams, ssrc)
+	}
+	if err := b.dispatch(frames); err != nil {
+		b.config.Logger.Error("error while flushing voice stream", slog.String("user_id", userID.String()), slog.String("err", err.Error()))
+	}
+	b.receiver.CleanupUser(userID)
+}
+
+// Close flushes all buffered packets & closes the wrapped OpusFrameReceiver.
+func (b *JitterBuffer) Close() {
+	b.closeOnce.Do(func() {
+		close(b.closeCh)
+	})
+	b.mu.Lock()
+	var frames []jitterFrame
+	for ssrc, stream := range b.streams {
+		frames = append(frames, b.flush(stream)...)
+		delete(b.streams, ssrc)
+	}
+	_ = b.dispatch(frames)
+	b.receiver.Close()
+}
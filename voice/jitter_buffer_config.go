@@ -0,0 +1,69 @@
+package voice
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultJitterBufferConfig returns a JitterBufferConfig with sensible defaults.
+func DefaultJitterBufferConfig() *JitterBufferConfig {
+	return &JitterBufferConfig{
+		Logger:        slog.Default(),
+		Depth:         5,
+		IdleTimeout:   200 * time.Millisecond,
+		InsertSilence: true,
+	}
+}
+
+// JitterBufferConfig is used to configure a JitterBuffer.
+type JitterBufferConfig struct {
+	Logger *slog.Logger
+
+	// Depth is the number of packets which are buffered per SSRC before a missing packet is considered lost.
+	// Every packet of depth adds 20ms of latency.
+	Depth int
+
+	// IdleTimeout is the time after which the buffered packets of a speaker who stopped sending packets are flushed.
+	IdleTimeout time.Duration
+
+	// InsertSilence passes a SilenceAudioFrame for every lost packet to OpusFrameReceiver(s) which do not implement LostOpusFrameReceiver.
+	InsertSilence bool
+}
+
+// JitterBufferConfigOpt is used to functionally configure a JitterBufferConfig.
+type JitterBufferConfigOpt func(config *JitterBufferConfig)
+
+// Apply applies the JitterBufferConfigOpt(s) to the JitterBufferConfig.
+func (c *JitterBufferConfig) Apply(opts []JitterBufferConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithJitterBufferLogger sets the JitterBuffer(s) used Logger.
+func WithJitterBufferLogger(logger *slog.Logger) JitterBufferConfigOpt {
+	return func(config *JitterBufferConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithJitterBufferDepth sets the number of packets the JitterBuffer buffers per SSRC.
+func WithJitterBufferDepth(depth int) JitterBufferConfigOpt {
+	return func(config *JitterBufferConfig) {
+		config.Depth = depth
+	}
+}
+
+// WithJitterBufferIdleTimeout sets the time after which the JitterBuffer flushes the packets of an idle speaker.
+func WithJitterBufferIdleTimeout(idleTimeout time.Duration) JitterBufferConfigOpt {
+	return func(config *JitterBufferConfig) {
+		config.IdleTimeout = idleTimeout
+	}
+}
+
+// WithJitterBufferInsertSilence sets whether the JitterBuffer inserts silence for lost packets.
+func WithJitterBufferInsertSilence(insertSilence bool) JitterBufferConfigOpt {
+	return func(config *JitterBufferConfig) {
+		config.InsertSilence = insertSilence
+	}
+}
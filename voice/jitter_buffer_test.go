@@ -0,0 +1,140 @@
+package voice
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingReceiver records the sequences of the received & lost packets. Lost packets are recorded as negative sequences.
+type recordingReceiver struct {
+	mu        sync.Mutex
+	sequences []int
+	// onReceive is called for every received packet
+	onReceive func()
+}
+
+func (r *recordingReceiver) ReceiveOpusFrame(_ snowflake.ID, packet *Packet) error {
+	if r.onReceive != nil {
+		r.onReceive()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sequences = append(r.sequences, int(packet.Sequence))
+	return nil
+}
+
+func (r *recordingReceiver) ReceiveLostOpusFrame(_ snowflake.ID, packet *Packet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sequences = append(r.sequences, -int(packet.Sequence))
+	return nil
+}
+
+func (r *recordingReceiver) CleanupUser(snowflake.ID) {}
+func (r *recordingReceiver) Close()                   {}
+
+func (r *recordingReceiver) received() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.sequences...)
+}
+
+func testPacket(sequence uint16) *Packet {
+	return &Packet{
+		Sequence:  sequence,
+		Timestamp: uint32(sequence) * uint32(OpusFrameSize),
+		SSRC:      1,
+		Opus:      []byte{byte(sequence)},
+	}
+}
+
+func receivePackets(t *testing.T, b *JitterBuffer, sequences ...uint16) {
+	t.Helper()
+	for _, sequence := range sequences {
+		require.NoError(t, b.ReceiveOpusFrame(1, testPacket(sequence)))
+	}
+}
+
+func TestJitterBufferReorder(t *testing.T) {
+	receiver := &recordingReceiver{}
+	b := NewJitterBuffer(receiver, WithJitterBufferDepth(3), WithJitterBufferIdleTimeout(time.Hour))
+	defer b.Close()
+
+	// 9 arrives after the first packet of the stream but before anything was passed on
+	receivePackets(t, b, 10, 9, 12, 11, 13, 14, 15)
+	assert.Equal(t, []int{9, 10, 11, 12, 13, 14, 15}, receiver.received())
+
+	stats := b.Stats(1)
+	assert.Equal(t, 7, stats.Received)
+	assert.Zero(t, stats.Late, "the first out of order packet should not be late")
+	assert.Equal(t, 2, stats.Reordered)
+}
+
+func TestJitterBufferWraparound(t *testing.T) {
+	receiver := &recordingReceiver{}
+	b := NewJitterBuffer(receiver, WithJitterBufferDepth(2), WithJitterBufferIdleTimeout(time.Hour))
+	defer b.Close()
+
+	receivePackets(t, b, 65534, 65535, 1, 0, 2, 3)
+	assert.Equal(t, []int{65534, 65535, 0, 1, 2, 3}, receiver.received())
+	assert.Zero(t, b.Stats(1).Lost)
+}
+
+func TestJitterBufferLoss(t *testing.T) {
+	receiver := &recordingReceiver{}
+	b := NewJitterBuffer(receiver, WithJitterBufferDepth(2), WithJitterBufferIdleTimeout(time.Hour))
+	defer b.Close()
+
+	receivePackets(t, b, 1, 2, 4, 5, 6)
+	// 3 arrives after it was reported as lost
+	receivePackets(t, b, 3)
+
+	assert.Equal(t, []int{1, 2, -3, 4, 5, 6}, receiver.received())
+	stats := b.Stats(1)
+	assert.Equal(t, 1, stats.Lost)
+	assert.Equal(t, 1, stats.Late)
+}
+
+func TestJitterBufferSequenceRestart(t *testing.T) {
+	receiver := &recordingReceiver{}
+	b := NewJitterBuffer(receiver, WithJitterBufferDepth(1), WithJitterBufferIdleTimeout(time.Hour))
+	defer b.Close()
+
+	receivePackets(t, b, 100, 101, 102)
+	// the sender restarted its sequence but the timestamp moved on, this is not a late packet
+	require.NoError(t, b.ReceiveOpusFrame(1, &Packet{Sequence: 5, Timestamp: 103 * uint32(OpusFrameSize), SSRC: 1}))
+
+	assert.Equal(t, []int{100, 101, 102, 5}, receiver.received())
+	assert.Zero(t, b.Stats(1).Late)
+}
+
+func TestJitterBufferIdleFlush(t *testing.T) {
+	receiver := &recordingReceiver{}
+	b := NewJitterBuffer(receiver, WithJitterBufferDepth(5), WithJitterBufferIdleTimeout(50*time.Millisecond))
+	defer b.Close()
+
+	// the receiver may call back into the JitterBuffer as it is not called while holding its lock
+	receiver.onReceive = func() {
+		_ = b.Stats(1)
+	}
+
+	receivePackets(t, b, 1, 3)
+	assert.Empty(t, receiver.received(), "the packets should be buffered until the buffer is full")
+
+	require.Eventually(t, func() bool {
+		return len(receiver.received()) == 3
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int{1, -2, 3}, receiver.received())
+
+	// the silence after the flush is not loss
+	receivePackets(t, b, 20)
+	require.Eventually(t, func() bool {
+		return len(receiver.received()) == 4
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 1, b.Stats(1).Lost)
+}
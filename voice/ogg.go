@@ -0,0 +1,607 @@
+package voice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+	// OpusSampleRate is the sample rate used by discord & the granule positions of Ogg Opus streams.
+	OpusSampleRate = 48000
+
+	oggPageHeaderSize = 27
+	oggMaxSegments    = 255
+	oggMaxSegmentSize = 255
+
+	oggHeaderTypeContinued = 0x01
+	oggHeaderTypeBOS       = 0x02
+	oggHeaderTypeEOS       = 0x04
+
+	// oggPagePackets is the number of opus packets written into a single page (1 second of audio).
+	oggPagePackets = 50
+)
+
+var (
+	// ErrInvalidOggPage is returned when an Ogg page could not be parsed.
+	ErrInvalidOggPage = errors.New("invalid ogg page")
+
+	// ErrInvalidOggChecksum is returned when the checksum of an Ogg page does not match its content.
+	ErrInvalidOggChecksum = errors.New("invalid ogg page checksum")
+
+	// ErrNotOpusStream is returned when the Ogg stream does not contain opus audio.
+	ErrNotOpusStream = errors.New("ogg stream is not an opus stream")
+)
+
+var (
+	oggCapturePattern = [4]byte{'O', 'g', 'g', 'S'}
+	opusHeadMagic     = []byte("OpusHead")
+	opusTagsMagic     = []byte("OpusTags")
+
+	oggCRCTable = func() [256]uint32 {
+		var table [256]uint32
+		for i := range table {
+			r := uint32(i) << 24
+			for j := 0; j < 8; j++ {
+				if r&0x80000000 != 0 {
+					r = (r << 1) ^ 0x04c11db7
+				} else {
+					r <<= 1
+				}
+			}
+			table[i] = r
+		}
+		return table
+	}()
+)
+
+func oggCRC(crc uint32, p []byte) uint32 {
+	for _, b := range p {
+		crc = (crc << 8) ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+// oggPage is a single page of an Ogg stream.
+type oggPage struct {
+	headerType  byte
+	granule     uint64
+	serial      uint32
+	sequence    uint32
+	segments    []byte
+	payload     []byte
+	headerBytes [oggPageHeaderSize + oggMaxSegments]byte
+}
+
+// NewOggReader returns a new OggReader reading opus packets from the given Ogg Opus stream.
+func NewOggReader(r io.Reader) *OggReader {
+	return &OggReader{
+		r: r,
+	}
+}
+
+// OggReader is an OpusFrameProvider that demuxes opus packets from an Ogg Opus stream (.opus/.ogg files).
+// Only the first logical opus stream is read, the OpusHead & OpusTags headers are skipped.
+// The stream should be encoded with 20ms frames at 48kHz as discord expects one frame per 20ms.
+type OggReader struct {
+	r io.Reader
+
+	page       oggPage
+	serial     uint32
+	hasSerial  bool
+	headers    int
+	segment    int
+	packetBuff []byte
+
+	// granule is the granule position of the last fully read page
+	granule uint64
+	// channels is the channel count from the OpusHead
+	channels int
+	// preSkip is the pre-skip from the OpusHead
+	preSkip uint16
+
+	// offset is the number of bytes read from r
+	offset int64
+	// pageFunc is called for every page of the logical stream with its offset in the stream
+	pageFunc func(offset int64, page *oggPage)
+}
+
+// Channels returns the channel count of the stream. It is available after the first packet was read.
+func (o *OggReader) Channels() int {
+	return o.channels
+}
+
+// PreSkip returns the number of samples which should be skipped at the start of the stream. It is available after the first packet was read.
+func (o *OggReader) PreSkip() uint16 {
+	return o.preSkip
+}
+
+// Granule returns the granule position (number of 48kHz samples) at the end of the last fully read page.
+func (o *OggReader) Granule() uint64 {
+	return o.granule
+}
+
+// ProvideOpusFrame returns the next opus packet of the stream or io.EOF once the stream ended.
+func (o *OggReader) ProvideOpusFrame() ([]byte, error) {
+	for {
+		packet, err := o.ReadPacket()
+		if err != nil {
+			return nil, err
+		}
+		if o.headers < 2 {
+			if err = o.readHeader(packet); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return packet, nil
+	}
+}
+
+// Close is a no-op.
+func (*OggReader) Close() {}
+
+func (o *OggReader) readHeader(packet []byte) error {
+	switch o.headers {
+	case 0:
+		if len(packet) < 19 || !bytes.Equal(packet[:8], opusHeadMagic) {
+			return ErrNotOpusStream
+		}
+		o.channels = int(packet[9])
+		o.preSkip = binary.LittleEndian.Uint16(packet[10:12])
+	case 1:
+		if !bytes.HasPrefix(packet, opusTagsMagic) {
+			return ErrNotOpusStream
+		}
+	}
+	o.headers++
+	return nil
+}
+
+// ReadPacket returns the next raw packet of the logical stream including the OpusHead & OpusTags headers.
+// Packets spanning multiple segments or pages are joined.
+func (o *OggReader) ReadPacket() ([]byte, error) {
+	o.packetBuff = o.packetBuff[:0]
+	for {
+		if o.segment >= len(o.page.segments) {
+			if err := o.readPage(); err != nil {
+				if errors.Is(err, io.EOF) && len(o.packetBuff) > 0 {
+					return nil, io.ErrUnexpectedEOF
+				}
+				return nil, err
+			}
+			continue
+		}
+
+		var offset int
+		for _, size := range o.page.segments[:o.segment] {
+			offset += int(size)
+		}
+		size := int(o.page.segments[o.segment])
+		o.packetBuff = append(o.packetBuff, o.page.payload[offset:offset+size]...)
+		o.segment++
+
+		// a lacing value smaller than 255 terminates the packet
+		if size < oggMaxSegmentSize {
+			if o.segment >= len(o.page.segments) && o.page.granule != ^uint64(0) {
+				o.granule = o.page.granule
+			}
+			packet := make([]byte, len(o.packetBuff))
+			copy(packet, o.packetBuff)
+			return packet, nil
+		}
+	}
+}
+
+// reset resets the OggReader to continue reading at the page starting at the given offset of the underlying io.Reader.
+// If keepHeaders is true, the OpusHead & OpusTags headers are expected to be already read.
+func (o *OggReader) reset(offset int64, keepHeaders bool) {
+	o.offset = offset
+	o.page.segments = nil
+	o.segment = 0
+	o.packetBuff = o.packetBuff[:0]
+	if !keepHeaders {
+		o.headers = 0
+		o.hasSerial = false
+		o.granule = 0
+	}
+}
+
+func (o *OggReader) readFull(p []byte) (int, error) {
+	n, err := io.ReadFull(o.r, p)
+	o.offset += int64(n)
+	return n, err
+}
+
+func (o *OggReader) readPage() error {
+	for {
+		pageOffset := o.offset
+		header := o.page.headerBytes[:oggPageHeaderSize]
+		if _, err := o.readFull(header); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("%w: truncated page header", ErrInvalidOggPage)
+			}
+			return err
+		}
+		if !bytes.Equal(header[:4], oggCapturePattern[:]) || header[4] != 0 {
+			return fmt.Errorf("%w: invalid capture pattern or version", ErrInvalidOggPage)
+		}
+
+		o.page.headerType = header[5]
+		o.page.granule = binary.LittleEndian.Uint64(header[6:14])
+		o.page.serial = binary.LittleEndian.Uint32(header[14:18])
+		o.page.sequence = binary.LittleEndian.Uint32(header[18:22])
+		checksum := binary.LittleEndian.Uint32(header[22:26])
+
+		segmentCount := int(header[26])
+		segments := o.page.headerBytes[oggPageHeaderSize : oggPageHeaderSize+segmentCount]
+		if _, err := o.readFull(segments); err != nil {
+			return fmt.Errorf("%w: truncated segment table", ErrInvalidOggPage)
+		}
+		o.page.segments = segments
+
+		var payloadSize int
+		for _, size := range segments {
+			payloadSize += int(size)
+		}
+		if cap(o.page.payload) < payloadSize {
+			o.page.payload = make([]byte, payloadSize)
+		}
+		o.page.payload = o.page.payload[:payloadSize]
+		if _, err := o.readFull(o.page.payload); err != nil {
+			return fmt.Errorf("%w: truncated payload", ErrInvalidOggPage)
+		}
+
+		// the checksum is calculated with the checksum field set to 0
+		clear(header[22:26])
+		crc := oggCRC(0, header)
+		crc = oggCRC(crc, segments)
+		crc = oggCRC(crc, o.page.payload)
+		if crc != checksum {
+			return ErrInvalidOggChecksum
+		}
+
+		if !o.hasSerial {
+			if o.page.headerType&oggHeaderTypeBOS == 0 {
+				return fmt.Errorf("%w: stream does not start with a beginning of stream page", ErrInvalidOggPage)
+			}
+			o.serial = o.page.serial
+			o.hasSerial = true
+		} else if o.page.serial != o.serial {
+			// skip pages of other multiplexed logical streams
+			continue
+		}
+
+		if o.pageFunc != nil {
+			o.pageFunc(pageOffset, &o.page)
+		}
+
+		o.segment = 0
+		// drop the continued part of a packet if we never saw its start
+		if o.page.headerType&oggHeaderTypeContinued != 0 && len(o.packetBuff) == 0 {
+			for o.segment < len(segments) {
+				size := segments[o.segment]
+				o.segment++
+				if size < oggMaxSegmentSize {
+					break
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// NewOggWriter returns a new OggWriter writing an Ogg Opus stream with the given channel count to the given io.Writer.
+// The OpusHead & OpusTags headers are written with the first packet.
+func NewOggWriter(w io.Writer, serial uint32, channels int) *OggWriter {
+	return &OggWriter{
+		w:        w,
+		serial:   serial,
+		channels: channels,
+	}
+}
+
+// OggWriter writes opus packets into an Ogg Opus stream which can be played by any player supporting .opus files.
+type OggWriter struct {
+	w        io.Writer
+	serial   uint32
+	channels int
+
+	sequence      uint32
+	granule       uint64
+	wroteHeaders  bool
+	closed        bool
+	packets       []oggPacket
+	pageBuff      []byte
+	lastTimestamp uint32
+	hasTimestamp  bool
+}
+
+type oggPacket struct {
+	data []byte
+	// granule is the granule position at the end of the packet
+	granule uint64
+}
+
+// Granule returns the current granule position (number of 48kHz samples) of the stream.
+func (o *OggWriter) Granule() uint64 {
+	return o.granule
+}
+
+// WritePacket writes an opus packet with the given duration in 48kHz samples.
+func (o *OggWriter) WritePacket(packet []byte, samples int) error {
+	if o.closed {
+		return io.ErrClosedPipe
+	}
+	if !o.wroteHeaders {
+		if err := o.writeHeaders(); err != nil {
+			return err
+		}
+	}
+	o.granule += uint64(samples)
+	o.packets = append(o.packets, oggPacket{
+		data:    append([]byte(nil), packet...),
+		granule: o.granule,
+	})
+
+	if len(o.packets) >= oggPagePackets || o.segmentCount() >= oggMaxSegments {
+		return o.flush(0)
+	}
+	return nil
+}
+
+// WriteRTPPacket writes the opus frame of the Packet. Gaps between the RTP timestamps of the packets are filled with silence,
+// so the stream keeps the timing of the original audio.
+func (o *OggWriter) WriteRTPPacket(packet *Packet) error {
+	if o.hasTimestamp {
+		// RTP timestamps wrap around, uint32 arithmetic handles this
+		gap := packet.Timestamp - o.lastTimestamp
+		if gap > uint32(OpusFrameSize) && gap < uint32(OpusSampleRate*60*60) {
+			for missing := int(gap)/OpusFrameSize - 1; missing > 0; missing-- {
+				if err := o.WritePacket(SilenceAudioFrame, OpusFrameSize); err != nil {
+					return err
+				}
+			}
+		} else if gap == 0 || gap > 1<<31 {
+			// duplicate or late packet, the gap for it was already filled with silence
+			return nil
+		}
+	}
+	o.lastTimestamp = packet.Timestamp
+	o.hasTimestamp = true
+	return o.WritePacket(packet.Opus, OpusFrameSize)
+}
+
+// Flush writes all buffered packets as a page.
+func (o *OggWriter) Flush() error {
+	if len(o.packets) == 0 {
+		return nil
+	}
+	return o.flush(0)
+}
+
+// Close flushes the buffered packets & ends the stream. It does not close the underlying io.Writer.
+func (o *OggWriter) Close() error {
+	if o.closed {
+		return nil
+	}
+	if !o.wroteHeaders {
+		if err := o.writeHeaders(); err != nil {
+			return err
+		}
+	}
+	o.closed = true
+	return o.flush(oggHeaderTypeEOS)
+}
+
+func (o *OggWriter) writeHeaders() error {
+	head := make([]byte, 19)
+	copy(head, opusHeadMagic)
+	head[8] = 1 // version
+	head[9] = byte(o.channels)
+	binary.LittleEndian.PutUint16(head[10:12], 0) // pre-skip
+	binary.LittleEndian.PutUint32(head[12:16], OpusSampleRate)
+	binary.LittleEndian.PutUint16(head[16:18], 0) // output gain
+	head[18] = 0                                  // channel mapping family
+
+	o.packets = []oggPacket{{data: head}}
+	if err := o.flush(oggHeaderTypeBOS); err != nil {
+		return err
+	}
+
+	vendor := "disgo"
+	tags := make([]byte, 8+4+len(vendor)+4)
+	copy(tags, opusTagsMagic)
+	binary.LittleEndian.PutUint32(tags[8:12], uint32(len(vendor)))
+	copy(tags[12:], vendor)
+	binary.LittleEndian.PutUint32(tags[12+len(vendor):], 0) // user comment list length
+
+	o.packets = []oggPacket{{data: tags}}
+	if err := o.flush(0); err != nil {
+		return err
+	}
+	o.wroteHeaders = true
+	return nil
+}
+
+func (o *OggWriter) segmentCount() int {
+	var count int
+	for _, packet := range o.packets {
+		count += len(packet.data)/oggMaxSegmentSize + 1
+	}
+	return count
+}
+
+// flush writes the buffered packets as one or more pages.
+func (o *OggWriter) flush(headerType byte) error {
+	var (
+		segments  []byte
+		payload   []byte
+		continued bool
+		// granule is the granule position of the last packet which ended on the current page, -1 if none did
+		granule = ^uint64(0)
+	)
+	writePage := func(last bool) error {
+		pageType := byte(0)
+		if continued {
+			pageType |= oggHeaderTypeContinued
+		}
+		if last {
+			pageType |= headerType
+			if granule == ^uint64(0) {
+				granule = o.granule
+			}
+		}
+		if err := o.writePage(pageType, granule, segments, payload); err != nil {
+			return err
+		}
+		segments = segments[:0]
+		payload = payload[:0]
+		granule = ^uint64(0)
+		return nil
+	}
+
+	for i, packet := range o.packets {
+		remaining := packet.data
+		for {
+			size := len(remaining)
+			if size > oggMaxSegmentSize {
+				size = oggMaxSegmentSize
+			}
+			segments = append(segments, byte(size))
+			payload = append(payload, remaining[:size]...)
+			remaining = remaining[size:]
+
+			// a lacing value smaller than 255 terminates the packet
+			done := size < oggMaxSegmentSize
+			if done {
+				granule = packet.granule
+			}
+			if len(segments) == oggMaxSegments && !(done && i == len(o.packets)-1) {
+				if err := writePage(false); err != nil {
+					return err
+				}
+				continued = !done
+			}
+			if done {
+				break
+			}
+		}
+	}
+	o.packets = o.packets[:0]
+	if len(segments) > 0 || headerType&oggHeaderTypeEOS != 0 {
+		return writePage(true)
+	}
+	return nil
+}
+
+func (o *OggWriter) writePage(headerType byte, granule uint64, segments []byte, payload []byte) error {
+	size := oggPageHeaderSize + len(segments) + len(payload)
+	if cap(o.pageBuff) < size {
+		o.pageBuff = make([]byte, size)
+	}
+	page := o.pageBuff[:size]
+
+	copy(page[0:4], oggCapturePattern[:])
+	page[4] = 0 // version
+	page[5] = headerType
+	binary.LittleEndian.PutUint64(page[6:14], granule)
+	binary.LittleEndian.PutUint32(page[14:18], o.serial)
+	binary.LittleEndian.PutUint32(page[18:22], o.sequence)
+	clear(page[22:26])
+	page[26] = byte(len(segments))
+	copy(page[oggPageHeaderSize:], segments)
+	copy(page[oggPageHeaderSize+len(segments):], payload)
+	binary.LittleEndian.PutUint32(page[22:26], oggCRC(0, page))
+
+	o.sequence++
+	if _, err := o.w.Write(page); err != nil {
+		return fmt.Errorf("error while writing ogg page: %w", err)
+	}
+	return nil
+}
+
+// OggWriterCreateFunc is used to create the io.WriteCloser for the Ogg Opus stream of a user.
+type OggWriterCreateFunc func(userID snowflake.ID) (io.WriteCloser, error)
+
+// NewOggOpusReceiver returns a new OggOpusReceiver writing the audio of every user into its own Ogg Opus stream created by the given OggWriterCreateFunc.
+func NewOggOpusReceiver(createFunc OggWriterCreateFunc, userFilter UserFilterFunc, opts ...OggOpusReceiverConfigOpt) *OggOpusReceiver {
+	config := DefaultOggOpusReceiverConfig()
+	config.Apply(opts)
+
+	return &OggOpusReceiver{
+		config:     *config,
+		createFunc: createFunc,
+		userFilter: userFilter,
+		writers:    map[snowflake.ID]*oggUserWriter{},
+	}
+}
+
+// OggOpusReceiver is an OpusFrameReceiver which writes the audio of every user into a separate Ogg Opus stream.
+// Silence between the packets of a user is kept by inserting silent frames. The streams are closed in CleanupUser & Close.
+type OggOpusReceiver struct {
+	config     OggOpusReceiverConfig
+	createFunc OggWriterCreateFunc
+	userFilter UserFilterFunc
+
+	writers map[snowflake.ID]*oggUserWriter
+	mu      sync.Mutex
+}
+
+type oggUserWriter struct {
+	*OggWriter
+	closer io.Closer
+}
+
+// ReceiveOpusFrame writes the opus frame of the Packet into the Ogg Opus stream of the user.
+func (r *OggOpusReceiver) ReceiveOpusFrame(userID snowflake.ID, packet *Packet) error {
+	if r.userFilter != nil && !r.userFilter(userID) {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	writer, ok := r.writers[userID]
+	if !ok {
+		w, err := r.createFunc(userID)
+		if err != nil {
+			return fmt.Errorf("error while creating ogg writer for user %s: %w", userID, err)
+		}
+		writer = &oggUserWriter{
+			OggWriter: NewOggWriter(w, packet.SSRC, r.config.Channels),
+			closer:    w,
+		}
+		r.writers[userID] = writer
+	}
+	return writer.WriteRTPPacket(packet)
+}
+
+// CleanupUser ends & closes the Ogg Opus stream of the user.
+func (r *OggOpusReceiver) CleanupUser(userID snowflake.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if writer, ok := r.writers[userID]; ok {
+		writer.close()
+		delete(r.writers, userID)
+	}
+}
+
+// Close ends & closes the Ogg Opus streams of all users.
+func (r *OggOpusReceiver) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for userID, writer := range r.writers {
+		writer.close()
+		delete(r.writers, userID)
+	}
+}
+
+func (w *oggUserWriter) close() {
+	_ = w.OggWriter.Close()
+	_ = w.closer.Close()
+}
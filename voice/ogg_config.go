@@ -0,0 +1,31 @@
+package voice
+
+// DefaultOggOpusReceiverConfig returns an OggOpusReceiverConfig with sensible defaults.
+func DefaultOggOpusReceiverConfig() *OggOpusReceiverConfig {
+	return &OggOpusReceiverConfig{
+		Channels: 2,
+	}
+}
+
+// OggOpusReceiverConfig is used to configure an OggOpusReceiver.
+type OggOpusReceiverConfig struct {
+	// Channels is the channel count written into the OpusHead of every stream. Discord sends stereo opus by default.
+	Channels int
+}
+
+// OggOpusReceiverConfigOpt is used to functionally configure an OggOpusReceiverConfig.
+type OggOpusReceiverConfigOpt func(config *OggOpusReceiverConfig)
+
+// Apply applies the OggOpusReceiverConfigOpt(s) to the OggOpusReceiverConfig.
+func (c *OggOpusReceiverConfig) Apply(opts []OggOpusReceiverConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithOggOpusReceiverChannels sets the channel count of the Ogg Opus streams written by the OggOpusReceiver.
+func WithOggOpusReceiverChannels(channels int) OggOpusReceiverConfigOpt {
+	return func(config *OggOpusReceiverConfig) {
+		config.Channels = channels
+	}
+}
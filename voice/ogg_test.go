@@ -0,0 +1,101 @@
+package voice
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func readAllPackets(t *testing.T, r *OggReader) [][]byte {
+	t.Helper()
+	var packets [][]byte
+	for {
+		packet, err := r.ProvideOpusFrame()
+		if errors.Is(err, io.EOF) {
+			return packets
+		}
+		require.NoError(t, err)
+		packets = append(packets, packet)
+	}
+}
+
+func TestOggRoundTrip(t *testing.T) {
+	packets := [][]byte{
+		[]byte("first"),
+		// exactly one full segment, needs a terminating 0 lacing value
+		bytes.Repeat([]byte{1}, oggMaxSegmentSize),
+		// spans several segments
+		bytes.Repeat([]byte{2}, 3*oggMaxSegmentSize+10),
+		// spans several pages
+		bytes.Repeat([]byte{3}, oggMaxSegments*oggMaxSegmentSize+100),
+		[]byte("last"),
+	}
+
+	var buf bytes.Buffer
+	writer := NewOggWriter(&buf, 1234, 1)
+	for _, packet := range packets {
+		require.NoError(t, writer.WritePacket(packet, OpusFrameSize))
+	}
+	require.NoError(t, writer.Close())
+	assert.Equal(t, uint64(len(packets)*OpusFrameSize), writer.Granule())
+
+	reader := NewOggReader(bytes.NewReader(buf.Bytes()))
+	assert.Equal(t, packets, readAllPackets(t, reader))
+	assert.Equal(t, 1, reader.Channels())
+	assert.Equal(t, uint64(len(packets)*OpusFrameSize), reader.Granule())
+}
+
+func TestOggChecksum(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewOggWriter(&buf, 1, 2)
+	require.NoError(t, writer.WritePacket([]byte("opus frame"), OpusFrameSize))
+	require.NoError(t, writer.Close())
+
+	data := buf.Bytes()
+	// flip a bit in the payload of the last page
+	data[len(data)-1] ^= 0xff
+
+	reader := NewOggReader(bytes.NewReader(data))
+	_, err := reader.ProvideOpusFrame()
+	assert.ErrorIs(t, err, ErrInvalidOggChecksum)
+}
+
+func TestOggNotOpusStream(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewOggWriter(&buf, 1, 2)
+	// write a page with a packet which is not an OpusHead
+	writer.packets = []oggPacket{{data: []byte("NotOpus, just some data")}}
+	require.NoError(t, writer.flush(oggHeaderTypeBOS))
+
+	_, err := NewOggReader(bytes.NewReader(buf.Bytes())).ProvideOpusFrame()
+	assert.ErrorIs(t, err, ErrNotOpusStream)
+}
+
+func TestOggOpusReceiver(t *testing.T) {
+	var buf bytes.Buffer
+	receiver := NewOggOpusReceiver(func(userID snowflake.ID) (io.WriteCloser, error) {
+		return nopWriteCloser{Writer: &buf}, nil
+	}, nil, WithOggOpusReceiverChannels(1))
+
+	// the packet with timestamp 2*OpusFrameSize was lost and is replaced with silence
+	for _, timestamp := range []uint32{0, uint32(OpusFrameSize), 3 * uint32(OpusFrameSize)} {
+		require.NoError(t, receiver.ReceiveOpusFrame(1, &Packet{SSRC: 1, Timestamp: timestamp, Opus: []byte("opus frame")}))
+	}
+	receiver.Close()
+
+	reader := NewOggReader(bytes.NewReader(buf.Bytes()))
+	packets := readAllPackets(t, reader)
+	assert.Equal(t, 1, reader.Channels(), "the channel count should be taken from the config")
+	assert.Equal(t, [][]byte{[]byte("opus frame"), []byte("opus frame"), SilenceAudioFrame, []byte("opus frame")}, packets)
+}
@@ -0,0 +1,405 @@
+package voice
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// ErrNoTrackPlaying is returned when an action requires a playing Track.
+var ErrNoTrackPlaying = errors.New("no track playing")
+
+var _ OpusFrameProvider = (Player)(nil)
+
+// OpcodePlayerEvent is not sent by discord. It is used to pass the PlayerEvent(s) of a Player to the EventHandlerFunc of its Conn.
+const OpcodePlayerEvent Opcode = -1
+
+type (
+	// Player is an OpusFrameProvider which plays a queue of Track(s) and supports pausing, skipping & seeking.
+	// It should be passed to Conn.SetOpusFrameProvider. While the Player is paused or has nothing to play it provides no frames,
+	// so the AudioSender sends silence & updates the speaking state. This also happens between two Track(s).
+	//
+	// The PlayerEvent(s) are passed to the EventHandlerFunc of the Conn with OpcodePlayerEvent.
+	// The EventHandlerFunc is called from the goroutine sending the audio, so it should not block.
+	Player interface {
+		OpusFrameProvider
+
+		// Play replaces the playing Track with the given Track without changing the queue.
+		Play(track Track)
+
+		// Enqueue adds the Track(s) to the end of the queue. The first Track is played right away if nothing is playing.
+		Enqueue(tracks ...Track)
+
+		// Queue returns the queued Track(s) without the playing Track.
+		Queue() []Track
+
+		// ClearQueue removes & closes all queued Track(s).
+		ClearQueue()
+
+		// PlayingTrack returns the playing Track or nil.
+		PlayingTrack() Track
+
+		// Skip ends the playing Track and starts the next queued Track.
+		Skip()
+
+		// Stop ends the playing Track and clears the queue.
+		Stop()
+
+		// Pause pauses the playback.
+		Pause()
+
+		// Resume resumes the playback.
+		Resume()
+
+		// Paused returns whether the playback is paused.
+		Paused() bool
+
+		// Seek moves the playing Track to the given frame index. See FramesToDuration & DurationToFrames.
+		Seek(frame int) error
+
+		// Position returns the frame index of the playing Track.
+		Position() int
+	}
+)
+
+// TrackEndReason is the reason a Track stopped playing.
+type TrackEndReason int
+
+// All TrackEndReason(s).
+const (
+	// TrackEndReasonFinished is used when the Track played until its end.
+	TrackEndReasonFinished TrackEndReason = iota
+	// TrackEndReasonError is used when the Track returned an error.
+	TrackEndReasonError
+	// TrackEndReasonSkipped is used when the Track was skipped.
+	TrackEndReasonSkipped
+	// TrackEndReasonReplaced is used when the Track was replaced by Player.Play.
+	TrackEndReasonReplaced
+	// TrackEndReasonStopped is used when the Player was stopped.
+	TrackEndReasonStopped
+	// TrackEndReasonCleanup is used when the Player was closed.
+	TrackEndReasonCleanup
+)
+
+func (r TrackEndReason) String() string {
+	switch r {
+	case TrackEndReasonFinished:
+		return "finished"
+	case TrackEndReasonError:
+		return "error"
+	case TrackEndReasonSkipped:
+		return "skipped"
+	case TrackEndReasonReplaced:
+		return "replaced"
+	case TrackEndReasonStopped:
+		return "stopped"
+	case TrackEndReasonCleanup:
+		return "cleanup"
+	default:
+		return "unknown"
+	}
+}
+
+// PlayerEvent is an event emitted by a Player.
+type PlayerEvent interface {
+	GatewayMessageData
+	playerEvent()
+}
+
+// TrackStartEvent is emitted when a Track starts playing.
+type TrackStartEvent struct {
+	Track Track
+}
+
+func (TrackStartEvent) voiceGatewayMessageData() {}
+func (TrackStartEvent) playerEvent()             {}
+
+// TrackEndEvent is emitted when a Track stopped playing.
+type TrackEndEvent struct {
+	Track  Track
+	Reason TrackEndReason
+}
+
+func (TrackEndEvent) voiceGatewayMessageData() {}
+func (TrackEndEvent) playerEvent()             {}
+
+// TrackExceptionEvent is emitted when a Track returned an error. It is followed by a TrackEndEvent with TrackEndReasonError.
+type TrackExceptionEvent struct {
+	Track Track
+	Err   error
+}
+
+func (TrackExceptionEvent) voiceGatewayMessageData() {}
+func (TrackExceptionEvent) playerEvent()             {}
+
+// PlayerPauseEvent is emitted when the Player was paused.
+type PlayerPauseEvent struct{}
+
+func (PlayerPauseEvent) voiceGatewayMessageData() {}
+func (PlayerPauseEvent) playerEvent()             {}
+
+// PlayerResumeEvent is emitted when the Player was resumed.
+type PlayerResumeEvent struct{}
+
+func (PlayerResumeEvent) voiceGatewayMessageData() {}
+func (PlayerResumeEvent) playerEvent()             {}
+
+// eventDispatcher is implemented by Conn(s) which pass events to their EventHandlerFunc.
+type eventDispatcher interface {
+	dispatchEvent(op Opcode, data GatewayMessageData)
+}
+
+// NewPlayer returns a new Player emitting its PlayerEvent(s) to the EventHandlerFunc of the given Conn.
+func NewPlayer(conn Conn, opts ...PlayerConfigOpt) Player {
+	config := DefaultPlayerConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "voice_player"))
+
+	return &playerImpl{
+		config: *config,
+		conn:   conn,
+	}
+}
+
+type playerImpl struct {
+	config PlayerConfig
+	conn   Conn
+
+	mu     sync.Mutex
+	track  Track
+	queue  []Track
+	paused bool
+	// gap is the number of frames left without audio before the next Track starts,
+	// so the AudioSender sends silence & clears the speaking state between two Track(s)
+	gap int
+	// events are collected while holding mu & dispatched after releasing it
+	events []PlayerEvent
+
+	// seekMu is held while seeking. The seeking Track is read without holding mu
+	seekMu sync.Mutex
+	// seeking is the Track which is seeking, it provides no frames until the seek finished
+	seeking   Track
+	seekFrame int
+}
+
+func (p *playerImpl) ProvideOpusFrame() ([]byte, error) {
+	p.mu.Lock()
+	frame := p.provideOpusFrame()
+	events := p.takeEvents()
+	p.mu.Unlock()
+
+	p.dispatch(events)
+	return frame, nil
+}
+
+func (p *playerImpl) provideOpusFrame() []byte {
+	if p.paused {
+		return nil
+	}
+	for {
+		if p.track == nil {
+			if p.gap > 0 {
+				p.gap--
+				return nil
+			}
+			if len(p.queue) == 0 {
+				return nil
+			}
+			p.start(p.queue[0])
+			p.queue = p.queue[1:]
+		}
+		if p.track == p.seeking {
+			return nil
+		}
+
+		frame, err := p.track.ProvideOpusFrame()
+		if err == nil {
+			return frame
+		}
+		if errors.Is(err, io.EOF) {
+			p.end(TrackEndReasonFinished)
+			continue
+		}
+		p.config.Logger.Error("error while providing opus frame", slog.String("err", err.Error()))
+		p.events = append(p.events, TrackExceptionEvent{
+			Track: p.track,
+			Err:   err,
+		})
+		p.end(TrackEndReasonError)
+	}
+}
+
+func (p *playerImpl) start(track Track) {
+	p.track = track
+	p.events = append(p.events, TrackStartEvent{Track: track})
+}
+
+func (p *playerImpl) end(reason TrackEndReason) {
+	if p.track == nil {
+		return
+	}
+	track := p.track
+	p.track = nil
+	// the AudioSender sends its silence frames & clears the speaking state before the next Track starts
+	p.gap = silenceFrames + 1
+	if track != p.seeking {
+		// the seeking Track is closed by Seek
+		track.Close()
+	}
+	p.events = append(p.events, TrackEndEvent{
+		Track:  track,
+		Reason: reason,
+	})
+}
+
+func (p *playerImpl) takeEvents() []PlayerEvent {
+	events := p.events
+	p.events = nil
+	return events
+}
+
+func (p *playerImpl) dispatch(events []PlayerEvent) {
+	dispatcher, ok := p.conn.(eventDispatcher)
+	if !ok {
+		return
+	}
+	for _, event := range events {
+		dispatcher.dispatchEvent(OpcodePlayerEvent, event)
+	}
+}
+
+// do runs the given func while holding the lock & dispatches the collected events afterwards.
+func (p *playerImpl) do(f func()) {
+	p.mu.Lock()
+	f()
+	events := p.takeEvents()
+	p.mu.Unlock()
+	p.dispatch(events)
+}
+
+func (p *playerImpl) Play(track Track) {
+	p.do(func() {
+		p.end(TrackEndReasonReplaced)
+		p.start(track)
+	})
+}
+
+func (p *playerImpl) Enqueue(tracks ...Track) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queue = append(p.queue, tracks...)
+}
+
+func (p *playerImpl) Queue() []Track {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	queue := make([]Track, len(p.queue))
+	copy(queue, p.queue)
+	return queue
+}
+
+func (p *playerImpl) ClearQueue() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clearQueue()
+}
+
+func (p *playerImpl) clearQueue() {
+	for _, track := range p.queue {
+		track.Close()
+	}
+	p.queue = nil
+}
+
+func (p *playerImpl) PlayingTrack() Track {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.track
+}
+
+func (p *playerImpl) Skip() {
+	p.do(func() {
+		p.end(TrackEndReasonSkipped)
+	})
+}
+
+func (p *playerImpl) Stop() {
+	p.do(func() {
+		p.end(TrackEndReasonStopped)
+		p.clearQueue()
+	})
+}
+
+func (p *playerImpl) Pause() {
+	p.do(func() {
+		if p.paused {
+			return
+		}
+		p.paused = true
+		p.events = append(p.events, PlayerPauseEvent{})
+	})
+}
+
+func (p *playerImpl) Resume() {
+	p.do(func() {
+		if !p.paused {
+			return
+		}
+		p.paused = false
+		p.events = append(p.events, PlayerResumeEvent{})
+	})
+}
+
+func (p *playerImpl) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+func (p *playerImpl) Seek(frame int) error {
+	p.seekMu.Lock()
+	defer p.seekMu.Unlock()
+
+	p.mu.Lock()
+	track := p.track
+	if track == nil {
+		p.mu.Unlock()
+		return ErrNoTrackPlaying
+	}
+	p.seeking = track
+	p.seekFrame = frame
+	p.mu.Unlock()
+
+	// seeking may read the Track forward, don't block the AudioSender meanwhile
+	err := track.Seek(frame)
+
+	p.mu.Lock()
+	p.seeking = nil
+	ended := p.track != track
+	p.mu.Unlock()
+	if ended {
+		track.Close()
+	}
+	return err
+}
+
+func (p *playerImpl) Position() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.track == nil {
+		return 0
+	}
+	if p.track == p.seeking {
+		return p.seekFrame
+	}
+	return p.track.Position()
+}
+
+// Close ends the playing Track & closes all queued Track(s).
+func (p *playerImpl) Close() {
+	p.do(func() {
+		p.end(TrackEndReasonCleanup)
+		p.clearQueue()
+	})
+}
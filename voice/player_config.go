@@ -0,0 +1,34 @@
+package voice
+
+import (
+	"log/slog"
+)
+
+// DefaultPlayerConfig returns a PlayerConfig with sensible defaults.
+func DefaultPlayerConfig() *PlayerConfig {
+	return &PlayerConfig{
+		Logger: slog.Default(),
+	}
+}
+
+// PlayerConfig is used to configure a Player.
+type PlayerConfig struct {
+	Logger *slog.Logger
+}
+
+// PlayerConfigOpt is used to functionally configure a PlayerConfig.
+type PlayerConfigOpt func(config *PlayerConfig)
+
+// Apply applies the PlayerConfigOpt(s) to the PlayerConfig.
+func (c *PlayerConfig) Apply(opts []PlayerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithPlayerLogger sets the Player(s) used Logger.
+func WithPlayerLogger(logger *slog.Logger) PlayerConfigOpt {
+	return func(config *PlayerConfig) {
+		config.Logger = logger
+	}
+}
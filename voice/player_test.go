@@ -0,0 +1,144 @@
+package voice
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type playerEventRecorder struct {
+	mu     sync.Mutex
+	events []PlayerEvent
+}
+
+func (r *playerEventRecorder) handle(op Opcode, data GatewayMessageData) {
+	if op != OpcodePlayerEvent {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, data.(PlayerEvent))
+}
+
+func (r *playerEventRecorder) take() []PlayerEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := r.events
+	r.events = nil
+	return events
+}
+
+func newTestPlayer(t *testing.T) (Player, *playerEventRecorder) {
+	t.Helper()
+	recorder := &playerEventRecorder{}
+	conn := newTestConn(t, "voice.discord.media", GatewayMessageDataReady{}, WithConnEventHandlerFunc(recorder.handle))
+	return NewPlayer(conn), recorder
+}
+
+func provideFrames(t *testing.T, p Player, n int) [][]byte {
+	t.Helper()
+	frames := make([][]byte, n)
+	for i := range frames {
+		frame, err := p.ProvideOpusFrame()
+		require.NoError(t, err)
+		frames[i] = frame
+	}
+	return frames
+}
+
+func TestPlayerQueue(t *testing.T) {
+	p, recorder := newTestPlayer(t)
+	first := NewFrameTrack([][]byte{{1}, {2}})
+	second := NewFrameTrack([][]byte{{3}})
+
+	p.Enqueue(first, second)
+	assert.Equal(t, []Track{first, second}, p.Queue())
+
+	assert.Equal(t, [][]byte{{1}, {2}}, provideFrames(t, p, 2))
+	assert.Equal(t, []Track{second}, p.Queue())
+	assert.Equal(t, []PlayerEvent{TrackStartEvent{Track: first}}, recorder.take())
+
+	// between two tracks no audio is provided, so the AudioSender sends silence & clears the speaking state
+	gap := provideFrames(t, p, silenceFrames+1)
+	for _, frame := range gap {
+		assert.Nil(t, frame)
+	}
+	assert.Equal(t, []PlayerEvent{TrackEndEvent{Track: first, Reason: TrackEndReasonFinished}}, recorder.take())
+
+	assert.Equal(t, [][]byte{{3}}, provideFrames(t, p, 1))
+	assert.Equal(t, []PlayerEvent{TrackStartEvent{Track: second}}, recorder.take())
+
+	p.Skip()
+	assert.Nil(t, p.PlayingTrack())
+	assert.Equal(t, []PlayerEvent{TrackEndEvent{Track: second, Reason: TrackEndReasonSkipped}}, recorder.take())
+}
+
+func TestPlayerPause(t *testing.T) {
+	p, recorder := newTestPlayer(t)
+	track := NewFrameTrack([][]byte{{1}, {2}})
+	p.Play(track)
+
+	assert.Equal(t, [][]byte{{1}}, provideFrames(t, p, 1))
+	p.Pause()
+	assert.True(t, p.Paused())
+	assert.Equal(t, [][]byte{nil, nil}, provideFrames(t, p, 2), "a paused player should not provide frames")
+
+	p.Resume()
+	assert.False(t, p.Paused())
+	assert.Equal(t, [][]byte{{2}}, provideFrames(t, p, 1))
+	assert.Equal(t, []PlayerEvent{TrackStartEvent{Track: track}, PlayerPauseEvent{}, PlayerResumeEvent{}}, recorder.take())
+}
+
+// blockingTrack is a Track whose Seek blocks until release is closed.
+type blockingTrack struct {
+	*FrameTrack
+	seeking chan struct{}
+	release chan struct{}
+}
+
+func (t *blockingTrack) Seek(frame int) error {
+	close(t.seeking)
+	<-t.release
+	return t.FrameTrack.Seek(frame)
+}
+
+func TestPlayerSeek(t *testing.T) {
+	p, _ := newTestPlayer(t)
+	track := &blockingTrack{
+		FrameTrack: NewFrameTrack([][]byte{{0}, {1}, {2}, {3}}),
+		seeking:    make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	p.Play(track)
+	assert.Equal(t, [][]byte{{0}}, provideFrames(t, p, 1))
+
+	seekErr := make(chan error)
+	go func() {
+		seekErr <- p.Seek(3)
+	}()
+	<-track.seeking
+
+	// the AudioSender is not blocked while the Track is seeking
+	done := make(chan []byte)
+	go func() {
+		frame, _ := p.ProvideOpusFrame()
+		done <- frame
+	}()
+	select {
+	case frame := <-done:
+		assert.Nil(t, frame)
+	case <-time.After(time.Second):
+		t.Fatal("ProvideOpusFrame blocked while seeking")
+	}
+	assert.Equal(t, 3, p.Position())
+
+	close(track.release)
+	require.NoError(t, <-seekErr)
+	assert.Equal(t, [][]byte{{3}}, provideFrames(t, p, 1))
+
+	p.Stop()
+	assert.ErrorIs(t, p.Seek(0), ErrNoTrackPlaying)
+}
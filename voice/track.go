@@ -0,0 +1,182 @@
+package voice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// ErrSeekNotSupported is returned when a Track can not seek to the requested frame.
+var ErrSeekNotSupported = errors.New("seek not supported")
+
+// Track is a seekable source of 20ms opus frames which can be played by a Player.
+type Track interface {
+	OpusFrameProvider
+
+	// Seek moves the Track to the given frame index. The next call to ProvideOpusFrame returns the frame at this index.
+	Seek(frame int) error
+
+	// Position returns the index of the next frame returned by ProvideOpusFrame.
+	Position() int
+}
+
+// FramesToDuration converts the given number of 20ms opus frames to a time.Duration.
+func FramesToDuration(frames int) time.Duration {
+	return time.Duration(frames) * time.Duration(OpusFrameSizeMs) * time.Millisecond
+}
+
+// DurationToFrames converts the given time.Duration to a number of 20ms opus frames.
+func DurationToFrames(d time.Duration) int {
+	return int(d / (time.Duration(OpusFrameSizeMs) * time.Millisecond))
+}
+
+var _ Track = (*FrameTrack)(nil)
+
+// NewFrameTrack returns a new FrameTrack playing the given opus frames.
+func NewFrameTrack(frames [][]byte) *FrameTrack {
+	return &FrameTrack{
+		frames: frames,
+	}
+}
+
+// FrameTrack is a Track playing opus frames held in memory.
+type FrameTrack struct {
+	frames   [][]byte
+	position int
+}
+
+// ProvideOpusFrame returns the next frame or io.EOF once all frames were played.
+func (t *FrameTrack) ProvideOpusFrame() ([]byte, error) {
+	if t.position >= len(t.frames) {
+		return nil, io.EOF
+	}
+	frame := t.frames[t.position]
+	t.position++
+	return frame, nil
+}
+
+// Seek moves the FrameTrack to the given frame index.
+func (t *FrameTrack) Seek(frame int) error {
+	if frame < 0 || frame > len(t.frames) {
+		return fmt.Errorf("frame %d out of range [0, %d]", frame, len(t.frames))
+	}
+	t.position = frame
+	return nil
+}
+
+// Position returns the index of the next frame.
+func (t *FrameTrack) Position() int {
+	return t.position
+}
+
+// Len returns the number of frames of the FrameTrack.
+func (t *FrameTrack) Len() int {
+	return len(t.frames)
+}
+
+// Close is a no-op.
+func (*FrameTrack) Close() {}
+
+var _ Track = (*OggTrack)(nil)
+
+// NewOggTrack returns a new OggTrack playing the Ogg Opus stream of the given io.ReadSeeker starting at its current offset.
+// If the io.ReadSeeker implements io.Closer it is closed with the OggTrack.
+func NewOggTrack(rs io.ReadSeeker) (*OggTrack, error) {
+	start, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting offset of ogg stream: %w", err)
+	}
+	t := &OggTrack{
+		rs:     rs,
+		start:  start,
+		reader: NewOggReader(rs),
+	}
+	t.reader.offset = start
+	t.reader.pageFunc = t.onPage
+	return t, nil
+}
+
+// OggTrack is a Track playing an Ogg Opus stream from an io.ReadSeeker.
+// While playing, the offsets of the pages are indexed, so seeking backwards or to already played frames does not need to read the stream from the start.
+type OggTrack struct {
+	rs       io.ReadSeeker
+	start    int64
+	reader   *OggReader
+	position int
+	// seekPoints are the offsets of pages which start with a new packet & the index of the first frame on them sorted by frame
+	seekPoints []oggSeekPoint
+}
+
+type oggSeekPoint struct {
+	offset int64
+	frame  int
+}
+
+func (t *OggTrack) onPage(offset int64, page *oggPage) {
+	if t.reader.headers < 2 || page.headerType&oggHeaderTypeContinued != 0 {
+		return
+	}
+	if len(t.seekPoints) > 0 && t.seekPoints[len(t.seekPoints)-1].offset >= offset {
+		return
+	}
+	t.seekPoints = append(t.seekPoints, oggSeekPoint{
+		offset: offset,
+		frame:  t.position,
+	})
+}
+
+// ProvideOpusFrame returns the next opus frame or io.EOF once the stream ended.
+func (t *OggTrack) ProvideOpusFrame() ([]byte, error) {
+	frame, err := t.reader.ProvideOpusFrame()
+	if err != nil {
+		return nil, err
+	}
+	t.position++
+	return frame, nil
+}
+
+// Seek moves the OggTrack to the given frame index. Seeking past the end of the stream returns io.EOF.
+func (t *OggTrack) Seek(frame int) error {
+	if frame < 0 {
+		return fmt.Errorf("frame %d out of range", frame)
+	}
+
+	// find the closest known page before the frame
+	i := sort.Search(len(t.seekPoints), func(i int) bool {
+		return t.seekPoints[i].frame > frame
+	}) - 1
+	offset, position, keepHeaders := t.start, 0, false
+	if i >= 0 {
+		offset, position, keepHeaders = t.seekPoints[i].offset, t.seekPoints[i].frame, true
+	}
+
+	// only seek if reading forward from the current position is not possible or slower
+	if frame < t.position || position > t.position {
+		if _, err := t.rs.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("error while seeking ogg stream: %w", err)
+		}
+		t.reader.reset(offset, keepHeaders)
+		t.position = position
+	}
+
+	for t.position < frame {
+		if _, err := t.ProvideOpusFrame(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Position returns the index of the next frame.
+func (t *OggTrack) Position() int {
+	return t.position
+}
+
+// Close closes the underlying io.ReadSeeker if it implements io.Closer.
+func (t *OggTrack) Close() {
+	if closer, ok := t.rs.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
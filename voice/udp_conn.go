@@ -12,8 +12,6 @@ import (
 	"strings"
 	"sync"
 	"time"
-
-	"golang.org/x/crypto/nacl/secretbox"
 )
 
 const (
@@ -24,8 +22,13 @@ const (
 	UDPTimeout = 30 * time.Second
 )
 
-// ErrDecryptionFailed is returned when the packet decryption fails.
-var ErrDecryptionFailed = errors.New("decryption failed")
+var (
+	// ErrDecryptionFailed is returned when the packet decryption fails.
+	ErrDecryptionFailed = errors.New("decryption failed")
+
+	// ErrNoSecretKey is returned when a packet is sent or received before the secret key was set.
+	ErrNoSecretKey = errors.New("no secret key set")
+)
 
 var (
 	_ io.Reader      = (UDPConn)(nil)
@@ -48,8 +51,8 @@ type (
 		// RemoteAddr returns the remote network address, if known.
 		RemoteAddr() net.Addr
 
-		// SetSecretKey sets the secret key used to encrypt packets.
-		SetSecretKey(secretKey [32]byte)
+		// SetSecretKey sets the EncryptionMode & secret key used to encrypt & decrypt packets.
+		SetSecretKey(mode EncryptionMode, secretKey [32]byte) error
 
 		SetDeadline(t time.Time) error
 
@@ -107,13 +110,12 @@ type udpConnImpl struct {
 	connMu sync.Mutex
 
 	packet    [12]byte
-	secretKey [32]byte
+	encrypter encrypter
 
 	sequence  uint16
 	timestamp uint32
-	nonce     [24]byte
 
-	receiveNonce  [24]byte
+	sendBuffer    []byte
 	receiveBuffer []byte
 }
 
@@ -129,8 +131,15 @@ func (u *udpConnImpl) RemoteAddr() net.Addr {
 	return u.conn.RemoteAddr()
 }
 
-func (u *udpConnImpl) SetSecretKey(secretKey [32]byte) {
-	u.secretKey = secretKey
+func (u *udpConnImpl) SetSecretKey(mode EncryptionMode, secretKey [32]byte) error {
+	encrypter, err := newEncrypter(mode, secretKey)
+	if err != nil {
+		return err
+	}
+	u.connMu.Lock()
+	defer u.connMu.Unlock()
+	u.encrypter = encrypter
+	return nil
 }
 
 func (u *udpConnImpl) SetDeadline(t time.Time) error {
@@ -156,6 +165,12 @@ func (u *udpConnImpl) Open(ctx context.Context, ip string, port int, ssrc uint32
 	defer u.connMu.Unlock()
 	host := net.JoinHostPort(ip, strconv.Itoa(port))
 	u.config.Logger.Debug("Opening UDPConn connection", slog.String("host", host))
+	// close the previous connection when we are reconnecting & drop its key, a new one is negotiated for the new session
+	if u.conn != nil {
+		_ = u.conn.Close()
+	}
+	u.encrypter = nil
+
 	var err error
 	u.conn, err = u.config.Dialer.DialContext(ctx, "udp", host)
 	if err != nil {
@@ -225,13 +240,19 @@ func (u *udpConnImpl) Write(p []byte) (int, error) {
 	binary.BigEndian.PutUint32(u.packet[4:8], u.timestamp)
 	u.timestamp += 960
 
-	// Copy the first 12 bytes from the packet into the nonce.
-	copy(u.nonce[:12], u.packet[:])
-
 	u.connMu.Lock()
 	conn := u.conn
+	encrypter := u.encrypter
 	u.connMu.Unlock()
-	if _, err := conn.Write(secretbox.Seal(u.packet[:], p, &u.nonce, &u.secretKey)); err != nil {
+	if conn == nil {
+		return 0, net.ErrClosed
+	}
+	if encrypter == nil {
+		return 0, ErrNoSecretKey
+	}
+
+	u.sendBuffer = encrypter.Seal(u.sendBuffer[:0], u.packet[:], p)
+	if _, err := conn.Write(u.sendBuffer); err != nil {
 		return 0, fmt.Errorf("failed to write packet: %w", err)
 	}
 	return len(p), nil
@@ -248,7 +269,11 @@ func (u *udpConnImpl) Read(p []byte) (n int, err error) {
 func (u *udpConnImpl) ReadPacket() (*Packet, error) {
 	u.connMu.Lock()
 	conn := u.conn
+	encrypter := u.encrypter
 	u.connMu.Unlock()
+	if conn == nil {
+		return nil, net.ErrClosed
+	}
 
 	for {
 		i, err := conn.Read(u.receiveBuffer)
@@ -258,29 +283,51 @@ func (u *udpConnImpl) ReadPacket() (*Packet, error) {
 		if i < OpusPacketHeaderSize || (u.receiveBuffer[0] != 0x80 && u.receiveBuffer[0] != 0x90) || (u.receiveBuffer[1] != 0x78 && u.receiveBuffer[1] != 0x80) {
 			continue
 		}
-
-		copy(u.receiveNonce[:], u.receiveBuffer[0:OpusPacketHeaderSize])
-
-		opus, ok := secretbox.Open(nil, u.receiveBuffer[OpusPacketHeaderSize:i], &u.receiveNonce, &u.secretKey)
-		if !ok {
-			return nil, ErrDecryptionFailed
+		if encrypter == nil {
+			return nil, ErrNoSecretKey
 		}
 
-		isExtension := u.receiveBuffer[0]&0x10 == 0x10
-		isMarker := u.receiveBuffer[1]&0x80 != 0x0
-
-		if isExtension && !isMarker {
-			extLen := binary.BigEndian.Uint16(opus[2:4])
-			shift := 4 + 4*int(extLen)
+		packet := u.receiveBuffer[:i]
+		isExtension := packet[0]&0x10 == 0x10
+		isMarker := packet[1]&0x80 != 0x0
+
+		var opus []byte
+		if encrypter.RTPSize() {
+			// the rtpsize modes only encrypt the payload & the extension data, the extension header is part of the additional data
+			headerSize := OpusPacketHeaderSize + 4*int(packet[0]&0x0F)
+			var extLen int
+			if isExtension {
+				if len(packet) < headerSize+4 {
+					continue
+				}
+				extLen = int(binary.BigEndian.Uint16(packet[headerSize+2 : headerSize+4]))
+				headerSize += 4
+			}
+			if opus, err = encrypter.Open(nil, packet, headerSize); err != nil {
+				return nil, err
+			}
+			if len(opus) < 4*extLen {
+				return nil, ErrDecryptionFailed
+			}
+			opus = opus[4*extLen:]
+		} else {
+			if opus, err = encrypter.Open(nil, packet, OpusPacketHeaderSize); err != nil {
+				return nil, err
+			}
+			if isExtension && !isMarker && len(opus) >= 4 {
+				extLen := binary.BigEndian.Uint16(opus[2:4])
+				shift := 4 + 4*int(extLen)
 
-			if len(opus) > shift {
-				opus = opus[shift:]
+				if len(opus) > shift {
+					opus = opus[shift:]
+				}
 			}
 		}
+
 		return &Packet{
-			Sequence:  binary.BigEndian.Uint16(u.receiveBuffer[2:4]),
-			Timestamp: binary.BigEndian.Uint32(u.receiveBuffer[4:8]),
-			SSRC:      binary.BigEndian.Uint32(u.receiveBuffer[8:12]),
+			Sequence:  binary.BigEndian.Uint16(packet[2:4]),
+			Timestamp: binary.BigEndian.Uint32(packet[4:8]),
+			SSRC:      binary.BigEndian.Uint32(packet[8:12]),
 			Opus:      opus,
 		}, nil
 	}
@@ -289,5 +336,11 @@ func (u *udpConnImpl) ReadPacket() (*Packet, error) {
 func (u *udpConnImpl) Close() error {
 	u.connMu.Lock()
 	defer u.connMu.Unlock()
-	return u.conn.Close()
+	if u.conn == nil {
+		return nil
+	}
+	err := u.conn.Close()
+	u.conn = nil
+	u.encrypter = nil
+	return err
 }